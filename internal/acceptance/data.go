@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/recording"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
 )
 
@@ -54,10 +55,27 @@ type TestData struct {
 
 	// resourceLabel is the local used for the resource - generally "test""
 	resourceLabel string
+
+	// random is used to generate random values when recording/replaying requests, since
+	// these values must be the same when the test is replayed
+	random *rand.Rand
 }
 
 // BuildTestData generates some test data for the given resource
+//
+// When the `ARM_TEST_RECORDING_MODE` Environment Variable is set to `record` the requests made
+// during this test are recorded (with any secrets and identifiers scrubbed), which can then be
+// replayed without access to Azure by setting this Environment Variable to `replay`.
 func BuildTestData(t *testing.T, resourceType string, resourceLabel string) TestData {
+	var cassette *recording.Cassette
+	if recording.Enabled() {
+		c, err := recording.Start(t)
+		if err != nil {
+			t.Fatalf("Error starting recording: %+v", err)
+		}
+		cassette = c
+	}
+
 	env, err := Environment()
 	if err != nil {
 		t.Fatalf("Error retrieving Environment: %+v", err)
@@ -85,6 +103,30 @@ func BuildTestData(t *testing.T, resourceType string, resourceLabel string) Test
 		}
 	}
 
+	if cassette != nil {
+		// the random values are taken from the recording when replaying, or when another set of
+		// Test Data has already been built for this test
+		if recording.CurrentMode() == recording.ModeReplay || cassette.RandomString != "" {
+			testData.RandomInteger = cassette.RandomInteger
+			testData.RandomString = cassette.RandomString
+			testData.Locations = Regions{
+				Primary:   cassette.Locations.Primary,
+				Secondary: cassette.Locations.Secondary,
+				Ternary:   cassette.Locations.Ternary,
+			}
+		} else {
+			cassette.RandomInteger = testData.RandomInteger
+			cassette.RandomString = testData.RandomString
+			cassette.Locations = recording.Locations{
+				Primary:   testData.Locations.Primary,
+				Secondary: testData.Locations.Secondary,
+				Ternary:   testData.Locations.Ternary,
+			}
+		}
+
+		testData.random = rand.New(rand.NewSource(int64(testData.RandomInteger)))
+	}
+
 	return testData
 }
 
@@ -123,6 +165,10 @@ func (td *TestData) RandomStringOfLength(len int) string {
 		panic("Invalid Test: RandomStringOfLength: length argument must be between 1 and 1024 characters")
 	}
 
+	if td.random != nil {
+		return randStringFromCharSetWithSource(td.random, len, charSetAlphaNum)
+	}
+
 	return randString(len)
 }
 
@@ -140,3 +186,13 @@ func randStringFromCharSet(strlen int, charSet string) string {
 	}
 	return string(result)
 }
+
+// randStringFromCharSetWithSource generates a random string by selecting characters from
+// the charset provided, using the specified source of randomness
+func randStringFromCharSetWithSource(random *rand.Rand, strlen int, charSet string) string {
+	result := make([]byte, strlen)
+	for i := 0; i < strlen; i++ {
+		result[i] = charSet[random.Intn(len(charSet))]
+	}
+	return string(result)
+}
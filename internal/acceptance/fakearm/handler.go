@@ -0,0 +1,328 @@
+package fakearm
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-uuid"
+)
+
+const operationsPath = "/fakearm/operations/"
+
+type operation struct {
+	remainingPolls int
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	// clients using a Resource ID append this to the endpoint (which ends with a `/`)
+	requestPath := "/" + strings.TrimLeft(r.URL.Path, "/")
+
+	s.requests = append(s.requests, Request{
+		Method: r.Method,
+		Path:   requestPath,
+	})
+
+	switch {
+	case requestPath == "/metadata/endpoints":
+		s.metadata(w)
+
+	case strings.HasPrefix(requestPath, operationsPath):
+		s.pollOperation(w, strings.TrimPrefix(requestPath, operationsPath))
+
+	case strings.HasPrefix(strings.ToLower(requestPath), "/subscriptions/"):
+		s.serveResourceManager(w, r, parsePath(requestPath))
+
+	default:
+		writeError(w, http.StatusNotFound, "NotFound", fmt.Sprintf("The path %q is not supported.", requestPath))
+	}
+}
+
+func (s *Server) serveResourceManager(w http.ResponseWriter, r *http.Request, path parsedPath) {
+
+	if path.isSubscription() {
+		writeJson(w, http.StatusOK, map[string]interface{}{
+			"id":             path.id(),
+			"subscriptionId": path.segments[1],
+			"displayName":    DefaultEnvironmentName,
+			"state":          "Enabled",
+		})
+		return
+	}
+
+	// Resource Providers are always registered
+	if path.isResourceProvider() || (r.Method == http.MethodPost && len(path.segments) == 5 && strings.EqualFold(path.segments[2], "providers")) {
+		writeJson(w, http.StatusOK, map[string]interface{}{
+			"id":                fmt.Sprintf("/subscriptions/%s/providers/%s", path.segments[1], path.segments[3]),
+			"namespace":         path.segments[3],
+			"registrationState": "Registered",
+		})
+		return
+	}
+
+	if !path.isResource() {
+		switch r.Method {
+		case http.MethodGet:
+			s.list(w, path)
+		case http.MethodPost:
+			// actions (e.g. `listKeys`) on a resource which exists succeed
+			if !s.ensureExists(w, path.action()) {
+				return
+			}
+			writeJson(w, http.StatusOK, map[string]interface{}{})
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", fmt.Sprintf("The method %q is not supported for %q.", r.Method, path.id()))
+		}
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		s.get(w, path)
+	case http.MethodHead:
+		s.head(w, path)
+	case http.MethodPut:
+		s.createOrUpdate(w, r, path)
+	case http.MethodPatch:
+		s.update(w, r, path)
+	case http.MethodDelete:
+		s.delete(w, path)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", fmt.Sprintf("The method %q is not supported for %q.", r.Method, path.id()))
+	}
+}
+
+func (s *Server) get(w http.ResponseWriter, path parsedPath) {
+	if !s.ensureExists(w, path) {
+		return
+	}
+
+	writeJson(w, http.StatusOK, s.resources[strings.ToLower(path.id())].body)
+}
+
+func (s *Server) head(w http.ResponseWriter, path parsedPath) {
+	if _, ok := s.resources[strings.ToLower(path.id())]; !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) list(w http.ResponseWriter, path parsedPath) {
+	prefix := strings.ToLower(path.id()) + "/"
+
+	ids := make([]string, 0)
+	for id := range s.resources {
+		// only direct children of this collection are returned
+		if strings.HasPrefix(id, prefix) && !strings.Contains(strings.TrimPrefix(id, prefix), "/") {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	values := make([]interface{}, 0)
+	for _, id := range ids {
+		values = append(values, s.resources[id].body)
+	}
+
+	writeJson(w, http.StatusOK, map[string]interface{}{
+		"value": values,
+	})
+}
+
+func (s *Server) createOrUpdate(w http.ResponseWriter, r *http.Request, path parsedPath) {
+	if !s.ensureParentExists(w, path) {
+		return
+	}
+
+	body := make(map[string]interface{})
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil && err != io.EOF {
+		writeError(w, http.StatusBadRequest, "InvalidRequestContent", fmt.Sprintf("The request content was invalid: %+v", err))
+		return
+	}
+
+	_, exists := s.resources[strings.ToLower(path.id())]
+	result := s.put(path, body)
+
+	if s.options.LongRunningOperations {
+		s.startOperation(w, http.StatusCreated)
+		writeJsonBody(w, result)
+		return
+	}
+
+	statusCode := http.StatusCreated
+	if exists {
+		statusCode = http.StatusOK
+	}
+	writeJson(w, statusCode, result)
+}
+
+func (s *Server) update(w http.ResponseWriter, r *http.Request, path parsedPath) {
+	if !s.ensureExists(w, path) {
+		return
+	}
+
+	patch := make(map[string]interface{})
+	if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidRequestContent", fmt.Sprintf("The request content was invalid: %+v", err))
+		return
+	}
+
+	// top-level fields are replaced, other than `properties` which is merged
+	existing := copyBody(s.resources[strings.ToLower(path.id())].body)
+	for k, v := range patch {
+		if k == "properties" {
+			existingProps, _ := existing[k].(map[string]interface{})
+			patchProps, _ := v.(map[string]interface{})
+			if existingProps != nil && patchProps != nil {
+				for pk, pv := range patchProps {
+					existingProps[pk] = pv
+				}
+				continue
+			}
+		}
+		existing[k] = v
+	}
+
+	writeJson(w, http.StatusOK, s.put(path, existing))
+}
+
+func (s *Server) delete(w http.ResponseWriter, path parsedPath) {
+	id := strings.ToLower(path.id())
+	if _, ok := s.resources[id]; !ok {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	// deleting a resource also deletes any nested resources (e.g. those within a Resource Group)
+	for k := range s.resources {
+		if k == id || strings.HasPrefix(k, id+"/") {
+			delete(s.resources, k)
+		}
+	}
+
+	if s.options.LongRunningOperations {
+		s.startOperation(w, http.StatusAccepted)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// startOperation writes the headers for a long-running operation which completes after the configured number of polls
+func (s *Server) startOperation(w http.ResponseWriter, statusCode int) {
+	operationId, err := uuid.GenerateUUID()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "InternalServerError", fmt.Sprintf("generating operation id: %+v", err))
+		return
+	}
+
+	s.operations[operationId] = &operation{
+		remainingPolls: s.options.PollCount,
+	}
+
+	w.Header().Set("Azure-AsyncOperation", fmt.Sprintf("%s%s%s", s.server.URL, operationsPath, operationId))
+	w.Header().Set("Retry-After", "1")
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+}
+
+func (s *Server) pollOperation(w http.ResponseWriter, operationId string) {
+	op, ok := s.operations[operationId]
+	if !ok {
+		writeError(w, http.StatusNotFound, "OperationNotFound", fmt.Sprintf("The operation %q was not found.", operationId))
+		return
+	}
+
+	if op.remainingPolls > 0 {
+		op.remainingPolls--
+		w.Header().Set("Retry-After", "1")
+		writeJson(w, http.StatusOK, map[string]interface{}{
+			"status": "InProgress",
+		})
+		return
+	}
+
+	writeJson(w, http.StatusOK, map[string]interface{}{
+		"status": "Succeeded",
+	})
+}
+
+// ensureExists writes a 404 (in the same format as Resource Manager) if the resource doesn't exist
+func (s *Server) ensureExists(w http.ResponseWriter, path parsedPath) bool {
+	if path.isSubscription() {
+		return true
+	}
+
+	if _, ok := s.resources[strings.ToLower(path.id())]; ok {
+		return true
+	}
+
+	if resourceGroupId, ok := s.resourceGroupMissing(path); ok {
+		writeError(w, http.StatusNotFound, "ResourceGroupNotFound", fmt.Sprintf("Resource group '%s' could not be found.", parsePath(resourceGroupId).name()))
+		return false
+	}
+
+	writeError(w, http.StatusNotFound, "ResourceNotFound", fmt.Sprintf("The Resource '%s/%s' was not found.", path.resourceType(), path.name()))
+	return false
+}
+
+// ensureParentExists writes a 404 (in the same format as Resource Manager) if the parent of the resource doesn't exist
+func (s *Server) ensureParentExists(w http.ResponseWriter, path parsedPath) bool {
+	if resourceGroupId, ok := s.resourceGroupMissing(path); ok {
+		writeError(w, http.StatusNotFound, "ResourceGroupNotFound", fmt.Sprintf("Resource group '%s' could not be found.", parsePath(resourceGroupId).name()))
+		return false
+	}
+
+	parent := path.parent()
+	if parent.isSubscription() || len(parent.segments) <= 4 {
+		return true
+	}
+
+	if _, ok := s.resources[strings.ToLower(parent.id())]; !ok {
+		writeError(w, http.StatusNotFound, "ParentResourceNotFound", fmt.Sprintf("Can not perform requested operation on nested resource. Parent resource '%s' not found.", parent.name()))
+		return false
+	}
+
+	return true
+}
+
+// resourceGroupMissing returns the ID of the Resource Group containing this resource, if it doesn't exist
+func (s *Server) resourceGroupMissing(path parsedPath) (string, bool) {
+	resourceGroupId, ok := path.resourceGroupId()
+	if !ok || strings.EqualFold(resourceGroupId, path.id()) {
+		return "", false
+	}
+
+	if _, exists := s.resources[strings.ToLower(resourceGroupId)]; exists {
+		return "", false
+	}
+
+	return resourceGroupId, true
+}
+
+func writeError(w http.ResponseWriter, statusCode int, code, message string) {
+	writeJson(w, statusCode, map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":    code,
+			"message": message,
+		},
+	})
+}
+
+func writeJson(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	writeJsonBody(w, body)
+}
+
+func writeJsonBody(w http.ResponseWriter, body interface{}) {
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,34 @@
+package fakearm
+
+import (
+	"net/http"
+)
+
+// metadata returns the Azure Environments supported by this Server, in the same format as the
+// `/metadata/endpoints` endpoint used by the `metadata_host` field in the Provider block
+func (s *Server) metadata(w http.ResponseWriter) {
+	endpoint := s.server.URL + "/"
+	writeJson(w, http.StatusOK, []interface{}{
+		map[string]interface{}{
+			"name":            DefaultEnvironmentName,
+			"portal":          endpoint,
+			"resourceManager": endpoint,
+			"graph":           endpoint,
+			"graphAudience":   endpoint,
+			"batch":           endpoint,
+			"gallery":         endpoint,
+			"authentication": map[string]interface{}{
+				"loginEndpoint":    endpoint,
+				"audiences":        []string{endpoint},
+				"tenant":           "common",
+				"identityProvider": "AAD",
+			},
+			"suffixes": map[string]interface{}{
+				"acrLoginServer":    "azurecr.io",
+				"keyVaultDns":       "vault.azure.net",
+				"sqlServerHostname": "database.windows.net",
+				"storage":           "core.windows.net",
+			},
+		},
+	})
+}
@@ -0,0 +1,108 @@
+package fakearm
+
+import (
+	"strings"
+)
+
+// parsedPath is a Resource Manager path split into its segments
+type parsedPath struct {
+	// segments are the segments within the path, e.g. [subscriptions, 000, resourceGroups, group1]
+	segments []string
+
+	// typeSegments are the segments excluding any `providers/{namespace}` markers, which are
+	// alternating type/name pairs for a resource and an odd number of segments for a collection
+	typeSegments []string
+
+	// namespace is the innermost Resource Provider, e.g. `Microsoft.Network`
+	namespace string
+}
+
+func parsePath(path string) parsedPath {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	typeSegments := make([]string, 0)
+	namespace := "Microsoft.Resources"
+	for i := 0; i < len(segments); i++ {
+		if strings.EqualFold(segments[i], "providers") && i+1 < len(segments) && i > 1 {
+			namespace = segments[i+1]
+			i++
+			continue
+		}
+
+		typeSegments = append(typeSegments, segments[i])
+	}
+
+	return parsedPath{
+		segments:     segments,
+		typeSegments: typeSegments,
+		namespace:    namespace,
+	}
+}
+
+// id returns the Resource ID in the casing it was specified
+func (p parsedPath) id() string {
+	return "/" + strings.Join(p.segments, "/")
+}
+
+// isResource returns whether this path refers to a single resource rather than a collection of resources
+func (p parsedPath) isResource() bool {
+	return len(p.typeSegments)%2 == 0
+}
+
+// isSubscription returns whether this path refers to the Subscription itself
+func (p parsedPath) isSubscription() bool {
+	return len(p.segments) == 2
+}
+
+// isResourceProvider returns whether this path refers to a Resource Provider, e.g. `/subscriptions/000/providers/Microsoft.Compute`
+func (p parsedPath) isResourceProvider() bool {
+	return len(p.segments) == 4 && strings.EqualFold(p.segments[2], "providers")
+}
+
+// name returns the name of the resource (or collection)
+func (p parsedPath) name() string {
+	return p.segments[len(p.segments)-1]
+}
+
+// resourceType returns the Resource Type, e.g. `Microsoft.Network/virtualNetworks/subnets`
+func (p parsedPath) resourceType() string {
+	if len(p.typeSegments) == 4 && strings.EqualFold(p.typeSegments[2], "resourceGroups") {
+		return "Microsoft.Resources/resourceGroups"
+	}
+
+	// the types are those following the innermost Resource Provider
+	types := make([]string, 0)
+	for i := len(p.segments) - 2; i >= 0; i -= 2 {
+		if strings.EqualFold(p.segments[i], "providers") {
+			break
+		}
+		types = append([]string{p.segments[i]}, types...)
+	}
+
+	return strings.Join(append([]string{p.namespace}, types...), "/")
+}
+
+// resourceGroupId returns the ID of the Resource Group containing this resource (if any)
+func (p parsedPath) resourceGroupId() (string, bool) {
+	if len(p.segments) < 4 || !strings.EqualFold(p.segments[2], "resourceGroups") {
+		return "", false
+	}
+
+	return "/" + strings.Join(p.segments[0:4], "/"), true
+}
+
+// parent returns the path of the parent resource, with any extension resource
+// markers (e.g. `providers/Microsoft.Insights`) removed
+func (p parsedPath) parent() parsedPath {
+	segments := p.segments[0 : len(p.segments)-2]
+	if len(segments) > 2 && strings.EqualFold(segments[len(segments)-2], "providers") {
+		segments = segments[0 : len(segments)-2]
+	}
+
+	return parsePath(strings.Join(segments, "/"))
+}
+
+// action returns the path of the resource which an action (e.g. `listKeys`) is being performed on
+func (p parsedPath) action() parsedPath {
+	return parsePath(strings.Join(p.segments[0:len(p.segments)-1], "/"))
+}
@@ -0,0 +1,213 @@
+// Package fakearm provides an in-memory stand-in for Azure Resource Manager, which the Provider's
+// clients can be pointed at (using Server.ClientOptions, or the `metadata_host` field in the
+// Provider block) to test logic such as the `requires import` checks without access to Azure.
+package fakearm
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+)
+
+const (
+	// DefaultEnvironmentName is the name of the Azure Environment returned from the metadata endpoint
+	DefaultEnvironmentName = "FakeARM"
+
+	// DefaultSubscriptionId is the Subscription ID used by ClientOptions
+	DefaultSubscriptionId = "00000000-0000-0000-0000-000000000000"
+
+	// DefaultTenantId is the Tenant ID used by ClientOptions
+	DefaultTenantId = "11111111-1111-1111-1111-111111111111"
+)
+
+type Options struct {
+	// LongRunningOperations specifies whether PUT and DELETE requests should be completed
+	// asynchronously, returning an `Azure-AsyncOperation` header which must be polled
+	LongRunningOperations bool
+
+	// PollCount is the number of times a long-running operation reports that it's
+	// `InProgress` before it completes
+	PollCount int
+}
+
+// Server is a stand-in for Azure Resource Manager which stores resources in memory,
+// allowing the Provider's clients to be tested without access to Azure.
+//
+// Resources are stored as-is (keyed by their case-insensitive Resource ID) with the `id`,
+// `name` and `type` fields populated - and requests for resources which don't exist (or whose
+// Resource Group/parent resource doesn't exist) return a 404 in the same format as Azure.
+type Server struct {
+	options Options
+	server  *httptest.Server
+
+	lock       sync.Mutex
+	resources  map[string]resource
+	operations map[string]*operation
+	requests   []Request
+}
+
+// Request is a request which was received by the Server
+type Request struct {
+	Method string
+	Path   string
+}
+
+type resource struct {
+	id   string
+	body map[string]interface{}
+}
+
+// New starts a new Server which is closed when the test completes
+func New(t *testing.T, options Options) *Server {
+	s := &Server{
+		options:    options,
+		resources:  make(map[string]resource),
+		operations: make(map[string]*operation),
+	}
+	s.server = httptest.NewTLSServer(http.HandlerFunc(s.serveHTTP))
+	t.Cleanup(s.server.Close)
+
+	return s
+}
+
+// URL returns the base URL of the Server, e.g. `https://127.0.0.1:1234`
+func (s *Server) URL() string {
+	return s.server.URL
+}
+
+// MetadataHost returns the host which can be used for the `metadata_host` field in the Provider block,
+// with the `environment` field set to DefaultEnvironmentName.
+//
+// NOTE: the certificate used by the Server must be trusted by the process for this to be used
+func (s *Server) MetadataHost() string {
+	uri, _ := url.Parse(s.server.URL)
+	return uri.Host
+}
+
+// Environment returns the Azure Environment for this Server
+func (s *Server) Environment() azure.Environment {
+	endpoint := s.server.URL + "/"
+	return azure.Environment{
+		Name:                    DefaultEnvironmentName,
+		ResourceManagerEndpoint: endpoint,
+		ActiveDirectoryEndpoint: endpoint,
+		GraphEndpoint:           endpoint,
+		BatchManagementEndpoint: endpoint,
+		TokenAudience:           endpoint,
+		StorageEndpointSuffix:   "core.windows.net",
+		KeyVaultDNSSuffix:       "vault.azure.net",
+		ResourceIdentifiers: azure.ResourceIdentifier{
+			Storage:             "https://storage.azure.com/",
+			Graph:               endpoint,
+			Synapse:             azure.NotAvailable,
+			ServiceBus:          azure.NotAvailable,
+			OperationalInsights: azure.NotAvailable,
+		},
+	}
+}
+
+// SendDecorator returns a SendDecorator which sends requests using a HTTP Client which trusts the Server's certificate
+func (s *Server) SendDecorator() autorest.SendDecorator {
+	return func(autorest.Sender) autorest.Sender {
+		return s.server.Client()
+	}
+}
+
+// ClientOptions returns the ClientOptions which can be used to build clients
+// (for example using `clients.Client.Build`) which send requests to this Server
+func (s *Server) ClientOptions() *common.ClientOptions {
+	env := s.Environment()
+	return &common.ClientOptions{
+		SubscriptionId:            DefaultSubscriptionId,
+		TenantID:                  DefaultTenantId,
+		TerraformVersion:          "0.15.0",
+		GraphAuthorizer:           autorest.NullAuthorizer{},
+		GraphEndpoint:             env.GraphEndpoint,
+		KeyVaultAuthorizer:        autorest.NullAuthorizer{},
+		ResourceManagerAuthorizer: autorest.NullAuthorizer{},
+		ResourceManagerEndpoint:   env.ResourceManagerEndpoint,
+		StorageAuthorizer:         autorest.NullAuthorizer{},
+		SynapseAuthorizer:         autorest.NullAuthorizer{},
+		BatchManagementAuthorizer: autorest.NullAuthorizer{},
+		SkipProviderReg:           true,
+		Environment:               env,
+		Features:                  features.Default(),
+		SendDecorators:            []autorest.SendDecorator{s.SendDecorator()},
+	}
+}
+
+// Put stores the specified resource, as if it were created via the API
+func (s *Server) Put(id string, body map[string]interface{}) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.put(parsePath(id), body)
+}
+
+// Get returns the specified resource, if it exists
+func (s *Server) Get(id string) (map[string]interface{}, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	r, ok := s.resources[strings.ToLower(id)]
+	if !ok {
+		return nil, false
+	}
+
+	return copyBody(r.body), true
+}
+
+// Exists returns whether the specified resource exists
+func (s *Server) Exists(id string) bool {
+	_, ok := s.Get(id)
+	return ok
+}
+
+// Requests returns the requests which have been received by the Server
+func (s *Server) Requests() []Request {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	out := make([]Request, len(s.requests))
+	copy(out, s.requests)
+	return out
+}
+
+func (s *Server) put(path parsedPath, body map[string]interface{}) map[string]interface{} {
+	body = copyBody(body)
+	body["id"] = path.id()
+	body["name"] = path.name()
+	body["type"] = path.resourceType()
+	if props, ok := body["properties"].(map[string]interface{}); ok {
+		if _, ok := props["provisioningState"]; !ok {
+			props["provisioningState"] = "Succeeded"
+		}
+	}
+
+	s.resources[strings.ToLower(path.id())] = resource{
+		id:   path.id(),
+		body: body,
+	}
+	return copyBody(body)
+}
+
+func copyBody(input map[string]interface{}) map[string]interface{} {
+	if input == nil {
+		return map[string]interface{}{}
+	}
+
+	// round-tripping via JSON ensures nested maps aren't shared
+	out := make(map[string]interface{})
+	raw, _ := json.Marshal(input)
+	_ = json.Unmarshal(raw, &out)
+	return out
+}
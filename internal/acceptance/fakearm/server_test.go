@@ -0,0 +1,213 @@
+package fakearm
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2020-06-01/resources"
+	resourceClient "github.com/hashicorp/terraform-provider-azurerm/internal/services/resource/client"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+func TestParsePath(t *testing.T) {
+	testData := []struct {
+		path         string
+		isResource   bool
+		resourceType string
+		parent       string
+	}{
+		{
+			path:         "/subscriptions/000/resourceGroups/group1",
+			isResource:   true,
+			resourceType: "Microsoft.Resources/resourceGroups",
+			parent:       "/subscriptions/000",
+		},
+		{
+			path:       "/subscriptions/000/resourceGroups",
+			isResource: false,
+		},
+		{
+			path:         "/subscriptions/000/resourceGroups/group1/providers/Microsoft.Network/virtualNetworks/network1",
+			isResource:   true,
+			resourceType: "Microsoft.Network/virtualNetworks",
+			parent:       "/subscriptions/000/resourceGroups/group1",
+		},
+		{
+			path:       "/subscriptions/000/resourceGroups/group1/providers/Microsoft.Network/virtualNetworks",
+			isResource: false,
+		},
+		{
+			path:         "/subscriptions/000/resourceGroups/group1/providers/Microsoft.Network/virtualNetworks/network1/subnets/subnet1",
+			isResource:   true,
+			resourceType: "Microsoft.Network/virtualNetworks/subnets",
+			parent:       "/subscriptions/000/resourceGroups/group1/providers/Microsoft.Network/virtualNetworks/network1",
+		},
+		{
+			path:         "/subscriptions/000/resourceGroups/group1/providers/Microsoft.Network/virtualNetworks/network1/providers/Microsoft.Insights/diagnosticSettings/setting1",
+			isResource:   true,
+			resourceType: "Microsoft.Insights/diagnosticSettings",
+			parent:       "/subscriptions/000/resourceGroups/group1/providers/Microsoft.Network/virtualNetworks/network1",
+		},
+		{
+			path:       "/subscriptions/000/resourceGroups/group1/providers/Microsoft.Storage/storageAccounts/account1/listKeys",
+			isResource: false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.path)
+
+		actual := parsePath(v.path)
+		if actual.isResource() != v.isResource {
+			t.Fatalf("expected isResource to be %t but got %t", v.isResource, actual.isResource())
+		}
+		if !v.isResource {
+			continue
+		}
+
+		if actual.resourceType() != v.resourceType {
+			t.Fatalf("expected the resource type to be %q but got %q", v.resourceType, actual.resourceType())
+		}
+		if parent := actual.parent().id(); parent != v.parent {
+			t.Fatalf("expected the parent to be %q but got %q", v.parent, parent)
+		}
+	}
+}
+
+func TestServerResourceGroupLifecycle(t *testing.T) {
+	for _, longRunning := range []bool{false, true} {
+		t.Logf("[DEBUG] Testing with Long Running Operations %t..", longRunning)
+
+		server := New(t, Options{
+			LongRunningOperations: longRunning,
+			PollCount:             1,
+		})
+		client := resourceClient.NewClient(server.ClientOptions()).GroupsClient
+		ctx := context.TODO()
+
+		resp, err := client.Get(ctx, "group1")
+		if !utils.ResponseWasNotFound(resp.Response) {
+			t.Fatalf("expected a 404 but got %+v", err)
+		}
+
+		if _, err := client.CreateOrUpdate(ctx, "group1", resources.Group{
+			Location: utils.String("westeurope"),
+			Tags: map[string]*string{
+				"hello": utils.String("world"),
+			},
+		}); err != nil {
+			t.Fatalf("creating: %+v", err)
+		}
+
+		group, err := client.Get(ctx, "group1")
+		if err != nil {
+			t.Fatalf("retrieving: %+v", err)
+		}
+		if group.ID == nil || *group.ID != "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/group1" {
+			t.Fatalf("expected the ID to be populated but got %+v", group.ID)
+		}
+		if group.Location == nil || *group.Location != "westeurope" {
+			t.Fatalf("expected the location to be `westeurope` but got %+v", group.Location)
+		}
+		if v := group.Tags["hello"]; v == nil || *v != "world" {
+			t.Fatalf("expected the tag `hello` to be `world` but got %+v", v)
+		}
+
+		exists, err := client.CheckExistence(ctx, "group1")
+		if err != nil {
+			t.Fatalf("checking existence: %+v", err)
+		}
+		if exists.StatusCode != http.StatusNoContent {
+			t.Fatalf("expected a 204 but got %d", exists.StatusCode)
+		}
+
+		future, err := client.Delete(ctx, "group1", "")
+		if err != nil {
+			t.Fatalf("deleting: %+v", err)
+		}
+		client.PollingDelay = time.Millisecond
+		if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+			t.Fatalf("waiting for deletion: %+v", err)
+		}
+
+		resp, err = client.Get(ctx, "group1")
+		if !utils.ResponseWasNotFound(resp.Response) {
+			t.Fatalf("expected a 404 after deletion but got %+v", err)
+		}
+	}
+}
+
+func TestServerNestedResources(t *testing.T) {
+	server := New(t, Options{})
+	client := resourceClient.NewClient(server.ClientOptions()).ResourcesClient
+	ctx := context.TODO()
+
+	networkId := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Network/virtualNetworks/network1"
+	subnetId := networkId + "/subnets/subnet1"
+
+	// the Resource Group doesn't exist
+	if _, err := client.CreateOrUpdateByID(ctx, networkId, "2020-11-01", resources.GenericResource{Location: utils.String("westeurope")}); err == nil {
+		t.Fatalf("expected an error but didn't get one")
+	}
+
+	server.Put("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1", map[string]interface{}{
+		"location": "westeurope",
+	})
+
+	// the parent resource doesn't exist
+	if _, err := client.CreateOrUpdateByID(ctx, subnetId, "2020-11-01", resources.GenericResource{}); err == nil {
+		t.Fatalf("expected an error but didn't get one")
+	}
+
+	future, err := client.CreateOrUpdateByID(ctx, networkId, "2020-11-01", resources.GenericResource{
+		Location: utils.String("westeurope"),
+		Properties: map[string]interface{}{
+			"addressSpace": map[string]interface{}{
+				"addressPrefixes": []string{"10.0.0.0/16"},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("creating network: %+v", err)
+	}
+	if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		t.Fatalf("waiting for creation of network: %+v", err)
+	}
+
+	if _, err := client.CreateOrUpdateByID(ctx, subnetId, "2020-11-01", resources.GenericResource{}); err != nil {
+		t.Fatalf("creating subnet: %+v", err)
+	}
+
+	// an existing resource is returned (which is used to raise a `requires import` error)
+	existing, err := client.GetByID(ctx, networkId, "2020-11-01")
+	if err != nil {
+		t.Fatalf("retrieving network: %+v", err)
+	}
+	if existing.Type == nil || *existing.Type != "Microsoft.Network/virtualNetworks" {
+		t.Fatalf("expected the type to be `Microsoft.Network/virtualNetworks` but got %+v", existing.Type)
+	}
+
+	body, ok := server.Get(subnetId)
+	if !ok {
+		t.Fatalf("expected the subnet to exist")
+	}
+	if v := body["type"]; v != "Microsoft.Network/virtualNetworks/subnets" {
+		t.Fatalf("expected the type to be `Microsoft.Network/virtualNetworks/subnets` but got %+v", v)
+	}
+
+	// deleting the Resource Group deletes everything within it
+	groups := resourceClient.NewClient(server.ClientOptions()).GroupsClient
+	if _, err := groups.Delete(ctx, "group1", ""); err != nil {
+		t.Fatalf("deleting Resource Group: %+v", err)
+	}
+	if server.Exists(networkId) || server.Exists(subnetId) {
+		t.Fatalf("expected the nested resources to be deleted")
+	}
+
+	resp, err := client.GetByID(ctx, subnetId, "2020-11-01")
+	if !utils.ResponseWasNotFound(resp.Response) {
+		t.Fatalf("expected a 404 but got %+v", err)
+	}
+}
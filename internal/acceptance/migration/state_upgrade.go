@@ -0,0 +1,69 @@
+package migration
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+// StateUpgradeTestData is a test case used to confirm that a StateUpgrade
+// migrates the raw state from an older version into the expected shape
+type StateUpgradeTestData struct {
+	// Name is a descriptive name for this test case
+	Name string
+
+	// Input is the raw state in the format of the older version
+	Input map[string]interface{}
+
+	// Expected is the raw state which should be returned from the StateUpgrade
+	// NOTE: this is ignored when ExpectError is true
+	Expected map[string]interface{}
+
+	// ExpectError specifies whether the StateUpgrade is expected to return an error
+	ExpectError bool
+}
+
+// TestStateUpgrade runs each of the test cases against the specified StateUpgrade, confirming
+// that each field in the Input exists in the Schema for the older version and that the raw
+// state is upgraded into the Expected raw state
+func TestStateUpgrade(t *testing.T, upgrade pluginsdk.StateUpgrade, testData []StateUpgradeTestData) {
+	schema := upgrade.Schema()
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.Name)
+
+		for key := range v.Input {
+			if key == "id" {
+				continue
+			}
+
+			if _, ok := schema[key]; !ok {
+				t.Fatalf("%q: the field %q was present in the Input but isn't defined in the Schema for this StateUpgrade", v.Name, key)
+			}
+		}
+
+		// copy the input, since the State Upgraders can amend the raw state in-place
+		input := make(map[string]interface{}, len(v.Input))
+		for key, val := range v.Input {
+			input[key] = val
+		}
+
+		actual, err := upgrade.UpgradeFunc()(context.TODO(), input, nil)
+		if err != nil {
+			if v.ExpectError {
+				continue
+			}
+
+			t.Fatalf("%q: expected no error but got: %+v", v.Name, err)
+		}
+		if v.ExpectError {
+			t.Fatalf("%q: expected an error but didn't get one", v.Name)
+		}
+
+		if !reflect.DeepEqual(v.Expected, actual) {
+			t.Fatalf("%q: expected %+v but got %+v", v.Name, v.Expected, actual)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package recording
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+)
+
+// Cassette contains the requests and responses recorded for a single test, along with
+// the random values used by that test so that the same resource names are used when replaying
+type Cassette struct {
+	// RandomInteger is the value of `TestData.RandomInteger` when this was recorded
+	RandomInteger int `json:"randomInteger"`
+
+	// RandomString is the value of `TestData.RandomString` when this was recorded
+	RandomString string `json:"randomString"`
+
+	// Locations are the Azure Regions used when this was recorded
+	Locations Locations `json:"locations"`
+
+	// ObjectId is the (scrubbed) Object ID of the authenticated principal
+	ObjectId string `json:"objectId"`
+
+	Interactions []Interaction `json:"interactions"`
+}
+
+type Locations struct {
+	Primary   string `json:"primary"`
+	Secondary string `json:"secondary"`
+	Ternary   string `json:"ternary"`
+}
+
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+
+	// replayed is whether this Interaction has been served whilst replaying
+	replayed bool
+}
+
+type Request struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+type Response struct {
+	StatusCode int         `json:"statusCode"`
+	Headers    http.Header `json:"headers"`
+	Body       string      `json:"body"`
+}
+
+var invalidFileNameCharacters = regexp.MustCompile(`[^a-zA-Z0-9_\-.]+`)
+
+// cassettePath returns the path to the Cassette for the specified test name
+func cassettePath(testName string) string {
+	fileName := invalidFileNameCharacters.ReplaceAllString(testName, "_")
+	return filepath.Join(directory(), fmt.Sprintf("%s.json", fileName))
+}
+
+func loadCassette(path string) (*Cassette, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading %q: %+v", path, err)
+	}
+
+	var cassette Cassette
+	if err := json.Unmarshal(contents, &cassette); err != nil {
+		return nil, fmt.Errorf("parsing %q: %+v", path, err)
+	}
+
+	return &cassette, nil
+}
+
+func (c *Cassette) save(path string) error {
+	contents, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("serializing: %+v", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating directory for %q: %+v", path, err)
+	}
+
+	if err := ioutil.WriteFile(path, contents, 0644); err != nil {
+		return fmt.Errorf("writing %q: %+v", path, err)
+	}
+
+	return nil
+}
+
+// nextInteraction returns the first Interaction matching the specified method, url and body which hasn't yet been replayed
+func (c *Cassette) nextInteraction(method, url, body string) *Interaction {
+	for i := range c.Interactions {
+		interaction := &c.Interactions[i]
+		if interaction.replayed {
+			continue
+		}
+
+		if interaction.Request.Method == method && interaction.Request.URL == url && bodiesMatch(interaction.Request.Body, body) {
+			interaction.replayed = true
+			return interaction
+		}
+	}
+
+	return nil
+}
+
+// bodiesMatch returns whether the request bodies are the same - JSON bodies are compared semantically,
+// since the order of the fields isn't significant
+func bodiesMatch(recorded, actual string) bool {
+	if recorded == actual {
+		return true
+	}
+
+	var recordedJson, actualJson interface{}
+	if err := json.Unmarshal([]byte(recorded), &recordedJson); err != nil {
+		return false
+	}
+	if err := json.Unmarshal([]byte(actual), &actualJson); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(recordedJson, actualJson)
+}
@@ -0,0 +1,55 @@
+package recording
+
+import (
+	"os"
+	"strings"
+)
+
+const (
+	// ModeEnvVar is the Environment Variable used to enable recording or replaying requests
+	ModeEnvVar = "ARM_TEST_RECORDING_MODE"
+
+	// DirectoryEnvVar is the Environment Variable which can be used to override the
+	// directory where recordings are stored, relative to the package being tested
+	DirectoryEnvVar = "ARM_TEST_RECORDINGS_DIR"
+
+	defaultDirectory = "testdata/recordings"
+)
+
+type Mode string
+
+const (
+	// ModeLive sends requests to Azure without recording them
+	ModeLive Mode = ""
+
+	// ModeRecord sends requests to Azure and records each request/response
+	ModeRecord Mode = "record"
+
+	// ModeReplay serves previously recorded responses without sending any requests to Azure
+	ModeReplay Mode = "replay"
+)
+
+// CurrentMode returns the Mode specified in the `ARM_TEST_RECORDING_MODE` Environment Variable
+func CurrentMode() Mode {
+	switch Mode(strings.ToLower(os.Getenv(ModeEnvVar))) {
+	case ModeRecord:
+		return ModeRecord
+	case ModeReplay:
+		return ModeReplay
+	}
+
+	return ModeLive
+}
+
+// Enabled returns whether requests are being recorded or replayed
+func Enabled() bool {
+	return CurrentMode() != ModeLive
+}
+
+func directory() string {
+	if v := os.Getenv(DirectoryEnvVar); v != "" {
+		return v
+	}
+
+	return defaultDirectory
+}
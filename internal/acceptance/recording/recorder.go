@@ -0,0 +1,295 @@
+package recording
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/hashicorp/go-azure-helpers/authentication"
+)
+
+var (
+	// only a single test can be recorded/replayed at a time, since the clients used by the
+	// Provider are unaware of which test they're being used for
+	activeLock     sync.Mutex
+	activeRecorder *recorder
+)
+
+type recorder struct {
+	sync.Mutex
+
+	mode     Mode
+	testName string
+	path     string
+	cassette *Cassette
+	scrubber *scrubber
+}
+
+// Start records or replays the requests made during the specified test, depending on the
+// `ARM_TEST_RECORDING_MODE` Environment Variable. The Cassette returned contains the random
+// values which were used when the test was recorded - and when recording these values should
+// be set on the Cassette so that they can be reused when replaying the test.
+//
+// When recording, the Cassette is saved once the test completes successfully. Subsequent calls
+// for the same test return the same Cassette.
+func Start(t *testing.T) (*Cassette, error) {
+	mode := CurrentMode()
+	if mode == ModeLive {
+		return nil, fmt.Errorf("recording is not enabled - set %q to %q or %q", ModeEnvVar, ModeRecord, ModeReplay)
+	}
+
+	// tests can build multiple sets of Test Data, which share a single recording
+	if rec := active(); rec != nil && rec.testName == t.Name() {
+		return rec.cassette, nil
+	}
+
+	rec := &recorder{
+		mode:     mode,
+		testName: t.Name(),
+		path:     cassettePath(t.Name()),
+		cassette: &Cassette{},
+	}
+
+	if mode == ModeReplay {
+		cassette, err := loadCassette(rec.path)
+		if err != nil {
+			return nil, fmt.Errorf("loading the recording for %q: %+v", t.Name(), err)
+		}
+		rec.cassette = cassette
+
+		// the tests (and the Provider) use these values, which must match the scrubbed values
+		for envVar, placeholder := range placeholders {
+			os.Setenv(envVar, placeholder)
+		}
+		os.Setenv("ARM_TEST_LOCATION", cassette.Locations.Primary)
+		os.Setenv("ARM_TEST_LOCATION_ALT", cassette.Locations.Secondary)
+		os.Setenv("ARM_TEST_LOCATION_ALT2", cassette.Locations.Ternary)
+	}
+
+	// this is populated after the Environment Variables are set, so that nothing is scrubbed when replaying
+	rec.scrubber = newScrubber()
+
+	activeLock.Lock()
+	if activeRecorder != nil {
+		activeLock.Unlock()
+		return nil, fmt.Errorf("unable to record %q since another test is being recorded - tests must be run sequentially", t.Name())
+	}
+	activeRecorder = rec
+	activeLock.Unlock()
+
+	t.Cleanup(func() {
+		activeLock.Lock()
+		activeRecorder = nil
+		activeLock.Unlock()
+
+		if mode != ModeRecord {
+			return
+		}
+
+		if t.Failed() {
+			log.Printf("[DEBUG] Recording: not saving the recording for %q since the test failed", t.Name())
+			return
+		}
+
+		if err := rec.cassette.save(rec.path); err != nil {
+			t.Errorf("saving the recording for %q: %+v", t.Name(), err)
+		}
+	})
+
+	return rec.cassette, nil
+}
+
+func active() *recorder {
+	activeLock.Lock()
+	defer activeLock.Unlock()
+
+	return activeRecorder
+}
+
+// SendDecorator returns a SendDecorator which records or replays requests for the active test
+func SendDecorator() autorest.SendDecorator {
+	return func(s autorest.Sender) autorest.Sender {
+		return autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+			rec := active()
+			if rec == nil {
+				if CurrentMode() == ModeReplay {
+					return nil, fmt.Errorf("unable to replay the %s request to %q since no recording is active", r.Method, r.URL.String())
+				}
+
+				return s.Do(r)
+			}
+
+			if rec.mode == ModeReplay {
+				return rec.replay(r)
+			}
+
+			return rec.record(s, r)
+		})
+	}
+}
+
+// ConfigureAuth ensures that the Object ID for the authenticated principal (which is looked up
+// outside of the Provider's clients) is scrubbed when recording and available when replaying.
+func ConfigureAuth(config *authentication.Config) {
+	lookup := config.GetAuthenticatedObjectID
+	config.GetAuthenticatedObjectID = func(ctx context.Context) (string, error) {
+		rec := active()
+		if rec == nil {
+			if CurrentMode() == ModeReplay || lookup == nil {
+				return "", nil
+			}
+
+			return lookup(ctx)
+		}
+
+		return rec.objectId(ctx, lookup)
+	}
+}
+
+func (r *recorder) objectId(ctx context.Context, lookup func(context.Context) (string, error)) (string, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	if r.mode == ModeReplay {
+		return r.cassette.ObjectId, nil
+	}
+
+	if lookup == nil {
+		return "", nil
+	}
+
+	objectId, err := lookup(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	r.scrubber.add(objectId, placeholderObjectId)
+	r.cassette.ObjectId = placeholderObjectId
+	return objectId, nil
+}
+
+func (r *recorder) record(s autorest.Sender, req *http.Request) (*http.Response, error) {
+	requestBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.Do(req)
+	if err != nil || resp == nil || isTokenRequest(req) {
+		return resp, err
+	}
+
+	var body []byte
+	if resp.Body != nil {
+		body, err = ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("reading the response body for %q: %+v", req.URL.String(), err)
+		}
+		resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	r.Lock()
+	defer r.Unlock()
+
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: Request{
+			Method: req.Method,
+			URL:    r.scrubber.scrub(req.URL.String()),
+			Body:   r.scrubber.scrubBody(requestBody),
+		},
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Headers:    r.scrubber.scrubHeaders(resp.Header),
+			Body:       r.scrubber.scrubBody(string(body)),
+		},
+	})
+
+	return resp, nil
+}
+
+func (r *recorder) replay(req *http.Request) (*http.Response, error) {
+	if isTokenRequest(req) {
+		return tokenResponse(req), nil
+	}
+
+	requestBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	r.Lock()
+	defer r.Unlock()
+
+	uri := r.scrubber.scrub(req.URL.String())
+	body := r.scrubber.scrubBody(requestBody)
+	interaction := r.cassette.nextInteraction(req.Method, uri, body)
+	if interaction == nil {
+		return nil, fmt.Errorf("no recorded response was found for the %s request to %q with the body %q in %q", req.Method, uri, body, r.path)
+	}
+
+	headers := interaction.Response.Headers.Clone()
+	if headers == nil {
+		headers = http.Header{}
+	}
+
+	// poll long-running operations as quickly as possible, since these have already completed
+	if headers.Get("Retry-After") != "" || headers.Get("Azure-AsyncOperation") != "" || headers.Get("Location") != "" {
+		headers.Set("Retry-After", "1")
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+		StatusCode:    interaction.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        headers,
+		Body:          ioutil.NopCloser(bytes.NewBufferString(interaction.Response.Body)),
+		ContentLength: int64(len(interaction.Response.Body)),
+		Request:       req,
+	}, nil
+}
+
+// readRequestBody returns the body of the request, which is replaced so that it can still be sent
+func readRequestBody(req *http.Request) (string, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return "", nil
+	}
+
+	body, err := ioutil.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return "", fmt.Errorf("reading the request body for %q: %+v", req.URL.String(), err)
+	}
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	return string(body), nil
+}
+
+// tokenResponse returns a stubbed access token, since requests to obtain access tokens are never recorded
+func tokenResponse(req *http.Request) *http.Response {
+	now := time.Now()
+	body := fmt.Sprintf(`{"access_token":%q,"token_type":"Bearer","expires_in":"3600","expires_on":"%d","not_before":"%d"}`, redacted, now.Add(time.Hour).Unix(), now.Unix())
+	return &http.Response{
+		Status:     "200 OK",
+		StatusCode: http.StatusOK,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header: http.Header{
+			"Content-Type": []string{"application/json"},
+		},
+		Body:          ioutil.NopCloser(bytes.NewBufferString(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
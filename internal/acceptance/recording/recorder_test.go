@@ -0,0 +1,198 @@
+package recording
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"testing"
+)
+
+type testSender struct {
+	requests int
+}
+
+func (s *testSender) Do(r *http.Request) (*http.Response, error) {
+	s.requests++
+	body := `{"id":"` + r.URL.Path + `","properties":{"primaryKey":"abc=="}}`
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header: http.Header{
+			"Content-Type": []string{"application/json"},
+		},
+		Body:    ioutil.NopCloser(bytes.NewBufferString(body)),
+		Request: r,
+	}, nil
+}
+
+func setEnv(t *testing.T, key, value string) {
+	existing, exists := os.LookupEnv(key)
+	os.Setenv(key, value)
+	t.Cleanup(func() {
+		if exists {
+			os.Setenv(key, existing)
+		} else {
+			os.Unsetenv(key)
+		}
+	})
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	setEnv(t, DirectoryEnvVar, t.TempDir())
+	for envVar := range placeholders {
+		setEnv(t, envVar, "")
+	}
+	for _, envVar := range []string{"ARM_TEST_LOCATION", "ARM_TEST_LOCATION_ALT", "ARM_TEST_LOCATION_ALT2"} {
+		setEnv(t, envVar, "")
+	}
+	setEnv(t, "ARM_SUBSCRIPTION_ID", "12345678-1234-1234-1234-123456789012")
+	uri := "https://management.azure.com/subscriptions/12345678-1234-1234-1234-123456789012/resourceGroups/group1"
+
+	sender := &testSender{}
+
+	t.Run("record", func(t *testing.T) {
+		setEnv(t, ModeEnvVar, string(ModeRecord))
+
+		cassette, err := Start(t)
+		if err != nil {
+			t.Fatalf("starting recording: %+v", err)
+		}
+		cassette.RandomInteger = 123
+		cassette.Locations.Primary = "westeurope"
+
+		recorded := SendDecorator()(sender)
+		req, _ := http.NewRequest(http.MethodGet, uri, nil)
+		resp, err := recorded.Do(req)
+		if err != nil {
+			t.Fatalf("sending request: %+v", err)
+		}
+
+		// the caller should receive the original (unscrubbed) response
+		body, _ := ioutil.ReadAll(resp.Body)
+		if !bytes.Contains(body, []byte("abc==")) {
+			t.Fatalf("expected the response body to be unmodified but got %q", string(body))
+		}
+	})
+
+	if sender.requests != 1 {
+		t.Fatalf("expected 1 request to be sent but got %d", sender.requests)
+	}
+
+	t.Run("replay", func(t *testing.T) {
+		setEnv(t, ModeEnvVar, string(ModeReplay))
+
+		// cassettes are named after the test, so load the one recorded above
+		cassette, err := loadCassette(cassettePath("TestRecordAndReplay/record"))
+		if err != nil {
+			t.Fatalf("loading recording: %+v", err)
+		}
+		if cassette.RandomInteger != 123 || cassette.Locations.Primary != "westeurope" {
+			t.Fatalf("expected the random values to be recorded but got %+v", *cassette)
+		}
+		if len(cassette.Interactions) != 1 {
+			t.Fatalf("expected 1 interaction but got %d", len(cassette.Interactions))
+		}
+
+		rec := &recorder{
+			mode:     ModeReplay,
+			cassette: cassette,
+			scrubber: &scrubber{},
+		}
+
+		req, _ := http.NewRequest(http.MethodGet, "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1", nil)
+		resp, err := rec.replay(req)
+		if err != nil {
+			t.Fatalf("replaying request: %+v", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("expected a 200 but got %d", resp.StatusCode)
+		}
+
+		body, _ := ioutil.ReadAll(resp.Body)
+		expected := `{"id":"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1","properties":{"primaryKey":"REDACTED"}}`
+		if string(body) != expected {
+			t.Fatalf("expected %q but got %q", expected, string(body))
+		}
+
+		// each interaction is only replayed once
+		if _, err := rec.replay(req); err == nil {
+			t.Fatalf("expected an error but didn't get one")
+		}
+
+		// and tokens are stubbed
+		tokenReq, _ := http.NewRequest(http.MethodPost, "https://login.microsoftonline.com/00000000-0000-0000-0000-000000000000/oauth2/token", nil)
+		tokenResp, err := rec.replay(tokenReq)
+		if err != nil {
+			t.Fatalf("replaying token request: %+v", err)
+		}
+		if tokenResp.StatusCode != http.StatusOK {
+			t.Fatalf("expected a 200 but got %d", tokenResp.StatusCode)
+		}
+	})
+
+	if sender.requests != 1 {
+		t.Fatalf("expected no further requests to be sent but got %d", sender.requests)
+	}
+}
+
+func TestSendDecoratorPassesThroughWhenLive(t *testing.T) {
+	setEnv(t, ModeEnvVar, "")
+
+	sender := &testSender{}
+	req, _ := http.NewRequest(http.MethodGet, "https://management.azure.com/subscriptions", nil)
+	if _, err := SendDecorator()(sender).Do(req); err != nil {
+		t.Fatalf("sending request: %+v", err)
+	}
+	if sender.requests != 1 {
+		t.Fatalf("expected 1 request but got %d", sender.requests)
+	}
+}
+
+func TestReplayMatchesRequestBody(t *testing.T) {
+	uri := "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1"
+	rec := &recorder{
+		mode: ModeReplay,
+		cassette: &Cassette{
+			Interactions: []Interaction{
+				{
+					Request: Request{
+						Method: http.MethodPut,
+						URL:    uri,
+						Body:   `{"location":"westeurope","tags":{"environment":"first"}}`,
+					},
+					Response: Response{
+						StatusCode: http.StatusOK,
+						Body:       `{"name":"first"}`,
+					},
+				},
+				{
+					Request: Request{
+						Method: http.MethodPut,
+						URL:    uri,
+						Body:   `{"location":"westeurope","tags":{"environment":"second"}}`,
+					},
+					Response: Response{
+						StatusCode: http.StatusOK,
+						Body:       `{"name":"second"}`,
+					},
+				},
+			},
+		},
+		scrubber: &scrubber{},
+	}
+
+	// the second interaction is matched on the body (where the order of the fields isn't significant)
+	req, _ := http.NewRequest(http.MethodPut, uri, bytes.NewBufferString(`{"tags":{"environment":"second"},"location":"westeurope"}`))
+	resp, err := rec.replay(req)
+	if err != nil {
+		t.Fatalf("replaying request: %+v", err)
+	}
+	if body, _ := ioutil.ReadAll(resp.Body); string(body) != `{"name":"second"}` {
+		t.Fatalf("expected the second response but got %q", string(body))
+	}
+
+	req, _ = http.NewRequest(http.MethodPut, uri, bytes.NewBufferString(`{"location":"westeurope","tags":{"environment":"third"}}`))
+	if _, err := rec.replay(req); err == nil {
+		t.Fatalf("expected an error for a request body which wasn't recorded but didn't get one")
+	}
+}
@@ -0,0 +1,112 @@
+package recording
+
+import (
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+)
+
+const (
+	redacted = "REDACTED"
+
+	placeholderSubscriptionId    = "00000000-0000-0000-0000-000000000000"
+	placeholderSubscriptionIdAlt = "11111111-1111-1111-1111-111111111111"
+	placeholderTenantId          = "22222222-2222-2222-2222-222222222222"
+	placeholderTenantIdAlt       = "33333333-3333-3333-3333-333333333333"
+	placeholderClientId          = "44444444-4444-4444-4444-444444444444"
+	placeholderClientIdAlt       = "55555555-5555-5555-5555-555555555555"
+	placeholderObjectId          = "66666666-6666-6666-6666-666666666666"
+)
+
+// placeholders are the values used in place of the values sourced from these Environment Variables
+// both when recording (where they're scrubbed) and replaying (where they're used as-is)
+var placeholders = map[string]string{
+	"ARM_SUBSCRIPTION_ID":     placeholderSubscriptionId,
+	"ARM_SUBSCRIPTION_ID_ALT": placeholderSubscriptionIdAlt,
+	"ARM_TENANT_ID":           placeholderTenantId,
+	"ARM_TENANT_ID_ALT":       placeholderTenantIdAlt,
+	"ARM_CLIENT_ID":           placeholderClientId,
+	"ARM_CLIENT_ID_ALT":       placeholderClientIdAlt,
+	"ARM_CLIENT_SECRET":       redacted,
+	"ARM_CLIENT_SECRET_ALT":   redacted,
+}
+
+var (
+	// sensitiveJsonFields matches string values within a JSON body whose field name ends
+	// with one of these suffixes (e.g. `primaryKey`, `adminPassword` or `connectionString`)
+	sensitiveJsonFields = regexp.MustCompile(`(?i)("[a-z0-9_]*(?:key|secret|password|connectionstring|token|signature)"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+
+	// sasSignatures matches the signature of a Shared Access Signature
+	sasSignatures = regexp.MustCompile(`(?i)([?&]sig=)[^&"\s]+`)
+
+	// sensitiveHeaders are removed from the recorded responses
+	sensitiveHeaders = []string{
+		"Authorization",
+		"Set-Cookie",
+		"Www-Authenticate",
+	}
+)
+
+type replacement struct {
+	pattern *regexp.Regexp
+	value   string
+}
+
+// scrubber removes secrets and identifiers specific to the account used to record the requests
+type scrubber struct {
+	replacements []replacement
+}
+
+func newScrubber() *scrubber {
+	s := &scrubber{}
+	for envVar, placeholder := range placeholders {
+		s.add(os.Getenv(envVar), placeholder)
+	}
+	return s
+}
+
+// add registers a value which should be replaced by the specified placeholder
+func (s *scrubber) add(value, placeholder string) {
+	if value == "" || value == placeholder {
+		return
+	}
+
+	s.replacements = append(s.replacements, replacement{
+		pattern: regexp.MustCompile("(?i)" + regexp.QuoteMeta(value)),
+		value:   placeholder,
+	})
+}
+
+func (s *scrubber) scrub(input string) string {
+	for _, r := range s.replacements {
+		input = r.pattern.ReplaceAllLiteralString(input, r.value)
+	}
+	input = sasSignatures.ReplaceAllString(input, "${1}"+redacted)
+	return input
+}
+
+func (s *scrubber) scrubBody(input string) string {
+	input = sensitiveJsonFields.ReplaceAllString(input, `${1}"`+redacted+`"`)
+	return s.scrub(input)
+}
+
+func (s *scrubber) scrubHeaders(input http.Header) http.Header {
+	output := http.Header{}
+	for k, values := range input {
+		for _, v := range values {
+			output.Add(k, s.scrub(v))
+		}
+	}
+	for _, k := range sensitiveHeaders {
+		output.Del(k)
+	}
+	return output
+}
+
+// isTokenRequest returns whether the request is to obtain an access token from Azure Active Directory,
+// which are never recorded (and are stubbed when replaying) since these contain credentials
+func isTokenRequest(r *http.Request) bool {
+	path := strings.ToLower(r.URL.Path)
+	return strings.HasSuffix(path, "/oauth2/token") || strings.HasSuffix(path, "/oauth2/v2.0/token")
+}
@@ -0,0 +1,110 @@
+package recording
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestScrubber(t *testing.T) {
+	s := &scrubber{}
+	s.add("12345678-1234-1234-1234-123456789012", placeholderSubscriptionId)
+	s.add("secret-value", redacted)
+
+	testData := []struct {
+		input    string
+		expected string
+	}{
+		{
+			input:    "https://management.azure.com/subscriptions/12345678-1234-1234-1234-123456789012/resourceGroups/group1",
+			expected: "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1",
+		},
+		{
+			// IDs returned from the API can be in a different casing
+			input:    "/SUBSCRIPTIONS/12345678-1234-1234-1234-123456789012",
+			expected: "/SUBSCRIPTIONS/00000000-0000-0000-0000-000000000000",
+		},
+		{
+			input:    "the secret-value is here",
+			expected: "the REDACTED is here",
+		},
+		{
+			input:    "https://account1.blob.core.windows.net/container1?sv=2019-12-12&sig=abc%2Fdef&se=2021-09-01",
+			expected: "https://account1.blob.core.windows.net/container1?sv=2019-12-12&sig=REDACTED&se=2021-09-01",
+		},
+		{
+			input:    "nothing to see here",
+			expected: "nothing to see here",
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.input)
+
+		actual := s.scrub(v.input)
+		if actual != v.expected {
+			t.Fatalf("expected %q but got %q", v.expected, actual)
+		}
+	}
+}
+
+func TestScrubberBody(t *testing.T) {
+	s := &scrubber{}
+
+	testData := []struct {
+		input    string
+		expected string
+	}{
+		{
+			input:    `{"name":"account1","properties":{"keyName":"key1"}}`,
+			expected: `{"name":"account1","properties":{"keyName":"key1"}}`,
+		},
+		{
+			input:    `{"keys":[{"keyName":"key1","value":"abc","primaryKey":"abc=="}]}`,
+			expected: `{"keys":[{"keyName":"key1","value":"abc","primaryKey":"REDACTED"}]}`,
+		},
+		{
+			input:    `{"adminPassword": "P@ssw0rd1234!", "primaryConnectionString": "Endpoint=sb://\"quoted\""}`,
+			expected: `{"adminPassword": "REDACTED", "primaryConnectionString": "REDACTED"}`,
+		},
+		{
+			input:    `{"access_token":"eyJ0eXAi","refresh_token":"abc"}`,
+			expected: `{"access_token":"REDACTED","refresh_token":"REDACTED"}`,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.input)
+
+		actual := s.scrubBody(v.input)
+		if actual != v.expected {
+			t.Fatalf("expected %q but got %q", v.expected, actual)
+		}
+	}
+}
+
+func TestScrubberHeaders(t *testing.T) {
+	s := &scrubber{}
+	s.add("12345678-1234-1234-1234-123456789012", placeholderSubscriptionId)
+
+	input := http.Header{
+		"Authorization":  []string{"Bearer abc"},
+		"Set-Cookie":     []string{"abc=def"},
+		"Content-Type":   []string{"application/json"},
+		"Location":       []string{"https://management.azure.com/subscriptions/12345678-1234-1234-1234-123456789012/operationResults/abc"},
+		"X-Ms-Client-Id": []string{"abc"},
+	}
+
+	actual := s.scrubHeaders(input)
+	if actual.Get("Authorization") != "" {
+		t.Fatalf("expected the Authorization header to be removed")
+	}
+	if actual.Get("Set-Cookie") != "" {
+		t.Fatalf("expected the Set-Cookie header to be removed")
+	}
+	if v := actual.Get("Content-Type"); v != "application/json" {
+		t.Fatalf("expected the Content-Type header to be `application/json` but got %q", v)
+	}
+	if v := actual.Get("Location"); v != "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/operationResults/abc" {
+		t.Fatalf("expected the Location header to be scrubbed but got %q", v)
+	}
+}
@@ -6,7 +6,9 @@ import (
 	"os"
 	"sync"
 
+	"github.com/Azure/go-autorest/autorest"
 	"github.com/hashicorp/go-azure-helpers/authentication"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/recording"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
 )
@@ -49,6 +51,13 @@ func Build() (*clients.Client, error) {
 			Features:                 features.Default(),
 			StorageUseAzureAD:        false,
 		}
+
+		// the recorder is shared across tests, since it records/replays the requests for the active test
+		if recording.Enabled() {
+			recording.ConfigureAuth(config)
+			clientBuilder.SendDecorators = []autorest.SendDecorator{recording.SendDecorator()}
+		}
+
 		client, err := clients.Build(context.TODO(), clientBuilder)
 		if err != nil {
 			return nil, err
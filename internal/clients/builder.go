@@ -13,7 +13,10 @@ import (
 	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/location"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/locks"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/resourceproviders"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/throttling"
 )
 
 type ClientBuilder struct {
@@ -26,6 +29,20 @@ type ClientBuilder struct {
 	StorageUseAzureAD           bool
 	TerraformVersion            string
 	Features                    features.UserFeatures
+	Throttling                  *throttling.Options
+
+	// Tags contains the `default_tags` and `ignore_tags` configured in the Provider block
+	Tags *tags.Config
+
+	// LockBackend (if specified) is used to lock resources across processes
+	LockBackend locks.Backend
+
+	// TraceFile (if specified) is the path to a file where a JSON line is written for each request/response
+	TraceFile string
+
+	// SendDecorators are applied to the Sender used by every client (including those used
+	// to obtain access tokens) - and are used in the acceptance tests to record/replay requests
+	SendDecorators []autorest.SendDecorator
 }
 
 const azureStackEnvironmentError = `
@@ -63,7 +80,9 @@ func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
 	}
 
 	client := Client{
-		Account: account,
+		Account:     account,
+		Tags:        builder.Tags,
+		LockBackend: builder.LockBackend,
 	}
 
 	oauthConfig, err := builder.AuthConfig.BuildOAuthConfig(env.ActiveDirectoryEndpoint)
@@ -76,7 +95,7 @@ func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
 		return nil, fmt.Errorf("unable to configure OAuthConfig for tenant %s", builder.AuthConfig.TenantID)
 	}
 
-	sender := sender.BuildSender("AzureRM")
+	sender := autorest.DecorateSender(sender.BuildSender("AzureRM"), builder.SendDecorators...)
 
 	// Resource Manager endpoints
 	endpoint := env.ResourceManagerEndpoint
@@ -138,6 +157,19 @@ func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
 		Environment:                 *env,
 		Features:                    builder.Features,
 		StorageUseAzureAD:           builder.StorageUseAzureAD,
+		SendDecorators:              builder.SendDecorators,
+	}
+
+	if builder.Throttling != nil {
+		o.Throttling = throttling.NewLimiter(*builder.Throttling)
+	}
+
+	if builder.TraceFile != "" {
+		tracer, err := common.NewTracer(builder.TraceFile)
+		if err != nil {
+			return nil, fmt.Errorf("building Tracer: %+v", err)
+		}
+		o.Tracer = tracer
 	}
 
 	if err := client.Build(ctx, o); err != nil {
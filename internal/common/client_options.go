@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/go-azure-helpers/sender"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/meta"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/throttling"
 	"github.com/hashicorp/terraform-provider-azurerm/version"
 )
 
@@ -35,13 +36,33 @@ type ClientOptions struct {
 	Environment                 azure.Environment
 	Features                    features.UserFeatures
 	StorageUseAzureAD           bool
+
+	// Throttling is the Rate Limiter shared by all clients, when nil requests aren't rate limited
+	Throttling *throttling.Limiter
+
+	// Tracer (if specified) traces each request/response sent by all clients
+	Tracer *Tracer
+
+	// SendDecorators are applied to the Sender used by each client, and are used in
+	// the acceptance tests to record and replay requests
+	SendDecorators []autorest.SendDecorator
 }
 
 func (o ClientOptions) ConfigureClient(c *autorest.Client, authorizer autorest.Authorizer) {
 	setUserAgent(c, o.TerraformVersion, o.PartnerId, o.DisableTerraformPartnerID)
 
 	c.Authorizer = authorizer
-	c.Sender = sender.BuildSender("AzureRM")
+	c.Sender = autorest.DecorateSender(sender.BuildSender("AzureRM"), o.SendDecorators...)
+	if o.Tracer != nil {
+		c.Sender = o.Tracer.WithTracing(c.Sender)
+	}
+	if o.Throttling != nil {
+		c.Sender = o.Throttling.WithThrottling(c.Sender)
+	}
+	// NOTE: this is applied after throttling so that blocked requests don't consume any quota
+	if o.Features.ReadOnly {
+		c.Sender = withReadOnlyMode(c.Sender)
+	}
 	c.SkipResourceProviderRegistration = o.SkipProviderReg
 	if !o.DisableCorrelationRequestID {
 		id := o.CustomCorrelationRequestID
@@ -0,0 +1,45 @@
+package common
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+// withReadOnlyMode returns a Sender which rejects any request which could modify infrastructure
+// (that is, any request other than a GET, HEAD or OPTIONS request) before it's sent to Azure
+func withReadOnlyMode(sender autorest.Sender) autorest.Sender {
+	return readOnlySender{
+		sender: sender,
+	}
+}
+
+type readOnlySender struct {
+	sender autorest.Sender
+}
+
+func (s readOnlySender) Do(r *http.Request) (*http.Response, error) {
+	if !isReadOnlyHttpMethod(r.Method) {
+		log.Printf("[DEBUG] Read-Only Mode: blocking a %s request to %q", r.Method, r.URL.String())
+		return nil, fmt.Errorf(readOnlyModeErrorFmt, r.Method, r.URL.Host, r.URL.Path)
+	}
+
+	return s.sender.Do(r)
+}
+
+func isReadOnlyHttpMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+
+	return false
+}
+
+const readOnlyModeErrorFmt = `the Provider is running in Read-Only mode and blocked a %s request to %q (path %q).
+
+Read-Only mode is enabled via "read_only = true" within the "features" block in the Provider
+block and prevents the Provider from making any changes to infrastructure - including any API
+operations which are exposed as a POST (for example, listing the keys for a resource).`
@@ -0,0 +1,88 @@
+package common
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+type testSender struct {
+	requests int
+}
+
+func (s *testSender) Do(r *http.Request) (*http.Response, error) {
+	s.requests++
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Request:    r,
+	}, nil
+}
+
+func TestReadOnlySender(t *testing.T) {
+	testData := []struct {
+		method      string
+		expectError bool
+	}{
+		{
+			method:      http.MethodGet,
+			expectError: false,
+		},
+		{
+			method:      http.MethodHead,
+			expectError: false,
+		},
+		{
+			method:      http.MethodOptions,
+			expectError: false,
+		},
+		{
+			method:      http.MethodPut,
+			expectError: true,
+		},
+		{
+			method:      http.MethodPatch,
+			expectError: true,
+		},
+		{
+			method:      http.MethodPost,
+			expectError: true,
+		},
+		{
+			method:      http.MethodDelete,
+			expectError: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.method)
+
+		inner := &testSender{}
+		sender := withReadOnlyMode(inner)
+		req := &http.Request{
+			Method: v.method,
+			URL: &url.URL{
+				Scheme: "https",
+				Host:   "management.azure.com",
+				Path:   "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Storage/storageAccounts/example/listKeys",
+			},
+		}
+
+		_, err := sender.Do(req)
+		if v.expectError {
+			if err == nil {
+				t.Fatalf("expected an error but didn't get one")
+			}
+			if inner.requests != 0 {
+				t.Fatalf("expected the request not to be sent but it was")
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("expected no error but got: %+v", err)
+		}
+		if inner.requests != 1 {
+			t.Fatalf("expected the request to be sent but it wasn't")
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package common
+
+import (
+	"strings"
+	"sync"
+)
+
+const redactedValue = "REDACTED"
+
+// redactedFieldSuffixes are the (lower-cased) suffixes of field names whose values are always
+// redacted from traces - for example `primaryKey`, `adminPassword` and `connectionString` - which
+// can be followed by a number, for example `key1` and `key2`
+var redactedFieldSuffixes = []string{
+	"connectionstring",
+	"key",
+	"password",
+	"secret",
+	"signature",
+	"token",
+}
+
+var (
+	redactedFieldsLock sync.RWMutex
+	redactedFields     = map[string]struct{}{}
+
+	// redactedHostFields are the fields which are redacted only for requests to a host ending in the
+	// (lower-cased) suffix, used for data plane APIs which use generic field names (e.g. `value`)
+	redactedHostFields = map[string]map[string]struct{}{}
+)
+
+// RegisterRedactedFields registers additional fields whose values should be redacted from traces,
+// for fields containing sensitive values which aren't matched by the default list.
+//
+// Each field is either the name of a field which is redacted wherever it appears (e.g. `primary`)
+// or the path to a field, separated by a `.`, (e.g. `keys.value`) - where lists are transparent.
+func RegisterRedactedFields(fields ...string) {
+	redactedFieldsLock.Lock()
+	defer redactedFieldsLock.Unlock()
+
+	for _, field := range fields {
+		redactedFields[strings.ToLower(field)] = struct{}{}
+	}
+}
+
+// RegisterRedactedFieldsForHost registers additional fields whose values should be redacted from traces
+// of requests sent to any host ending in the specified suffix (e.g. `vault.azure.net`), for data plane
+// APIs where a field name (such as `value`) is only sensitive for that API.
+//
+// Fields are specified in the same format as RegisterRedactedFields.
+func RegisterRedactedFieldsForHost(hostSuffix string, fields ...string) {
+	redactedFieldsLock.Lock()
+	defer redactedFieldsLock.Unlock()
+
+	hostSuffix = strings.ToLower(strings.TrimPrefix(hostSuffix, "."))
+	if _, ok := redactedHostFields[hostSuffix]; !ok {
+		redactedHostFields[hostSuffix] = map[string]struct{}{}
+	}
+	for _, field := range fields {
+		redactedHostFields[hostSuffix][strings.ToLower(field)] = struct{}{}
+	}
+}
+
+func shouldRedactField(host, name, path string) bool {
+	name = strings.ToLower(name)
+	path = strings.ToLower(path)
+	unnumbered := strings.TrimRight(name, "0123456789")
+	for _, suffix := range redactedFieldSuffixes {
+		if strings.HasSuffix(unnumbered, suffix) {
+			return true
+		}
+	}
+
+	redactedFieldsLock.RLock()
+	defer redactedFieldsLock.RUnlock()
+
+	if matchesRedactedField(redactedFields, name, path) {
+		return true
+	}
+
+	host = strings.ToLower(host)
+	for hostSuffix, fields := range redactedHostFields {
+		if host == hostSuffix || strings.HasSuffix(host, "."+hostSuffix) {
+			if matchesRedactedField(fields, name, path) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func matchesRedactedField(fields map[string]struct{}, name, path string) bool {
+	if _, ok := fields[name]; ok {
+		return true
+	}
+	_, ok := fields[path]
+	return ok
+}
+
+// redactValue returns a copy of the (unmarshalled JSON) value sent to/received from the specified host
+// with any sensitive fields redacted
+func redactValue(input interface{}, host, path string) interface{} {
+	switch v := input.(type) {
+	case map[string]interface{}:
+		output := make(map[string]interface{}, len(v))
+		for key, val := range v {
+			fieldPath := key
+			if path != "" {
+				fieldPath = path + "." + key
+			}
+
+			// only values (rather than nested objects) are redacted, so the structure remains visible
+			if _, isString := val.(string); isString && shouldRedactField(host, key, fieldPath) {
+				output[key] = redactedValue
+				continue
+			}
+
+			output[key] = redactValue(val, host, fieldPath)
+		}
+		return output
+
+	case []interface{}:
+		output := make([]interface{}, len(v))
+		for i, val := range v {
+			output[i] = redactValue(val, host, path)
+		}
+		return output
+	}
+
+	return input
+}
@@ -0,0 +1,174 @@
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+// Tracer writes a JSON line for each HTTP request/response sent by the Provider's clients,
+// with any sensitive values (see RegisterRedactedFields) redacted
+type Tracer struct {
+	lock   sync.Mutex
+	writer io.Writer
+
+	// now is overridden in tests
+	now func() time.Time
+}
+
+// NewTracer returns a Tracer which appends to the file at the specified path
+func NewTracer(path string) (*Tracer, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("opening trace file %q: %+v", path, err)
+	}
+
+	return newTracer(file), nil
+}
+
+func newTracer(writer io.Writer) *Tracer {
+	return &Tracer{
+		writer: writer,
+		now:    time.Now,
+	}
+}
+
+type traceEntry struct {
+	Timestamp     string      `json:"timestamp"`
+	CorrelationId string      `json:"correlation_id,omitempty"`
+	RequestId     string      `json:"request_id,omitempty"`
+	Method        string      `json:"method"`
+	URL           string      `json:"url"`
+	ResourceType  string      `json:"resource_type,omitempty"`
+	StatusCode    int         `json:"status_code,omitempty"`
+	DurationMs    int64       `json:"duration_ms"`
+	RequestBody   interface{} `json:"request_body,omitempty"`
+	ResponseBody  interface{} `json:"response_body,omitempty"`
+	Error         string      `json:"error,omitempty"`
+}
+
+// WithTracing returns a Sender which traces each request sent via the specified Sender
+func (t *Tracer) WithTracing(sender autorest.Sender) autorest.Sender {
+	return autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+		entry := traceEntry{
+			Timestamp:     t.now().UTC().Format(time.RFC3339Nano),
+			CorrelationId: r.Header.Get(HeaderCorrelationRequestID),
+			Method:        r.Method,
+			URL:           redactUrl(r.URL),
+			ResourceType:  resourceTypeFromPath(r.URL.Path),
+		}
+
+		if r.Body != nil && r.Body != http.NoBody {
+			body, err := ioutil.ReadAll(r.Body)
+			r.Body.Close()
+			if err != nil {
+				return nil, fmt.Errorf("reading request body: %+v", err)
+			}
+			r.Body = ioutil.NopCloser(bytes.NewReader(body))
+			entry.RequestBody = redactBody(r.URL.Hostname(), body)
+		}
+
+		start := t.now()
+		resp, err := sender.Do(r)
+		entry.DurationMs = t.now().Sub(start).Milliseconds()
+
+		if err != nil {
+			entry.Error = err.Error()
+		}
+		if resp != nil {
+			entry.StatusCode = resp.StatusCode
+			entry.RequestId = resp.Header.Get("x-ms-request-id")
+
+			if resp.Body != nil {
+				body, readErr := ioutil.ReadAll(resp.Body)
+				resp.Body.Close()
+				resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+				if readErr != nil {
+					return resp, fmt.Errorf("reading response body: %+v", readErr)
+				}
+				entry.ResponseBody = redactBody(r.URL.Hostname(), body)
+			}
+		}
+
+		t.write(entry)
+		return resp, err
+	})
+}
+
+func (t *Tracer) write(entry traceEntry) {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		log.Printf("[DEBUG] Tracing: unable to serialize trace for %s %s: %+v", entry.Method, entry.URL, err)
+		return
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if _, err := t.writer.Write(append(line, '\n')); err != nil {
+		log.Printf("[DEBUG] Tracing: unable to write trace for %s %s: %+v", entry.Method, entry.URL, err)
+	}
+}
+
+// redactBody returns the body sent to/received from the specified host with any sensitive fields redacted -
+// bodies which aren't JSON are omitted since it's not possible to determine if these contain sensitive values
+func redactBody(host string, body []byte) interface{} {
+	if len(body) == 0 {
+		return nil
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return fmt.Sprintf("(omitted %d bytes of non-JSON content)", len(body))
+	}
+
+	return redactValue(value, host, "")
+}
+
+// redactUrl returns the URL with any Shared Access Signature redacted
+func redactUrl(input *url.URL) string {
+	output := *input
+	values := output.Query()
+	if values.Get("sig") != "" {
+		values.Set("sig", redactedValue)
+		output.RawQuery = values.Encode()
+	}
+	return output.String()
+}
+
+// resourceTypeFromPath returns the Resource Type for a Resource Manager path,
+// e.g. `Microsoft.Network/virtualNetworks/subnets`
+func resourceTypeFromPath(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) < 2 || !strings.EqualFold(segments[0], "subscriptions") {
+		return ""
+	}
+
+	// find the innermost Resource Provider, the types are every other segment which follows it
+	for i := len(segments) - 2; i >= 2; i-- {
+		if strings.EqualFold(segments[i], "providers") {
+			types := []string{segments[i+1]}
+			for j := i + 2; j < len(segments); j += 2 {
+				types = append(types, segments[j])
+			}
+			return strings.Join(types, "/")
+		}
+	}
+
+	if len(segments) >= 3 && strings.EqualFold(segments[2], "resourceGroups") {
+		return "Microsoft.Resources/resourceGroups"
+	}
+
+	return ""
+}
@@ -0,0 +1,218 @@
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+func TestTracerWritesRedactedEntry(t *testing.T) {
+	buf := &bytes.Buffer{}
+	tracer := newTracer(buf)
+	tracer.now = func() time.Time {
+		return time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	sender := tracer.WithTracing(autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+		// the request body must still be readable by the underlying sender
+		body, _ := ioutil.ReadAll(r.Body)
+		if !strings.Contains(string(body), "hunter2") {
+			t.Fatalf("expected the request body to be passed through but got %q", string(body))
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"X-Ms-Request-Id": []string{"abc123"},
+			},
+			Body: ioutil.NopCloser(strings.NewReader(`{"primaryKey":"secret-value","name":"example"}`)),
+		}, nil
+	}))
+
+	req, _ := http.NewRequest(http.MethodPut, "https://management.azure.com/subscriptions/000/resourceGroups/group1/providers/Microsoft.Sql/servers/server1?api-version=2020-01-01", strings.NewReader(`{"properties":{"administratorLoginPassword":"hunter2","version":"12.0"}}`))
+	req.Header.Set(HeaderCorrelationRequestID, "my-correlation-id")
+	resp, err := sender.Do(req)
+	if err != nil {
+		t.Fatalf("sending request: %+v", err)
+	}
+
+	// the response body must still be readable by the caller
+	body, _ := ioutil.ReadAll(resp.Body)
+	if !strings.Contains(string(body), "secret-value") {
+		t.Fatalf("expected the response body to be passed through but got %q", string(body))
+	}
+
+	line := buf.String()
+	if strings.Contains(line, "hunter2") || strings.Contains(line, "secret-value") {
+		t.Fatalf("expected sensitive values to be redacted but got %s", line)
+	}
+
+	var entry traceEntry
+	if err := json.Unmarshal([]byte(line), &entry); err != nil {
+		t.Fatalf("parsing trace entry %q: %+v", line, err)
+	}
+	if entry.CorrelationId != "my-correlation-id" {
+		t.Fatalf("expected the correlation id to be `my-correlation-id` but got %q", entry.CorrelationId)
+	}
+	if entry.RequestId != "abc123" {
+		t.Fatalf("expected the request id to be `abc123` but got %q", entry.RequestId)
+	}
+	if entry.ResourceType != "Microsoft.Sql/servers" {
+		t.Fatalf("expected the resource type to be `Microsoft.Sql/servers` but got %q", entry.ResourceType)
+	}
+	if entry.StatusCode != http.StatusOK {
+		t.Fatalf("expected the status code to be 200 but got %d", entry.StatusCode)
+	}
+	if entry.Timestamp != "2021-01-01T00:00:00Z" {
+		t.Fatalf("expected the timestamp to be `2021-01-01T00:00:00Z` but got %q", entry.Timestamp)
+	}
+
+	response := entry.ResponseBody.(map[string]interface{})
+	if response["name"] != "example" {
+		t.Fatalf("expected non-sensitive values to be retained but got %+v", response)
+	}
+}
+
+func TestRedactBody(t *testing.T) {
+	RegisterRedactedFields("keys.value", "passwords.value", "primary")
+
+	testData := []struct {
+		input    string
+		expected string
+	}{
+		{
+			input:    ``,
+			expected: `null`,
+		},
+		{
+			input:    `{"name":"example","tags":{"environment":"Production"}}`,
+			expected: `{"name":"example","tags":{"environment":"Production"}}`,
+		},
+		{
+			input:    `{"properties":{"connectionString":"Server=foo","sasToken":"abc","storageAccountKey":"def"}}`,
+			expected: `{"properties":{"connectionString":"REDACTED","sasToken":"REDACTED","storageAccountKey":"REDACTED"}}`,
+		},
+		{
+			// nested objects aren't redacted, only their values
+			input:    `{"properties":{"keyVaultProperties":{"keyName":"example","keyVersion":"1"}}}`,
+			expected: `{"properties":{"keyVaultProperties":{"keyName":"example","keyVersion":"1"}}}`,
+		},
+		{
+			input:    `{"keys":[{"keyName":"key1","value":"abc"},{"keyName":"key2","value":"def"}],"value":"retained"}`,
+			expected: `{"keys":[{"keyName":"key1","value":"REDACTED"},{"keyName":"key2","value":"REDACTED"}],"value":"retained"}`,
+		},
+		{
+			// e.g. Cognitive Services Accounts and Event Grid Topics
+			input:    `{"key1":"abc","key2":"def","keyCount":2}`,
+			expected: `{"key1":"REDACTED","key2":"REDACTED","keyCount":2}`,
+		},
+		{
+			// e.g. Container Registry credentials
+			input:    `{"username":"example","passwords":[{"name":"password","value":"abc"},{"name":"password2","value":"def"}]}`,
+			expected: `{"passwords":[{"name":"password","value":"REDACTED"},{"name":"password2","value":"REDACTED"}],"username":"example"}`,
+		},
+		{
+			input:    `{"accountName":"example","primary":"abc"}`,
+			expected: `{"accountName":"example","primary":"REDACTED"}`,
+		},
+		{
+			input:    `<html>hello</html>`,
+			expected: `"(omitted 18 bytes of non-JSON content)"`,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.input)
+
+		actual, err := json.Marshal(redactBody("management.azure.com", []byte(v.input)))
+		if err != nil {
+			t.Fatalf("serializing: %+v", err)
+		}
+		if string(actual) != v.expected {
+			t.Fatalf("expected %s but got %s", v.expected, string(actual))
+		}
+	}
+}
+
+func TestTracerRedactsKeyVaultSecrets(t *testing.T) {
+	RegisterRedactedFieldsForHost("vault.azure.net", "value")
+
+	buf := &bytes.Buffer{}
+	tracer := newTracer(buf)
+	sender := tracer.WithTracing(autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(`{"id":"https://example.vault.azure.net/secrets/x/1","value":"response-secret"}`)),
+		}, nil
+	}))
+
+	req, _ := http.NewRequest(http.MethodPut, "https://example.vault.azure.net/secrets/x?api-version=7.1", strings.NewReader(`{"value":"request-secret","contentType":"text/plain"}`))
+	if _, err := sender.Do(req); err != nil {
+		t.Fatalf("sending request: %+v", err)
+	}
+
+	line := buf.String()
+	if strings.Contains(line, "request-secret") || strings.Contains(line, "response-secret") {
+		t.Fatalf("expected the secret values to be redacted but got %s", line)
+	}
+
+	var entry traceEntry
+	if err := json.Unmarshal([]byte(line), &entry); err != nil {
+		t.Fatalf("parsing trace entry %q: %+v", line, err)
+	}
+	request := entry.RequestBody.(map[string]interface{})
+	if request["value"] != redactedValue || request["contentType"] != "text/plain" {
+		t.Fatalf("expected only the `value` to be redacted but got %+v", request)
+	}
+
+	// `value` remains visible for other hosts
+	if actual := redactBody("management.azure.com", []byte(`{"value":"retained"}`)); actual.(map[string]interface{})["value"] != "retained" {
+		t.Fatalf("expected `value` to be retained for Resource Manager but got %+v", actual)
+	}
+}
+
+func TestResourceTypeFromPath(t *testing.T) {
+	testData := []struct {
+		path     string
+		expected string
+	}{
+		{
+			path:     "/subscriptions/000",
+			expected: "",
+		},
+		{
+			path:     "/subscriptions/000/resourceGroups/group1",
+			expected: "Microsoft.Resources/resourceGroups",
+		},
+		{
+			path:     "/subscriptions/000/providers/Microsoft.Compute",
+			expected: "Microsoft.Compute",
+		},
+		{
+			path:     "/subscriptions/000/resourceGroups/group1/providers/Microsoft.Network/virtualNetworks/network1/subnets/subnet1",
+			expected: "Microsoft.Network/virtualNetworks/subnets",
+		},
+		{
+			path:     "/subscriptions/000/resourceGroups/group1/providers/Microsoft.Network/virtualNetworks/network1/providers/Microsoft.Insights/diagnosticSettings/setting1",
+			expected: "Microsoft.Insights/diagnosticSettings",
+		},
+		{
+			path:     "/container/blob.txt",
+			expected: "",
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.path)
+
+		if actual := resourceTypeFromPath(v.path); actual != v.expected {
+			t.Fatalf("expected %q but got %q", v.expected, actual)
+		}
+	}
+}
@@ -31,5 +31,6 @@ func Default() UserFeatures {
 			ForceDelete:               false,
 			RollInstancesWhenRequired: true,
 		},
+		ReadOnly: false,
 	}
 }
@@ -9,6 +9,9 @@ type UserFeatures struct {
 	TemplateDeployment     TemplateDeploymentFeatures
 	LogAnalyticsWorkspace  LogAnalyticsWorkspaceFeatures
 	ResourceGroup          ResourceGroupFeatures
+
+	// ReadOnly prevents the Provider from sending any requests which could modify infrastructure
+	ReadOnly bool
 }
 
 type CognitiveAccountFeatures struct {
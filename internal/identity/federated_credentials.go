@@ -0,0 +1,132 @@
+package identity
+
+import (
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+// DefaultFederatedCredentialAudience is the audience recommended by Azure Active Directory for
+// Federated Identity Credentials
+const DefaultFederatedCredentialAudience = "api://AzureADTokenExchange"
+
+// FederatedCredential is a Federated Identity Credential, which allows a token issued by an external
+// Identity Provider (for example a Kubernetes Service Account or GitHub Actions) to be exchanged for
+// a token for a User Assigned Identity/Application
+type FederatedCredential struct {
+	Name      string   `tfschema:"name"`
+	Issuer    string   `tfschema:"issuer"`
+	Subject   string   `tfschema:"subject"`
+	Audiences []string `tfschema:"audiences"`
+}
+
+// FederatedCredentialProperties is the representation of a Federated Identity Credential used by the API
+type FederatedCredentialProperties struct {
+	Issuer    *string   `json:"issuer,omitempty"`
+	Subject   *string   `json:"subject,omitempty"`
+	Audiences *[]string `json:"audiences,omitempty"`
+}
+
+// FederatedCredentials contains helpers for working with a list of Federated Identity Credentials
+type FederatedCredentials struct{}
+
+func (f FederatedCredentials) Expand(input []interface{}) []FederatedCredential {
+	output := make([]FederatedCredential, 0)
+	for _, item := range input {
+		if item == nil {
+			continue
+		}
+		v := item.(map[string]interface{})
+
+		output = append(output, FederatedCredential{
+			Name:      v["name"].(string),
+			Issuer:    v["issuer"].(string),
+			Subject:   v["subject"].(string),
+			Audiences: *utils.ExpandStringSlice(v["audiences"].([]interface{})),
+		})
+	}
+	return output
+}
+
+func (f FederatedCredentials) Flatten(input []FederatedCredential) []interface{} {
+	output := make([]interface{}, 0)
+	for _, item := range input {
+		audiences := item.Audiences
+		output = append(output, map[string]interface{}{
+			"name":      item.Name,
+			"issuer":    item.Issuer,
+			"subject":   item.Subject,
+			"audiences": utils.FlattenStringSlice(&audiences),
+		})
+	}
+	return output
+}
+
+func (f FederatedCredentials) Schema() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Optional: true,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"name": {
+					Type:         pluginsdk.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+				"issuer": {
+					Type:         pluginsdk.TypeString,
+					Required:     true,
+					ValidateFunc: validation.IsURLWithHTTPS,
+				},
+				"subject": {
+					Type:         pluginsdk.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+				"audiences": {
+					Type:     pluginsdk.TypeList,
+					Optional: true,
+					Computed: true,
+					Elem: &pluginsdk.Schema{
+						Type:         pluginsdk.TypeString,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+				},
+			},
+		},
+	}
+}
+
+// ToProperties returns the API representation of this Federated Identity Credential, using the default
+// audience when no audiences are specified
+func (c FederatedCredential) ToProperties() FederatedCredentialProperties {
+	audiences := c.Audiences
+	if len(audiences) == 0 {
+		audiences = []string{DefaultFederatedCredentialAudience}
+	}
+
+	return FederatedCredentialProperties{
+		Issuer:    stringPointer(c.Issuer),
+		Subject:   stringPointer(c.Subject),
+		Audiences: &audiences,
+	}
+}
+
+// FederatedCredentialFromProperties returns the Federated Identity Credential with the specified name from
+// the API representation
+func FederatedCredentialFromProperties(name string, input *FederatedCredentialProperties) FederatedCredential {
+	output := FederatedCredential{
+		Name:      name,
+		Audiences: []string{},
+	}
+	if input == nil {
+		return output
+	}
+
+	output.Issuer = stringValue(input.Issuer)
+	output.Subject = stringValue(input.Subject)
+	if input.Audiences != nil {
+		output.Audiences = *input.Audiences
+	}
+	return output
+}
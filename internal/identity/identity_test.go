@@ -0,0 +1,340 @@
+package identity
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+const (
+	testIdentityId1 = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.ManagedIdentity/userAssignedIdentities/identity1"
+	testIdentityId2 = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.ManagedIdentity/userAssignedIdentities/identity2"
+)
+
+// TestSchemaRoundTrip ensures that each identity shape can be flattened into the Schema and expanded
+// back into the same configuration
+func TestSchemaRoundTrip(t *testing.T) {
+	testData := []struct {
+		Name     string
+		Identity Identity
+		Input    ExpandedConfig
+	}{
+		{
+			Name:     "SystemAssigned - None",
+			Identity: SystemAssigned{},
+			Input:    ExpandedConfig{Type: none},
+		},
+		{
+			Name:     "SystemAssigned",
+			Identity: SystemAssigned{},
+			Input:    ExpandedConfig{Type: systemAssigned},
+		},
+		{
+			Name:     "UserAssigned - None",
+			Identity: UserAssigned{},
+			Input:    ExpandedConfig{Type: none},
+		},
+		{
+			Name:     "UserAssigned",
+			Identity: UserAssigned{},
+			Input: ExpandedConfig{
+				Type:                    userAssigned,
+				UserAssignedIdentityIds: []string{testIdentityId1, testIdentityId2},
+			},
+		},
+		{
+			Name:     "SystemAssignedUserAssigned - SystemAssigned",
+			Identity: SystemAssignedUserAssigned{},
+			Input:    ExpandedConfig{Type: systemAssigned},
+		},
+		{
+			Name:     "SystemAssignedUserAssigned - UserAssigned",
+			Identity: SystemAssignedUserAssigned{},
+			Input: ExpandedConfig{
+				Type:                    userAssigned,
+				UserAssignedIdentityIds: []string{testIdentityId1},
+			},
+		},
+		{
+			Name:     "SystemAssignedUserAssigned - SystemAssigned, UserAssigned",
+			Identity: SystemAssignedUserAssigned{},
+			Input: ExpandedConfig{
+				Type:                    systemAssignedUserAssigned,
+				UserAssignedIdentityIds: []string{testIdentityId1, testIdentityId2},
+			},
+		},
+		{
+			Name:     "SystemOrUserAssigned - SystemAssigned",
+			Identity: SystemOrUserAssigned{},
+			Input:    ExpandedConfig{Type: systemAssigned},
+		},
+		{
+			Name:     "SystemOrUserAssigned - UserAssigned",
+			Identity: SystemOrUserAssigned{},
+			Input: ExpandedConfig{
+				Type:                    userAssigned,
+				UserAssignedIdentityIds: []string{testIdentityId2},
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.Name)
+
+		d := schema.TestResourceDataRaw(t, map[string]*pluginsdk.Schema{
+			"identity": v.Identity.Schema(),
+		}, map[string]interface{}{})
+		if err := d.Set("identity", v.Identity.Flatten(&v.Input)); err != nil {
+			t.Fatalf("setting `identity`: %+v", err)
+		}
+
+		actual, err := v.Identity.Expand(d.Get("identity").([]interface{}))
+		if err != nil {
+			t.Fatalf("expanding: %+v", err)
+		}
+		if actual.Type != v.Input.Type {
+			t.Fatalf("expected the type %q but got %q", v.Input.Type, actual.Type)
+		}
+		assertIdentityIds(t, v.Input.UserAssignedIdentityIds, actual.UserAssignedIdentityIds)
+	}
+}
+
+// TestApiRoundTrip ensures that each API representation of an identity can be serialized to/from JSON
+// and converted to/from the ExpandedConfig without losing information
+func TestApiRoundTrip(t *testing.T) {
+	newCasters := map[string]func() ExpandedConfigCaster{
+		"SystemAssignedIdentity":         func() ExpandedConfigCaster { return &SystemAssignedIdentity{} },
+		"UserAssignedIdentityList":       func() ExpandedConfigCaster { return &UserAssignedIdentityList{} },
+		"UserAssignedIdentityMap":        func() ExpandedConfigCaster { return &UserAssignedIdentityMap{} },
+		"SystemUserAssignedIdentityList": func() ExpandedConfigCaster { return &SystemUserAssignedIdentityList{} },
+		"SystemUserAssignedIdentityMap":  func() ExpandedConfigCaster { return &SystemUserAssignedIdentityMap{} },
+	}
+	supportsSystemAssigned := map[string]bool{
+		"SystemAssignedIdentity":         true,
+		"SystemUserAssignedIdentityList": true,
+		"SystemUserAssignedIdentityMap":  true,
+	}
+	supportsUserAssigned := map[string]bool{
+		"UserAssignedIdentityList":       true,
+		"UserAssignedIdentityMap":        true,
+		"SystemUserAssignedIdentityList": true,
+		"SystemUserAssignedIdentityMap":  true,
+	}
+
+	for name, newCaster := range newCasters {
+		t.Logf("[DEBUG] Testing %q..", name)
+
+		input := ExpandedConfig{
+			Type: userAssigned,
+		}
+		if supportsSystemAssigned[name] {
+			input.Type = systemAssigned
+			input.PrincipalId = "11111111-1111-1111-1111-111111111111"
+			input.TenantId = "22222222-2222-2222-2222-222222222222"
+		}
+		if supportsUserAssigned[name] {
+			if supportsSystemAssigned[name] {
+				input.Type = systemAssignedUserAssigned
+			}
+			input.UserAssignedIdentityIds = []string{testIdentityId1, testIdentityId2}
+		}
+
+		caster := newCaster()
+		caster.FromExpandedConfig(input)
+		payload, err := json.Marshal(caster)
+		if err != nil {
+			t.Fatalf("serializing: %+v", err)
+		}
+
+		result := newCaster()
+		if err := json.Unmarshal(payload, result); err != nil {
+			t.Fatalf("deserializing %s: %+v", string(payload), err)
+		}
+		actual := result.ToExpandedConfig()
+
+		if actual.Type != input.Type {
+			t.Fatalf("expected the type %q but got %q", input.Type, actual.Type)
+		}
+		if actual.PrincipalId != input.PrincipalId {
+			t.Fatalf("expected the principal id %q but got %q", input.PrincipalId, actual.PrincipalId)
+		}
+		if actual.TenantId != input.TenantId {
+			t.Fatalf("expected the tenant id %q but got %q", input.TenantId, actual.TenantId)
+		}
+		assertIdentityIds(t, input.UserAssignedIdentityIds, actual.UserAssignedIdentityIds)
+	}
+}
+
+func TestUserAssignedIdentityDetails(t *testing.T) {
+	payloads := map[string]ExpandedConfigCaster{
+		`{"type":"SystemAssigned,UserAssigned","principalId":"11111111-1111-1111-1111-111111111111","tenantId":"22222222-2222-2222-2222-222222222222","userAssignedIdentities":{"` + testIdentityId1 + `":{"clientId":"33333333-3333-3333-3333-333333333333","principalId":"44444444-4444-4444-4444-444444444444"}}}`:               &SystemUserAssignedIdentityMap{},
+		`{"type":"systemassigned, userassigned","principalId":"11111111-1111-1111-1111-111111111111","tenantId":"22222222-2222-2222-2222-222222222222","userAssignedIdentities":[{"resourceId":"` + testIdentityId1 + `","clientId":"33333333-3333-3333-3333-333333333333","principalId":"44444444-4444-4444-4444-444444444444"}]}`: &SystemUserAssignedIdentityList{},
+	}
+
+	for payload, caster := range payloads {
+		t.Logf("[DEBUG] Testing %q..", payload)
+
+		if err := json.Unmarshal([]byte(payload), caster); err != nil {
+			t.Fatalf("deserializing: %+v", err)
+		}
+		actual := caster.ToExpandedConfig()
+
+		if actual.Type != systemAssignedUserAssigned {
+			t.Fatalf("expected the type to be normalized to %q but got %q", systemAssignedUserAssigned, actual.Type)
+		}
+		expected := map[string]UserAssignedIdentityDetails{
+			testIdentityId1: {
+				ClientId:    "33333333-3333-3333-3333-333333333333",
+				PrincipalId: "44444444-4444-4444-4444-444444444444",
+			},
+		}
+		if !reflect.DeepEqual(actual.UserAssignedIdentityDetails, expected) {
+			t.Fatalf("expected the details %+v but got %+v", expected, actual.UserAssignedIdentityDetails)
+		}
+	}
+}
+
+func TestNormalizeType(t *testing.T) {
+	testData := map[Type]Type{
+		"":                             none,
+		"none":                         none,
+		"SystemAssigned":               systemAssigned,
+		"systemAssigned":               systemAssigned,
+		"UserAssigned":                 userAssigned,
+		"SystemAssigned,UserAssigned":  systemAssignedUserAssigned,
+		"SystemAssigned, UserAssigned": systemAssignedUserAssigned,
+		"Delegated":                    "Delegated",
+	}
+
+	for input, expected := range testData {
+		t.Logf("[DEBUG] Testing %q..", input)
+
+		if actual := normalizeType(input); actual != expected {
+			t.Fatalf("expected %q but got %q", expected, actual)
+		}
+	}
+}
+
+func TestSystemOrUserAssignedValidation(t *testing.T) {
+	testData := []struct {
+		Name        string
+		Type        string
+		IdentityIds []interface{}
+		ShouldError bool
+	}{
+		{
+			Name:        "SystemAssigned",
+			Type:        string(systemAssigned),
+			ShouldError: false,
+		},
+		{
+			Name:        "SystemAssigned with Identity IDs",
+			Type:        string(systemAssigned),
+			IdentityIds: []interface{}{testIdentityId1},
+			ShouldError: true,
+		},
+		{
+			Name:        "UserAssigned without Identity IDs",
+			Type:        string(userAssigned),
+			ShouldError: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.Name)
+
+		_, err := SystemOrUserAssigned{}.Expand([]interface{}{
+			map[string]interface{}{
+				"type":         v.Type,
+				"identity_ids": pluginsdk.NewSet(pluginsdk.HashString, v.IdentityIds),
+			},
+		})
+		if v.ShouldError && err == nil {
+			t.Fatalf("expected an error but didn't get one")
+		}
+		if !v.ShouldError && err != nil {
+			t.Fatalf("expected no error but got %+v", err)
+		}
+	}
+}
+
+func TestTypedRoundTrip(t *testing.T) {
+	input := &ExpandedConfig{
+		Type:                    "SystemAssigned,UserAssigned",
+		UserAssignedIdentityIds: []string{testIdentityId1},
+	}
+
+	actual := ExpandTyped(FlattenTyped(input))
+	if actual.Type != systemAssignedUserAssigned {
+		t.Fatalf("expected the type %q but got %q", systemAssignedUserAssigned, actual.Type)
+	}
+	assertIdentityIds(t, input.UserAssignedIdentityIds, actual.UserAssignedIdentityIds)
+
+	if actual := ExpandTyped(FlattenTyped(nil)); actual.Type != none {
+		t.Fatalf("expected the type %q but got %q", none, actual.Type)
+	}
+}
+
+func TestFederatedCredentialsRoundTrip(t *testing.T) {
+	input := []FederatedCredential{
+		{
+			Name:      "kubernetes",
+			Issuer:    "https://oidc.prod-aks.azure.com/00000000-0000-0000-0000-000000000000/",
+			Subject:   "system:serviceaccount:default:workload",
+			Audiences: []string{DefaultFederatedCredentialAudience},
+		},
+		{
+			Name:      "github",
+			Issuer:    "https://token.actions.githubusercontent.com",
+			Subject:   "repo:example/example:ref:refs/heads/main",
+			Audiences: []string{"api://example"},
+		},
+	}
+
+	d := schema.TestResourceDataRaw(t, map[string]*pluginsdk.Schema{
+		"federated_credential": FederatedCredentials{}.Schema(),
+	}, map[string]interface{}{})
+	if err := d.Set("federated_credential", FederatedCredentials{}.Flatten(input)); err != nil {
+		t.Fatalf("setting `federated_credential`: %+v", err)
+	}
+
+	actual := FederatedCredentials{}.Expand(d.Get("federated_credential").([]interface{}))
+	if !reflect.DeepEqual(actual, input) {
+		t.Fatalf("expected %+v but got %+v", input, actual)
+	}
+
+	for _, credential := range input {
+		payload, err := json.Marshal(credential.ToProperties())
+		if err != nil {
+			t.Fatalf("serializing: %+v", err)
+		}
+		var properties FederatedCredentialProperties
+		if err := json.Unmarshal(payload, &properties); err != nil {
+			t.Fatalf("deserializing: %+v", err)
+		}
+		if actual := FederatedCredentialFromProperties(credential.Name, &properties); !reflect.DeepEqual(actual, credential) {
+			t.Fatalf("expected %+v but got %+v", credential, actual)
+		}
+	}
+
+	// the default audience is used when none are specified
+	properties := FederatedCredential{Name: "example"}.ToProperties()
+	if properties.Audiences == nil || len(*properties.Audiences) != 1 || (*properties.Audiences)[0] != DefaultFederatedCredentialAudience {
+		t.Fatalf("expected the default audience but got %+v", properties.Audiences)
+	}
+}
+
+func assertIdentityIds(t *testing.T, expected []string, actual []string) {
+	expectedIds := append([]string{}, expected...)
+	actualIds := append([]string{}, actual...)
+	sort.Strings(expectedIds)
+	sort.Strings(actualIds)
+
+	if !reflect.DeepEqual(expectedIds, actualIds) {
+		t.Fatalf("expected the identity ids %+v but got %+v", expectedIds, actualIds)
+	}
+}
@@ -1,6 +1,8 @@
 package identity
 
 import (
+	"strings"
+
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 )
 
@@ -13,6 +15,23 @@ const (
 	systemAssignedUserAssigned Type = "SystemAssigned, UserAssigned"
 )
 
+// normalizeType returns the Type in the casing/format used within Terraform, since some API's return the
+// type in a different casing or without the space (e.g. `SystemAssigned,UserAssigned`)
+func normalizeType(input Type) Type {
+	if input == "" {
+		return none
+	}
+
+	normalized := strings.ReplaceAll(strings.ToLower(string(input)), " ", "")
+	for _, t := range []Type{none, systemAssigned, userAssigned, systemAssignedUserAssigned} {
+		if normalized == strings.ReplaceAll(strings.ToLower(string(t)), " ", "") {
+			return t
+		}
+	}
+
+	return input
+}
+
 type ExpandedConfig struct {
 	// Type is the type of User Assigned Identity, either `None`, `SystemAssigned`, `UserAssigned`
 	// or `SystemAssigned, UserAssigned`
@@ -20,6 +39,16 @@ type ExpandedConfig struct {
 	PrincipalId             string   `tfschema:"principal_id"`
 	TenantId                string   `tfschema:"tenant_id"`
 	UserAssignedIdentityIds []string `tfschema:"identity_ids"`
+
+	// UserAssignedIdentityDetails contains the Client/Principal/Tenant ID for each User Assigned Identity
+	// (keyed by the User Assigned Identity ID) where these are returned by the API
+	UserAssignedIdentityDetails map[string]UserAssignedIdentityDetails
+}
+
+type UserAssignedIdentityDetails struct {
+	ClientId    string
+	PrincipalId string
+	TenantId    string
 }
 
 type Identity interface {
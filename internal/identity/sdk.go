@@ -1,5 +1,7 @@
 package identity
 
+import "sort"
+
 type userAssignedIdentity struct {
 	ResourceId *string `json:"resourceId,omitempty"`
 	userAssignedIdentityInfo
@@ -8,6 +10,19 @@ type userAssignedIdentity struct {
 type userAssignedIdentityInfo struct {
 	ClientId    *string `json:"clientId,omitempty"`
 	PrincipalId *string `json:"principalId,omitempty"`
+	TenantId    *string `json:"tenantId,omitempty"`
+}
+
+func (u *userAssignedIdentityInfo) toDetails() UserAssignedIdentityDetails {
+	if u == nil {
+		return UserAssignedIdentityDetails{}
+	}
+
+	return UserAssignedIdentityDetails{
+		ClientId:    stringValue(u.ClientId),
+		PrincipalId: stringValue(u.PrincipalId),
+		TenantId:    stringValue(u.TenantId),
+	}
 }
 
 type ExpandedConfigCaster interface {
@@ -27,18 +42,11 @@ func (s *SystemAssignedIdentity) ToExpandedConfig() ExpandedConfig {
 	if s == nil {
 		return ExpandedConfig{}
 	}
-	principalId := ""
-	if s.PrincipalId != nil {
-		principalId = *s.PrincipalId
-	}
-	tenantId := ""
-	if s.TenantId != nil {
-		tenantId = *s.TenantId
-	}
+
 	return ExpandedConfig{
-		Type:        s.Type,
-		PrincipalId: principalId,
-		TenantId:    tenantId,
+		Type:        normalizeType(s.Type),
+		PrincipalId: stringValue(s.PrincipalId),
+		TenantId:    stringValue(s.TenantId),
 	}
 }
 
@@ -48,8 +56,8 @@ func (s *SystemAssignedIdentity) FromExpandedConfig(config ExpandedConfig) {
 	}
 	*s = SystemAssignedIdentity{
 		Type:        config.Type,
-		TenantId:    &config.TenantId,
-		PrincipalId: &config.PrincipalId,
+		TenantId:    stringPointer(config.TenantId),
+		PrincipalId: stringPointer(config.PrincipalId),
 	}
 }
 
@@ -65,21 +73,9 @@ func (u *UserAssignedIdentityList) ToExpandedConfig() ExpandedConfig {
 		return ExpandedConfig{}
 	}
 	out := ExpandedConfig{
-		Type: u.Type,
+		Type: normalizeType(u.Type),
 	}
-
-	if u.UserAssignedIdentities == nil {
-		return out
-	}
-
-	var identities []string
-	for _, id := range *u.UserAssignedIdentities {
-		if id.ResourceId == nil {
-			continue
-		}
-		identities = append(identities, *id.PrincipalId)
-	}
-	out.UserAssignedIdentityIds = identities
+	out.UserAssignedIdentityIds, out.UserAssignedIdentityDetails = flattenUserAssignedIdentityList(u.UserAssignedIdentities)
 
 	return out
 }
@@ -89,20 +85,9 @@ func (u *UserAssignedIdentityList) FromExpandedConfig(config ExpandedConfig) {
 		return
 	}
 	*u = UserAssignedIdentityList{
-		Type: config.Type,
-	}
-
-	if len(config.UserAssignedIdentityIds) == 0 {
-		return
-	}
-
-	var identities []userAssignedIdentity
-	for _, id := range config.UserAssignedIdentityIds {
-		identities = append(identities, userAssignedIdentity{
-			ResourceId: &id,
-		})
+		Type:                   config.Type,
+		UserAssignedIdentities: expandUserAssignedIdentityList(config.UserAssignedIdentityIds),
 	}
-	u.UserAssignedIdentities = &identities
 }
 
 var _ ExpandedConfigCaster = &UserAssignedIdentityMap{}
@@ -117,15 +102,9 @@ func (u *UserAssignedIdentityMap) ToExpandedConfig() ExpandedConfig {
 		return ExpandedConfig{}
 	}
 	out := ExpandedConfig{
-		Type: u.Type,
-	}
-
-	var identities []string
-	for k := range u.UserAssignedIdentities {
-		identities = append(identities, k)
+		Type: normalizeType(u.Type),
 	}
-
-	out.UserAssignedIdentityIds = identities
+	out.UserAssignedIdentityIds, out.UserAssignedIdentityDetails = flattenUserAssignedIdentityMap(u.UserAssignedIdentities)
 
 	return out
 }
@@ -136,17 +115,8 @@ func (u *UserAssignedIdentityMap) FromExpandedConfig(config ExpandedConfig) {
 	}
 
 	*u = UserAssignedIdentityMap{
-		Type: config.Type,
-	}
-
-	if len(config.UserAssignedIdentityIds) == 0 {
-		return
-	}
-
-	u.UserAssignedIdentities = map[string]*userAssignedIdentityInfo{}
-	for _, id := range config.UserAssignedIdentityIds {
-		// The user assigned identity information is not used by the provider. So simply assign the value to nil.
-		u.UserAssignedIdentities[id] = nil
+		Type:                   config.Type,
+		UserAssignedIdentities: expandUserAssignedIdentityMap(config.UserAssignedIdentityIds),
 	}
 }
 
@@ -163,33 +133,13 @@ func (s *SystemUserAssignedIdentityList) ToExpandedConfig() ExpandedConfig {
 	if s == nil {
 		return ExpandedConfig{}
 	}
-	principalId := ""
-	if s.PrincipalId != nil {
-		principalId = *s.PrincipalId
-	}
-	tenantId := ""
-	if s.TenantId != nil {
-		tenantId = *s.TenantId
-	}
 
 	out := ExpandedConfig{
-		Type:        s.Type,
-		PrincipalId: principalId,
-		TenantId:    tenantId,
-	}
-
-	if s.UserAssignedIdentities == nil {
-		return out
-	}
-
-	var identities []string
-	for _, id := range *s.UserAssignedIdentities {
-		if id.ResourceId == nil {
-			continue
-		}
-		identities = append(identities, *id.ResourceId)
+		Type:        normalizeType(s.Type),
+		PrincipalId: stringValue(s.PrincipalId),
+		TenantId:    stringValue(s.TenantId),
 	}
-	out.UserAssignedIdentityIds = identities
+	out.UserAssignedIdentityIds, out.UserAssignedIdentityDetails = flattenUserAssignedIdentityList(s.UserAssignedIdentities)
 
 	return out
 }
@@ -200,22 +150,11 @@ func (s *SystemUserAssignedIdentityList) FromExpandedConfig(config ExpandedConfi
 	}
 
 	*s = SystemUserAssignedIdentityList{
-		Type:        config.Type,
-		TenantId:    &config.TenantId,
-		PrincipalId: &config.PrincipalId,
-	}
-
-	if len(config.UserAssignedIdentityIds) == 0 {
-		return
-	}
-
-	var identities []userAssignedIdentity
-	for _, id := range config.UserAssignedIdentityIds {
-		identities = append(identities, userAssignedIdentity{
-			ResourceId: &id,
-		})
+		Type:                   config.Type,
+		TenantId:               stringPointer(config.TenantId),
+		PrincipalId:            stringPointer(config.PrincipalId),
+		UserAssignedIdentities: expandUserAssignedIdentityList(config.UserAssignedIdentityIds),
 	}
-	s.UserAssignedIdentities = &identities
 }
 
 var _ ExpandedConfigCaster = &SystemUserAssignedIdentityMap{}
@@ -231,26 +170,13 @@ func (s *SystemUserAssignedIdentityMap) ToExpandedConfig() ExpandedConfig {
 	if s == nil {
 		return ExpandedConfig{}
 	}
-	principalId := ""
-	if s.PrincipalId != nil {
-		principalId = *s.PrincipalId
-	}
-	tenantId := ""
-	if s.TenantId != nil {
-		tenantId = *s.TenantId
-	}
 
 	out := ExpandedConfig{
-		Type:        s.Type,
-		PrincipalId: principalId,
-		TenantId:    tenantId,
+		Type:        normalizeType(s.Type),
+		PrincipalId: stringValue(s.PrincipalId),
+		TenantId:    stringValue(s.TenantId),
 	}
-
-	var identities []string
-	for k := range s.UserAssignedIdentities {
-		identities = append(identities, k)
-	}
-	out.UserAssignedIdentityIds = identities
+	out.UserAssignedIdentityIds, out.UserAssignedIdentityDetails = flattenUserAssignedIdentityMap(s.UserAssignedIdentities)
 
 	return out
 }
@@ -261,18 +187,80 @@ func (s *SystemUserAssignedIdentityMap) FromExpandedConfig(config ExpandedConfig
 	}
 
 	*s = SystemUserAssignedIdentityMap{
-		Type:        config.Type,
-		TenantId:    &config.TenantId,
-		PrincipalId: &config.PrincipalId,
+		Type:                   config.Type,
+		TenantId:               stringPointer(config.TenantId),
+		PrincipalId:            stringPointer(config.PrincipalId),
+		UserAssignedIdentities: expandUserAssignedIdentityMap(config.UserAssignedIdentityIds),
 	}
+}
 
-	if len(config.UserAssignedIdentityIds) == 0 {
-		return
+func expandUserAssignedIdentityList(input []string) *[]userAssignedIdentity {
+	if len(input) == 0 {
+		return nil
+	}
+
+	identities := make([]userAssignedIdentity, 0, len(input))
+	for _, id := range input {
+		identities = append(identities, userAssignedIdentity{
+			ResourceId: stringPointer(id),
+		})
+	}
+	return &identities
+}
+
+func flattenUserAssignedIdentityList(input *[]userAssignedIdentity) ([]string, map[string]UserAssignedIdentityDetails) {
+	if input == nil {
+		return nil, nil
+	}
+
+	var identities []string
+	details := make(map[string]UserAssignedIdentityDetails)
+	for _, id := range *input {
+		if id.ResourceId == nil {
+			continue
+		}
+		identities = append(identities, *id.ResourceId)
+		details[*id.ResourceId] = id.userAssignedIdentityInfo.toDetails()
+	}
+	return identities, details
+}
+
+func expandUserAssignedIdentityMap(input []string) map[string]*userAssignedIdentityInfo {
+	if len(input) == 0 {
+		return nil
 	}
 
-	s.UserAssignedIdentities = map[string]*userAssignedIdentityInfo{}
-	for _, id := range config.UserAssignedIdentityIds {
-		// The user assigned identity information is not used by the provider. So simply assign the value to nil.
-		s.UserAssignedIdentities[id] = nil
+	identities := make(map[string]*userAssignedIdentityInfo, len(input))
+	for _, id := range input {
+		// the User Assigned Identity information is returned by the API (rather than being specified) so is omitted
+		identities[id] = nil
 	}
+	return identities
+}
+
+func flattenUserAssignedIdentityMap(input map[string]*userAssignedIdentityInfo) ([]string, map[string]UserAssignedIdentityDetails) {
+	if len(input) == 0 {
+		return nil, nil
+	}
+
+	identities := make([]string, 0, len(input))
+	details := make(map[string]UserAssignedIdentityDetails, len(input))
+	for k, v := range input {
+		identities = append(identities, k)
+		details[k] = v.toDetails()
+	}
+	// the ordering of a map isn't stable, so the ID's are sorted to avoid spurious diffs
+	sort.Strings(identities)
+	return identities, details
+}
+
+func stringValue(input *string) string {
+	if input == nil {
+		return ""
+	}
+	return *input
+}
+
+func stringPointer(input string) *string {
+	return &input
 }
@@ -0,0 +1,98 @@
+package identity
+
+import (
+	"fmt"
+
+	msivalidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/msi/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+var _ Identity = SystemOrUserAssigned{}
+
+// SystemOrUserAssigned is used for resources which support either a System Assigned or User Assigned
+// Identities - but not both at the same time
+type SystemOrUserAssigned struct{}
+
+func (s SystemOrUserAssigned) Expand(input []interface{}) (*ExpandedConfig, error) {
+	if len(input) == 0 || input[0] == nil {
+		return &ExpandedConfig{
+			Type: none,
+		}, nil
+	}
+
+	v := input[0].(map[string]interface{})
+
+	config := &ExpandedConfig{
+		Type: Type(v["type"].(string)),
+	}
+
+	identityIds := v["identity_ids"].(*pluginsdk.Set).List()
+	if config.Type == userAssigned && len(identityIds) == 0 {
+		return nil, fmt.Errorf("`identity_ids` must be specified when `type` is set to %q", string(userAssigned))
+	}
+	if config.Type != userAssigned && len(identityIds) != 0 {
+		return nil, fmt.Errorf("`identity_ids` can only be specified when `type` is set to %q", string(userAssigned))
+	}
+	if len(identityIds) != 0 {
+		config.UserAssignedIdentityIds = *utils.ExpandStringSlice(identityIds)
+	}
+
+	return config, nil
+}
+
+func (s SystemOrUserAssigned) Flatten(input *ExpandedConfig) []interface{} {
+	if input == nil || input.Type == none {
+		return []interface{}{}
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"type":         input.Type,
+			"identity_ids": utils.FlattenStringSlice(&input.UserAssignedIdentityIds),
+			"principal_id": input.PrincipalId,
+			"tenant_id":    input.TenantId,
+		},
+	}
+}
+
+func (s SystemOrUserAssigned) Schema() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"type": {
+					Type:     pluginsdk.TypeString,
+					Required: true,
+					ValidateFunc: validation.StringInSlice([]string{
+						string(systemAssigned),
+						string(userAssigned),
+					}, false),
+				},
+				"identity_ids": {
+					Type:     pluginsdk.TypeSet,
+					Optional: true,
+					Elem: &pluginsdk.Schema{
+						Type:         pluginsdk.TypeString,
+						ValidateFunc: msivalidate.UserAssignedIdentityID,
+					},
+				},
+				"principal_id": {
+					Type:     pluginsdk.TypeString,
+					Computed: true,
+				},
+				"tenant_id": {
+					Type:     pluginsdk.TypeString,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+func (s SystemOrUserAssigned) SchemaDataSource() *pluginsdk.Schema {
+	return SystemAssignedUserAssigned{}.SchemaDataSource()
+}
@@ -0,0 +1,29 @@
+package identity
+
+// ExpandTyped returns the identity configuration from the `identity` block within a Typed Resource model,
+// where the model field is defined as `[]identity.ExpandedConfig` with the struct tag `tfschema:"identity"`
+func ExpandTyped(input []ExpandedConfig) *ExpandedConfig {
+	if len(input) == 0 {
+		return &ExpandedConfig{
+			Type: none,
+		}
+	}
+
+	config := input[0]
+	config.Type = normalizeType(config.Type)
+	return &config
+}
+
+// FlattenTyped returns the `identity` block for a Typed Resource model from the identity configuration
+func FlattenTyped(input *ExpandedConfig) []ExpandedConfig {
+	if input == nil || normalizeType(input.Type) == none {
+		return []ExpandedConfig{}
+	}
+
+	config := *input
+	config.Type = normalizeType(config.Type)
+	if config.UserAssignedIdentityIds == nil {
+		config.UserAssignedIdentityIds = []string{}
+	}
+	return []ExpandedConfig{config}
+}
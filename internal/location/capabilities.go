@@ -0,0 +1,90 @@
+package location
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+// ValidateZonesSupported returns an error if Availability Zones are specified for a location which
+// is known not to support them - locations which aren't in the catalogue are assumed to support them
+func ValidateZonesSupported(location string, zones []string) error {
+	if len(zones) == 0 {
+		return nil
+	}
+
+	if supported, known := SupportsAvailabilityZones(location); known && !supported {
+		return fmt.Errorf("the location %q doesn't support Availability Zones but the zones %q were specified", Normalize(location), strings.Join(zones, ", "))
+	}
+
+	return nil
+}
+
+// ValidateZoneRedundantSkuSupported returns an error if the specified SKU is one of the zoneRedundantSkus
+// (which require Availability Zones) and the location is known not to support Availability Zones
+func ValidateZoneRedundantSkuSupported(location, sku string, zoneRedundantSkus ...string) error {
+	for _, v := range zoneRedundantSkus {
+		if !strings.EqualFold(v, sku) {
+			continue
+		}
+
+		if supported, known := SupportsAvailabilityZones(location); known && !supported {
+			return fmt.Errorf("the SKU %q requires Availability Zones which aren't supported in the location %q", sku, Normalize(location))
+		}
+	}
+
+	return nil
+}
+
+// ZonesSupportedCustomizeDiff returns a CustomizeDiffFunc which validates that Availability Zones
+// are only specified (in the list field zonesField) when the location in locationField supports them
+func ZonesSupportedCustomizeDiff(locationField, zonesField string) pluginsdk.CustomizeDiffFunc {
+	return func(ctx context.Context, diff *pluginsdk.ResourceDiff, _ interface{}) error {
+		if !enhancedEnabled {
+			return nil
+		}
+
+		location, ok := diff.Get(locationField).(string)
+		if !ok || location == "" {
+			return nil
+		}
+
+		zones := make([]string, 0)
+		for _, v := range diff.Get(zonesField).([]interface{}) {
+			if zone, ok := v.(string); ok && zone != "" {
+				zones = append(zones, zone)
+			}
+		}
+
+		if err := ValidateZonesSupported(location, zones); err != nil {
+			return fmt.Errorf("validating `%s`: %+v", zonesField, err)
+		}
+		return nil
+	}
+}
+
+// ZoneRedundantSkuSupportedCustomizeDiff returns a CustomizeDiffFunc which validates that the SKU in skuField
+// is only one of the zoneRedundantSkus when the location in locationField supports Availability Zones
+func ZoneRedundantSkuSupportedCustomizeDiff(locationField, skuField string, zoneRedundantSkus ...string) pluginsdk.CustomizeDiffFunc {
+	return func(ctx context.Context, diff *pluginsdk.ResourceDiff, _ interface{}) error {
+		if !enhancedEnabled {
+			return nil
+		}
+
+		location, ok := diff.Get(locationField).(string)
+		if !ok || location == "" {
+			return nil
+		}
+		sku, ok := diff.Get(skuField).(string)
+		if !ok || sku == "" {
+			return nil
+		}
+
+		if err := ValidateZoneRedundantSkuSupported(location, sku, zoneRedundantSkus...); err != nil {
+			return fmt.Errorf("validating `%s`: %+v", skuField, err)
+		}
+		return nil
+	}
+}
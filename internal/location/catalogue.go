@@ -0,0 +1,123 @@
+package location
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/Azure/go-autorest/autorest/azure"
+)
+
+// catalogueJson is a snapshot of the Regions available in each Azure Environment, which is used when
+// the Azure MetaData Service is unavailable (for example in air-gapped environments) and to determine
+// the capabilities of each Region - it should be updated (and the version bumped) as Regions are added
+//
+//go:embed catalogue.json
+var catalogueJson []byte
+
+// Region is an Azure Region from the embedded catalogue
+type Region struct {
+	// Name is the normalized name of this Region, e.g. `westeurope`
+	Name string `json:"name"`
+
+	// DisplayName is the human readable name of this Region, e.g. `West Europe`
+	DisplayName string `json:"displayName"`
+
+	// PairedRegion is the normalized name of the Region this is paired with for disaster recovery
+	PairedRegion string `json:"pairedRegion"`
+
+	// AvailabilityZones specifies whether this Region supports Availability Zones
+	AvailabilityZones bool `json:"availabilityZones"`
+}
+
+type catalogue struct {
+	Version string `json:"version"`
+
+	// Environments are the Regions available in each Azure Environment, keyed by the Environment Name
+	Environments map[string][]Region `json:"environments"`
+}
+
+var embeddedCatalogue = mustParseCatalogue(catalogueJson)
+
+// catalogueEnvironment is the name of the Azure Environment which the catalogue is used for, which is
+// set when the supported locations are cached
+var catalogueEnvironment = azure.PublicCloud.Name
+
+func mustParseCatalogue(input []byte) catalogue {
+	var out catalogue
+	if err := json.Unmarshal(input, &out); err != nil {
+		panic(fmt.Sprintf("parsing the embedded location catalogue: %+v", err))
+	}
+	return out
+}
+
+// CatalogueVersion returns the version of the embedded catalogue of Regions
+func CatalogueVersion() string {
+	return embeddedCatalogue.Version
+}
+
+// CatalogueRegions returns the Regions in the embedded catalogue for the specified Azure Environment
+// (e.g. `AzurePublicCloud`), or nil if this Environment isn't in the catalogue
+func CatalogueRegions(environmentName string) []Region {
+	regions, ok := embeddedCatalogue.Environments[environmentName]
+	if !ok {
+		return nil
+	}
+
+	out := make([]Region, len(regions))
+	copy(out, regions)
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Name < out[j].Name
+	})
+	return out
+}
+
+// LookupRegion returns the Region from the embedded catalogue for the specified location
+// (in either its normalized or display name form) in the Azure Environment being used
+func LookupRegion(location string) (*Region, bool) {
+	normalized := Normalize(location)
+	for _, region := range embeddedCatalogue.Environments[catalogueEnvironment] {
+		if region.Name == normalized {
+			r := region
+			return &r, true
+		}
+	}
+
+	return nil, false
+}
+
+// PairedRegion returns the name of the Region which the specified location is paired with,
+// if this is known
+func PairedRegion(location string) (string, bool) {
+	region, ok := LookupRegion(location)
+	if !ok || region.PairedRegion == "" {
+		return "", false
+	}
+
+	return region.PairedRegion, true
+}
+
+// SupportsAvailabilityZones returns whether the specified location is known to support Availability Zones - the
+// second return value is false when the location isn't in the catalogue, in which case this isn't known
+func SupportsAvailabilityZones(location string) (supported bool, known bool) {
+	region, ok := LookupRegion(location)
+	if !ok {
+		return false, false
+	}
+
+	return region.AvailabilityZones, true
+}
+
+func catalogueLocations(environmentName string) *[]string {
+	regions := CatalogueRegions(environmentName)
+	if regions == nil {
+		return nil
+	}
+
+	locations := make([]string, 0, len(regions))
+	for _, region := range regions {
+		locations = append(locations, region.Name)
+	}
+	return &locations
+}
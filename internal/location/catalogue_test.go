@@ -0,0 +1,156 @@
+package location
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest/azure"
+)
+
+func TestCatalogueIsValid(t *testing.T) {
+	if CatalogueVersion() == "" {
+		t.Fatalf("expected the catalogue to have a version")
+	}
+
+	for _, env := range []azure.Environment{azure.PublicCloud, azure.ChinaCloud, azure.USGovernmentCloud, azure.GermanCloud} {
+		t.Logf("[DEBUG] Testing %q..", env.Name)
+
+		regions := CatalogueRegions(env.Name)
+		if len(regions) == 0 {
+			t.Fatalf("expected the catalogue to contain regions for %q", env.Name)
+		}
+
+		names := make(map[string]struct{})
+		for _, region := range regions {
+			names[region.Name] = struct{}{}
+		}
+
+		for _, region := range regions {
+			if Normalize(region.DisplayName) != region.Name {
+				t.Fatalf("expected the display name %q to normalize to %q", region.DisplayName, region.Name)
+			}
+			if _, ok := names[region.PairedRegion]; !ok {
+				t.Fatalf("expected the paired region %q for %q to exist within %q", region.PairedRegion, region.Name, env.Name)
+			}
+		}
+	}
+}
+
+func TestLookupRegion(t *testing.T) {
+	region, ok := LookupRegion("West Europe")
+	if !ok {
+		t.Fatalf("expected `West Europe` to be found")
+	}
+	if region.Name != "westeurope" || !region.AvailabilityZones {
+		t.Fatalf("expected `westeurope` supporting Availability Zones but got %+v", region)
+	}
+
+	paired, ok := PairedRegion("westeurope")
+	if !ok || paired != "northeurope" {
+		t.Fatalf("expected the paired region to be `northeurope` but got %q", paired)
+	}
+
+	if _, ok := LookupRegion("doesnotexist"); ok {
+		t.Fatalf("expected `doesnotexist` not to be found")
+	}
+}
+
+func TestValidateZonesSupported(t *testing.T) {
+	testData := []struct {
+		location string
+		zones    []string
+		valid    bool
+	}{
+		{
+			location: "westeurope",
+			zones:    []string{"1", "2"},
+			valid:    true,
+		},
+		{
+			location: "West Central US",
+			zones:    []string{},
+			valid:    true,
+		},
+		{
+			location: "West Central US",
+			zones:    []string{"1"},
+			valid:    false,
+		},
+		{
+			// locations which aren't in the catalogue are assumed to support Availability Zones
+			location: "newregion",
+			zones:    []string{"1"},
+			valid:    true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q with %+v..", v.location, v.zones)
+
+		err := ValidateZonesSupported(v.location, v.zones)
+		if v.valid && err != nil {
+			t.Fatalf("expected no error but got %+v", err)
+		}
+		if !v.valid && err == nil {
+			t.Fatalf("expected an error but didn't get one")
+		}
+	}
+}
+
+func TestValidateZoneRedundantSkuSupported(t *testing.T) {
+	testData := []struct {
+		location string
+		sku      string
+		valid    bool
+	}{
+		{
+			location: "westeurope",
+			sku:      "ZRS",
+			valid:    true,
+		},
+		{
+			location: "ukwest",
+			sku:      "LRS",
+			valid:    true,
+		},
+		{
+			location: "ukwest",
+			sku:      "zrs",
+			valid:    false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q in %q..", v.sku, v.location)
+
+		err := ValidateZoneRedundantSkuSupported(v.location, v.sku, "ZRS", "GZRS", "RAGZRS")
+		if v.valid && err != nil {
+			t.Fatalf("expected no error but got %+v", err)
+		}
+		if !v.valid && err == nil {
+			t.Fatalf("expected an error but didn't get one")
+		}
+	}
+}
+
+func TestCacheSupportedLocationsFallsBackToCatalogue(t *testing.T) {
+	defer func() {
+		supportedLocations = nil
+		catalogueEnvironment = azure.PublicCloud.Name
+	}()
+
+	env := azure.ChinaCloud
+	// nothing is listening on this port, so retrieving the locations fails
+	env.ResourceManagerEndpoint = "https://127.0.0.1:1/"
+	CacheSupportedLocations(context.TODO(), &env)
+
+	if supportedLocations == nil {
+		t.Fatalf("expected the supported locations to be populated from the catalogue")
+	}
+	if len(*supportedLocations) != len(CatalogueRegions(azure.ChinaCloud.Name)) {
+		t.Fatalf("expected the locations for %q but got %+v", azure.ChinaCloud.Name, *supportedLocations)
+	}
+	if _, ok := LookupRegion("chinanorth"); !ok {
+		t.Fatalf("expected regions to be looked up within %q", azure.ChinaCloud.Name)
+	}
+}
@@ -2,6 +2,7 @@ package location
 
 import (
 	"context"
+	"fmt"
 	"log"
 
 	"github.com/Azure/go-autorest/autorest/azure"
@@ -11,11 +12,22 @@ import (
 var supportedLocations *[]string
 
 // CacheSupportedLocations attempts to retrieve the supported locations from the Azure MetaData Service
-// and caches them, for used in enhanced validation
+// (falling back to the embedded catalogue of Regions when this is unavailable), for used in enhanced validation
 func CacheSupportedLocations(ctx context.Context, env *azure.Environment) {
+	catalogueEnvironment = env.Name
+
 	locs, err := availableAzureLocations(ctx, env)
+	if err == nil && locs.Locations == nil {
+		err = fmt.Errorf("no locations were returned for %q", env.ResourceManagerEndpoint)
+	}
 	if err != nil {
-		log.Printf("[DEBUG] error retrieving locations: %s. Enhanced validation will be unavailable", err)
+		if fallback := catalogueLocations(env.Name); fallback != nil {
+			log.Printf("[DEBUG] error retrieving locations: %+v. Using the embedded catalogue of locations (version %s)", err, CatalogueVersion())
+			supportedLocations = fallback
+			return
+		}
+
+		log.Printf("[DEBUG] error retrieving locations: %+v. Enhanced validation will be unavailable", err)
 		return
 	}
 
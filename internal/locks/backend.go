@@ -0,0 +1,34 @@
+package locks
+
+import (
+	"context"
+)
+
+// Backend provides locking across processes (for example multiple Terraform runs which manage
+// resources within the same Virtual Network), which is used in addition to the in-process locks
+type Backend interface {
+	// Lock acquires the lock for the specified key, blocking until either it's
+	// acquired or the context is cancelled
+	Lock(ctx context.Context, key string) error
+
+	// Unlock releases the lock for the specified key
+	Unlock(key string) error
+}
+
+type backendContextKey struct{}
+
+// WithBackend returns a copy of the context which is used to lock keys across processes using the specified
+// Backend, when nil keys are only locked within this process.
+//
+// Since acquiring a lock from a Backend can fail, keys are only locked using the Backend when they're locked
+// using a context (for example ByIDWithContext) - keys locked using the blocking functions (for example ByID)
+// are only locked within this process
+func WithBackend(ctx context.Context, backend Backend) context.Context {
+	return context.WithValue(ctx, backendContextKey{}, backend)
+}
+
+// backendFromContext returns the Backend used to lock keys across processes, if any
+func backendFromContext(ctx context.Context) Backend {
+	backend, _ := ctx.Value(backendContextKey{}).(Backend)
+	return backend
+}
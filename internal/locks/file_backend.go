@@ -0,0 +1,283 @@
+package locks
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	// fileLockPollInterval is how often an existing lock file is checked to see if it's been released
+	fileLockPollInterval = 1 * time.Second
+
+	// fileLockStaleAfter is the duration after which a lock file which hasn't been refreshed is considered
+	// abandoned (for example when the process holding it crashed) and is removed
+	fileLockStaleAfter = 5 * time.Minute
+)
+
+var _ Backend = &FileBackend{}
+
+// FileBackend is a Backend which locks keys by exclusively creating a file for each key within
+// a directory - which can be on a volume shared between machines.
+//
+// Whilst a lock is held its file is periodically refreshed, so that the lock files
+// of processes which exit without releasing their locks can be detected and removed.
+type FileBackend struct {
+	directory    string
+	pollInterval time.Duration
+	staleAfter   time.Duration
+
+	lock sync.Mutex
+
+	// held contains each of the locks held by this FileBackend
+	held map[string]heldFileLock
+}
+
+// heldFileLock is a lock file which was created by this FileBackend
+type heldFileLock struct {
+	// token is the Token written into the lock file, so that it's only removed whilst it's still this lock
+	token string
+
+	// stop is closed to stop refreshing the lock file
+	stop chan struct{}
+}
+
+// fileLockInfo is written into each lock file, to be able to identify which process holds the lock
+type fileLockInfo struct {
+	// Token is unique to each acquisition of the lock, so that a lock file which has been replaced can be identified
+	Token    string    `json:"token"`
+	Key      string    `json:"key"`
+	Hostname string    `json:"hostname"`
+	PID      int       `json:"pid"`
+	Acquired time.Time `json:"acquired"`
+}
+
+// NewFileBackend returns a FileBackend which creates lock files within the specified directory,
+// creating the directory if it doesn't exist
+func NewFileBackend(directory string) (*FileBackend, error) {
+	if err := os.MkdirAll(directory, 0700); err != nil {
+		return nil, fmt.Errorf("creating the lock directory %q: %+v", directory, err)
+	}
+
+	return &FileBackend{
+		directory:    directory,
+		pollInterval: fileLockPollInterval,
+		staleAfter:   fileLockStaleAfter,
+		held:         make(map[string]heldFileLock),
+	}, nil
+}
+
+// Lock creates the lock file for the specified key, waiting for any existing lock file to be removed
+func (b *FileBackend) Lock(ctx context.Context, key string) error {
+	path := b.pathForKey(key)
+	hostname, _ := os.Hostname()
+	token, err := newFileLockToken()
+	if err != nil {
+		return err
+	}
+	info, err := json.Marshal(fileLockInfo{
+		Token:    token,
+		Key:      key,
+		Hostname: hostname,
+		PID:      os.Getpid(),
+		Acquired: time.Now().UTC(),
+	})
+	if err != nil {
+		return fmt.Errorf("serializing lock info: %+v", err)
+	}
+
+	for {
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if err == nil {
+			_, writeErr := file.Write(info)
+			closeErr := file.Close()
+			if writeErr != nil || closeErr != nil {
+				os.Remove(path)
+				return fmt.Errorf("writing lock file %q: %+v / %+v", path, writeErr, closeErr)
+			}
+
+			b.startRefreshing(key, path, token)
+			return nil
+		}
+		if !os.IsExist(err) {
+			return fmt.Errorf("creating lock file %q: %+v", path, err)
+		}
+
+		if b.removeIfStale(path) {
+			continue
+		}
+
+		log.Printf("[DEBUG] Waiting for the lock file for %q which is held by %s", key, describeLockFile(path))
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("waiting for the lock file %q (held by %s): %+v", path, describeLockFile(path), ctx.Err())
+		case <-time.After(b.pollInterval):
+		}
+	}
+}
+
+// Unlock removes the lock file for the specified key, providing it's still the lock file which was created by Lock -
+// since if this lock was considered stale (and removed) the lock file could now be held by another process.
+//
+// As with removing a stale lock file, the lock file is atomically renamed to a unique path before it's checked,
+// and is restored if it's not this lock
+func (b *FileBackend) Unlock(key string) error {
+	b.lock.Lock()
+	held, ok := b.held[key]
+	if ok {
+		close(held.stop)
+		delete(b.held, key)
+	}
+	b.lock.Unlock()
+
+	path := b.pathForKey(key)
+	if !ok {
+		log.Printf("[WARN] The lock file %q for %q isn't held by this process, so won't be removed", path, key)
+		return nil
+	}
+
+	removed := fmt.Sprintf("%s.%s.unlock", path, held.token)
+	if err := os.Rename(path, removed); err != nil {
+		if os.IsNotExist(err) {
+			log.Printf("[WARN] The lock file %q for %q was removed whilst the lock was held", path, key)
+			return nil
+		}
+		return fmt.Errorf("removing lock file %q: %+v", path, err)
+	}
+	defer os.Remove(removed)
+
+	contents, err := ioutil.ReadFile(removed)
+	if err == nil {
+		var info fileLockInfo
+		if err := json.Unmarshal(contents, &info); err == nil && info.Token == held.token {
+			return nil
+		}
+	}
+
+	// the lock file was considered stale and has since been acquired by another process, so is restored -
+	// unless another lock file has since been created, in which case that's used instead
+	log.Printf("[WARN] The lock file %q for %q was replaced whilst the lock was held (and is now held by %s)", path, key, describeLockInfo(contents))
+	if err := os.Link(removed, path); err != nil && !os.IsExist(err) {
+		return fmt.Errorf("restoring lock file %q: %+v", path, err)
+	}
+
+	return nil
+}
+
+// pathForKey returns the path to the lock file for a key - since keys can contain characters
+// which aren't valid in file names (such as Resource IDs) these are hashed
+func (b *FileBackend) pathForKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return filepath.Join(b.directory, hex.EncodeToString(hash[:])+".lock")
+}
+
+// startRefreshing periodically updates the modification time of the lock file whilst the lock is held
+func (b *FileBackend) startRefreshing(key, path, token string) {
+	stop := make(chan struct{})
+	b.lock.Lock()
+	b.held[key] = heldFileLock{
+		token: token,
+		stop:  stop,
+	}
+	b.lock.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(b.staleAfter / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				now := time.Now()
+				if err := os.Chtimes(path, now, now); err != nil {
+					log.Printf("[WARN] Unable to refresh the lock file %q: %+v", path, err)
+				}
+			}
+		}
+	}()
+}
+
+// removeIfStale removes the lock file if it hasn't been refreshed recently, returning whether it was removed.
+//
+// Since another process can remove the same stale lock file (and create a new one) between it being checked
+// and removed, the lock file is first atomically renamed to a unique path - if the renamed file isn't the
+// stale lock file which was checked it's restored, rather than removing a lock which is held
+func (b *FileBackend) removeIfStale(path string) bool {
+	stat, err := os.Stat(path)
+	if err != nil {
+		// it's been removed in the meantime
+		return os.IsNotExist(err)
+	}
+	if time.Since(stat.ModTime()) < b.staleAfter {
+		return false
+	}
+	stale, err := ioutil.ReadFile(path)
+	if err != nil {
+		return os.IsNotExist(err)
+	}
+
+	token, err := newFileLockToken()
+	if err != nil {
+		log.Printf("[WARN] Unable to remove the stale lock file %q: %+v", path, err)
+		return false
+	}
+	removed := fmt.Sprintf("%s.%s.stale", path, token)
+	if err := os.Rename(path, removed); err != nil {
+		if !os.IsNotExist(err) {
+			log.Printf("[WARN] Unable to remove the stale lock file %q: %+v", path, err)
+		}
+		return os.IsNotExist(err)
+	}
+	defer os.Remove(removed)
+
+	if contents, err := ioutil.ReadFile(removed); err != nil || !bytes.Equal(contents, stale) {
+		// the lock file was replaced in the meantime, so is restored - unless another lock file has since been
+		// created, in which case that's used instead
+		if err := os.Link(removed, path); err != nil && !os.IsExist(err) {
+			log.Printf("[WARN] Unable to restore the lock file %q: %+v", path, err)
+		}
+		return false
+	}
+
+	log.Printf("[WARN] Removed the stale lock file %q which was held by %s", path, describeLockInfo(stale))
+	return true
+}
+
+// newFileLockToken returns a random token used to uniquely identify a lock file
+func newFileLockToken() (string, error) {
+	token := make([]byte, 16)
+	if _, err := rand.Read(token); err != nil {
+		return "", fmt.Errorf("generating lock token: %+v", err)
+	}
+
+	return hex.EncodeToString(token), nil
+}
+
+func describeLockFile(path string) string {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "(unknown)"
+	}
+
+	return describeLockInfo(contents)
+}
+
+func describeLockInfo(contents []byte) string {
+	var info fileLockInfo
+	if err := json.Unmarshal(contents, &info); err != nil {
+		return "(unknown)"
+	}
+
+	return fmt.Sprintf("process %d on %q since %s", info.PID, info.Hostname, info.Acquired.Format(time.RFC3339))
+}
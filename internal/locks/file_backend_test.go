@@ -0,0 +1,134 @@
+package locks
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileBackendLocksAcrossBackends(t *testing.T) {
+	directory, err := ioutil.TempDir("", "locks")
+	if err != nil {
+		t.Fatalf("creating temp directory: %+v", err)
+	}
+	defer os.RemoveAll(directory)
+
+	// each Backend represents a separate process using the same directory
+	first, err := NewFileBackend(directory)
+	if err != nil {
+		t.Fatalf("building first backend: %+v", err)
+	}
+	second, err := NewFileBackend(directory)
+	if err != nil {
+		t.Fatalf("building second backend: %+v", err)
+	}
+	second.pollInterval = 10 * time.Millisecond
+
+	key := "/subscriptions/000/resourceGroups/group1/providers/Microsoft.Network/virtualNetworks/network1"
+	if err := first.Lock(context.Background(), key); err != nil {
+		t.Fatalf("locking first backend: %+v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := second.Lock(ctx, key); err == nil {
+		t.Fatalf("expected an error but didn't get one")
+	}
+
+	// other keys are unaffected
+	if err := second.Lock(context.Background(), "other"); err != nil {
+		t.Fatalf("locking other key: %+v", err)
+	}
+	if err := second.Unlock("other"); err != nil {
+		t.Fatalf("unlocking other key: %+v", err)
+	}
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		first.Unlock(key)
+	}()
+	if err := second.Lock(context.Background(), key); err != nil {
+		t.Fatalf("locking second backend: %+v", err)
+	}
+	if err := second.Unlock(key); err != nil {
+		t.Fatalf("unlocking second backend: %+v", err)
+	}
+}
+
+func TestFileBackendRemovesStaleLocks(t *testing.T) {
+	directory, err := ioutil.TempDir("", "locks")
+	if err != nil {
+		t.Fatalf("creating temp directory: %+v", err)
+	}
+	defer os.RemoveAll(directory)
+
+	backend, err := NewFileBackend(directory)
+	if err != nil {
+		t.Fatalf("building backend: %+v", err)
+	}
+
+	// a lock file left behind by a process which exited without releasing it
+	path := backend.pathForKey("example")
+	if err := ioutil.WriteFile(path, []byte(`{"key":"example","hostname":"other","pid":1}`), 0600); err != nil {
+		t.Fatalf("writing lock file: %+v", err)
+	}
+	abandoned := time.Now().Add(-2 * backend.staleAfter)
+	if err := os.Chtimes(path, abandoned, abandoned); err != nil {
+		t.Fatalf("updating lock file: %+v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := backend.Lock(ctx, "example"); err != nil {
+		t.Fatalf("expected the stale lock to be removed but got %+v", err)
+	}
+	if err := backend.Unlock("example"); err != nil {
+		t.Fatalf("unlocking: %+v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("expected the lock file to be removed but got %+v", err)
+	}
+	if files, _ := filepath.Glob(filepath.Join(directory, "*")); len(files) != 0 {
+		t.Fatalf("expected no files to remain in the lock directory but got %+v", files)
+	}
+}
+
+func TestFileBackendUnlockRetainsReplacedLocks(t *testing.T) {
+	directory, err := ioutil.TempDir("", "locks")
+	if err != nil {
+		t.Fatalf("creating temp directory: %+v", err)
+	}
+	defer os.RemoveAll(directory)
+
+	backend, err := NewFileBackend(directory)
+	if err != nil {
+		t.Fatalf("building backend: %+v", err)
+	}
+	if err := backend.Lock(context.Background(), "example"); err != nil {
+		t.Fatalf("locking: %+v", err)
+	}
+
+	// the lock was considered stale by another process, which removed it and now holds the lock
+	path := backend.pathForKey("example")
+	replaced := []byte(`{"token":"other","key":"example","hostname":"other","pid":1}`)
+	if err := ioutil.WriteFile(path, replaced, 0600); err != nil {
+		t.Fatalf("writing lock file: %+v", err)
+	}
+
+	if err := backend.Unlock("example"); err != nil {
+		t.Fatalf("unlocking: %+v", err)
+	}
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("expected the lock file held by the other process to remain but got %+v", err)
+	}
+	if string(contents) != string(replaced) {
+		t.Fatalf("expected the lock file to be %q but got %q", replaced, contents)
+	}
+	if files, _ := filepath.Glob(filepath.Join(directory, "*")); len(files) != 1 {
+		t.Fatalf("expected only the lock file to remain in the lock directory but got %+v", files)
+	}
+}
@@ -1,5 +1,10 @@
 package locks
 
+import (
+	"context"
+	"sort"
+)
+
 // armMutexKV is the instance of MutexKV for ARM resources
 var armMutexKV = NewMutexKV()
 
@@ -7,20 +12,49 @@ func ByID(id string) {
 	armMutexKV.Lock(id)
 }
 
+// ByIDWithContext locks the specified ID, returning an error if the context is cancelled (or times out)
+// before the lock is acquired - in which case UnlockByID mustn't be called
+func ByIDWithContext(ctx context.Context, id string) error {
+	return armMutexKV.LockWithContext(ctx, id)
+}
+
 // handle the case of using the same name for different kinds of resources
 func ByName(name string, resourceType string) {
 	updatedName := resourceType + "." + name
 	armMutexKV.Lock(updatedName)
 }
 
-func MultipleByName(names *[]string, resourceType string) {
-	newSlice := removeDuplicatesFromStringArray(*names)
+// ByNameWithContext locks the specified name for this kind of resource, returning an error if the context
+// is cancelled (or times out) before the lock is acquired - in which case UnlockByName mustn't be called
+func ByNameWithContext(ctx context.Context, name string, resourceType string) error {
+	updatedName := resourceType + "." + name
+	return armMutexKV.LockWithContext(ctx, updatedName)
+}
 
-	for _, name := range newSlice {
+// MultipleByName locks each of the specified names, which are acquired in a consistent order to
+// avoid deadlocking with another caller locking an overlapping set of names
+func MultipleByName(names *[]string, resourceType string) {
+	for _, name := range sortedNames(names) {
 		ByName(name, resourceType)
 	}
 }
 
+// MultipleByNameWithContext locks each of the specified names (in a consistent order), returning an error
+// if the context is cancelled (or times out) before all of the locks are acquired - in which case any
+// locks which were acquired are released and UnlockMultipleByName mustn't be called
+func MultipleByNameWithContext(ctx context.Context, names *[]string, resourceType string) error {
+	acquired := make([]string, 0)
+	for _, name := range sortedNames(names) {
+		if err := ByNameWithContext(ctx, name, resourceType); err != nil {
+			UnlockMultipleByName(&acquired, resourceType)
+			return err
+		}
+		acquired = append(acquired, name)
+	}
+
+	return nil
+}
+
 func UnlockByID(id string) {
 	armMutexKV.Unlock(id)
 }
@@ -31,9 +65,16 @@ func UnlockByName(name string, resourceType string) {
 }
 
 func UnlockMultipleByName(names *[]string, resourceType string) {
-	newSlice := removeDuplicatesFromStringArray(*names)
+	newSlice := sortedNames(names)
 
-	for _, name := range newSlice {
-		UnlockByName(name, resourceType)
+	// released in the reverse order to which they were acquired
+	for i := len(newSlice) - 1; i >= 0; i-- {
+		UnlockByName(newSlice[i], resourceType)
 	}
 }
+
+func sortedNames(names *[]string) []string {
+	newSlice := removeDuplicatesFromStringArray(*names)
+	sort.Strings(newSlice)
+	return newSlice
+}
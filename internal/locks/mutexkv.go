@@ -1,48 +1,192 @@
 package locks
 
 import (
+	"context"
+	"fmt"
 	"log"
 	"sync"
+	"time"
 )
 
+// contentionLogInterval is how often a message is logged whilst waiting to acquire a lock
+var contentionLogInterval = 30 * time.Second
+
 // mutexKV is a simple key/value store for arbitrary mutexes. It can be used to
 // serialize changes across arbitrary collaborators that share knowledge of the
 // keys they must serialize on.
 type mutexKV struct {
 	lock  sync.Mutex
-	store map[string]*sync.Mutex
+	store map[string]*keyLock
+}
+
+// keyLock is a mutex which can be acquired using a context, and which tracks
+// who holds it (and since when) to be able to diagnose contention
+type keyLock struct {
+	// sem contains a value whilst the lock is held
+	sem chan struct{}
+
+	// holder, acquired and viaBackend are guarded by the mutexKV's lock
+	holder   string
+	acquired time.Time
+
+	// viaBackend is the Backend which this key was also locked in, if any
+	viaBackend Backend
 }
 
 // Locks the mutex for the given key. Caller is responsible for calling Unlock
-// for the same key
+// for the same key. Since callers can't handle an error the key is only locked
+// within this process, LockWithContext should be used to lock across processes
 func (m *mutexKV) Lock(key string) {
+	// a background context is never cancelled, so this can't fail
+	_ = m.lockInProcess(context.Background(), key)
+}
+
+// LockWithContext locks the mutex for the given key, returning an error if the context
+// is cancelled (or times out) before the lock is acquired - or if the key can't be locked
+// using the Backend from the context (see WithBackend). If this returns nil the caller is
+// responsible for calling Unlock for the same key
+func (m *mutexKV) LockWithContext(ctx context.Context, key string) error {
+	if err := m.lockInProcess(ctx, key); err != nil {
+		return err
+	}
+
+	backend := backendFromContext(ctx)
+	if backend == nil {
+		return nil
+	}
+
+	if err := backend.Lock(ctx, key); err != nil {
+		m.unlockInProcess(key)
+		return fmt.Errorf("acquiring the cross-process lock %q: %+v", key, err)
+	}
+	m.lock.Lock()
+	m.store[key].viaBackend = backend
+	m.lock.Unlock()
+
+	return nil
+}
+
+func (m *mutexKV) lockInProcess(ctx context.Context, key string) error {
 	log.Printf("[DEBUG] Locking %q", key)
-	m.get(key).Lock()
-	log.Printf("[DEBUG] Locked %q", key)
+	l := m.get(key)
+	holder := holderFromContext(ctx)
+
+	select {
+	case l.sem <- struct{}{}:
+		m.acquired(l, holder)
+		log.Printf("[DEBUG] Locked %q", key)
+		return nil
+	default:
+	}
+
+	ticker := time.NewTicker(contentionLogInterval)
+	defer ticker.Stop()
+	waitingSince := time.Now()
+
+	for {
+		select {
+		case l.sem <- struct{}{}:
+			m.acquired(l, holder)
+			log.Printf("[DEBUG] Locked %q after waiting %s", key, time.Since(waitingSince).Round(time.Second))
+			return nil
+
+		case <-ticker.C:
+			currentHolder, heldFor := m.heldBy(l)
+			log.Printf("[DEBUG] %s is waiting for the lock %q which has been held by %s for %s", describeHolder(holder), key, currentHolder, heldFor)
+
+		case <-ctx.Done():
+			currentHolder, heldFor := m.heldBy(l)
+			return fmt.Errorf("waiting for the lock %q (which has been held by %s for %s): %+v", key, currentHolder, heldFor, ctx.Err())
+		}
+	}
 }
 
 // Unlock the mutex for the given key. Caller must have called Lock for the same key first
 func (m *mutexKV) Unlock(key string) {
+	l := m.get(key)
+
+	m.lock.Lock()
+	backend := l.viaBackend
+	l.viaBackend = nil
+	m.lock.Unlock()
+
+	if backend != nil {
+		if err := backend.Unlock(key); err != nil {
+			log.Printf("[WARN] Unable to release the cross-process lock %q: %+v", key, err)
+		}
+	}
+
+	m.unlockInProcess(key)
+}
+
+func (m *mutexKV) unlockInProcess(key string) {
 	log.Printf("[DEBUG] Unlocking %q", key)
-	m.get(key).Unlock()
+	l := m.get(key)
+
+	m.lock.Lock()
+	l.holder = ""
+	l.acquired = time.Time{}
+	m.lock.Unlock()
+
+	select {
+	case <-l.sem:
+	default:
+		panic(fmt.Sprintf("unlock of unlocked key %q", key))
+	}
 	log.Printf("[DEBUG] Unlocked %q", key)
 }
 
 // Returns a mutex for the given key, no guarantee of its lock status
-func (m *mutexKV) get(key string) *sync.Mutex {
+func (m *mutexKV) get(key string) *keyLock {
 	m.lock.Lock()
 	defer m.lock.Unlock()
 	mutex, ok := m.store[key]
 	if !ok {
-		mutex = &sync.Mutex{}
+		mutex = &keyLock{
+			sem: make(chan struct{}, 1),
+		}
 		m.store[key] = mutex
 	}
 	return mutex
 }
 
+func (m *mutexKV) acquired(l *keyLock, holder string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	l.holder = holder
+	l.acquired = time.Now()
+}
+
+func (m *mutexKV) heldBy(l *keyLock) (string, time.Duration) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return describeHolder(l.holder), time.Since(l.acquired).Round(time.Second)
+}
+
+type holderContextKey struct{}
+
+// WithHolder returns a copy of the context which records the specified holder (for example the
+// Resource ID being managed) when a key is locked, which is logged to diagnose contention
+func WithHolder(ctx context.Context, holder string) context.Context {
+	return context.WithValue(ctx, holderContextKey{}, holder)
+}
+
+// holderFromContext returns the holder recorded when locking a key, if any
+func holderFromContext(ctx context.Context) string {
+	holder, _ := ctx.Value(holderContextKey{}).(string)
+	return holder
+}
+
+func describeHolder(holder string) string {
+	if holder == "" {
+		return "(unknown)"
+	}
+	return holder
+}
+
 // Returns a properly initialized mutexKV
 func NewMutexKV() *mutexKV {
 	return &mutexKV{
-		store: make(map[string]*sync.Mutex),
+		store: make(map[string]*keyLock),
 	}
 }
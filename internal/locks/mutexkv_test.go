@@ -0,0 +1,172 @@
+package locks
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMutexKVLockWithContextTimesOut(t *testing.T) {
+	m := NewMutexKV()
+	if err := m.LockWithContext(WithHolder(context.Background(), "azurerm_subnet.first"), "example"); err != nil {
+		t.Fatalf("expected no error but got %+v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(WithHolder(context.Background(), "azurerm_subnet.second"), 50*time.Millisecond)
+	defer cancel()
+	err := m.LockWithContext(ctx, "example")
+	if err == nil {
+		t.Fatalf("expected an error but didn't get one")
+	}
+	if !strings.Contains(err.Error(), `"example"`) || !strings.Contains(err.Error(), "azurerm_subnet.first") {
+		t.Fatalf("expected the error to contain the key and the holder but got %+v", err)
+	}
+
+	// once released the lock can be acquired
+	m.Unlock("example")
+	if err := m.LockWithContext(context.Background(), "example"); err != nil {
+		t.Fatalf("expected no error but got %+v", err)
+	}
+	m.Unlock("example")
+}
+
+func TestMutexKVLogsContention(t *testing.T) {
+	buf := &bytes.Buffer{}
+	output := log.Writer()
+	log.SetOutput(buf)
+	defer log.SetOutput(output)
+
+	previous := contentionLogInterval
+	contentionLogInterval = 10 * time.Millisecond
+	defer func() {
+		contentionLogInterval = previous
+	}()
+
+	m := NewMutexKV()
+	m.Lock("example")
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		m.Unlock("example")
+	}()
+
+	if err := m.LockWithContext(context.Background(), "example"); err != nil {
+		t.Fatalf("expected no error but got %+v", err)
+	}
+	m.Unlock("example")
+
+	if !strings.Contains(buf.String(), `is waiting for the lock "example" which has been held by`) {
+		t.Fatalf("expected the contention to be logged but got %q", buf.String())
+	}
+}
+
+func TestMutexKVUnlockOfUnlockedKeyPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected a panic but didn't get one")
+		}
+	}()
+
+	NewMutexKV().Unlock("example")
+}
+
+func TestMultipleByNameWithContextDoesNotDeadlock(t *testing.T) {
+	first := []string{"a", "b", "c"}
+	second := []string{"c", "b", "a"}
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 50; i++ {
+		for _, names := range [][]string{first, second} {
+			names := names
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+				defer cancel()
+				if err := MultipleByNameWithContext(ctx, &names, "testResource"); err != nil {
+					t.Errorf("expected no error but got %+v", err)
+					return
+				}
+				UnlockMultipleByName(&names, "testResource")
+			}()
+		}
+	}
+	wg.Wait()
+}
+
+func TestMultipleByNameWithContextReleasesLocksOnError(t *testing.T) {
+	ByName("b", "testPartialResource")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	names := []string{"a", "b"}
+	if err := MultipleByNameWithContext(ctx, &names, "testPartialResource"); err == nil {
+		t.Fatalf("expected an error but didn't get one")
+	}
+	UnlockByName("b", "testPartialResource")
+
+	// `a` must have been released, so all of these can now be acquired
+	if err := MultipleByNameWithContext(context.Background(), &names, "testPartialResource"); err != nil {
+		t.Fatalf("expected no error but got %+v", err)
+	}
+	UnlockMultipleByName(&names, "testPartialResource")
+}
+
+type fakeBackend struct {
+	locked  map[string]bool
+	lockErr error
+}
+
+func (b *fakeBackend) Lock(_ context.Context, key string) error {
+	if b.lockErr != nil {
+		return b.lockErr
+	}
+	b.locked[key] = true
+	return nil
+}
+
+func (b *fakeBackend) Unlock(key string) error {
+	delete(b.locked, key)
+	return nil
+}
+
+func TestMutexKVUsesBackend(t *testing.T) {
+	backend := &fakeBackend{
+		locked: make(map[string]bool),
+	}
+	m := NewMutexKV()
+	ctx := WithBackend(context.Background(), backend)
+
+	if err := m.LockWithContext(ctx, "example"); err != nil {
+		t.Fatalf("expected no error but got %+v", err)
+	}
+	if !backend.locked["example"] {
+		t.Fatalf("expected the key to be locked in the backend")
+	}
+	m.Unlock("example")
+	if backend.locked["example"] {
+		t.Fatalf("expected the key to be unlocked in the backend")
+	}
+
+	// when the backend fails the error is returned and the in-process lock is released
+	backend.lockErr = fmt.Errorf("unavailable")
+	if err := m.LockWithContext(ctx, "example"); err == nil {
+		t.Fatalf("expected an error but didn't get one")
+	}
+	backend.lockErr = nil
+	if err := m.LockWithContext(ctx, "example"); err != nil {
+		t.Fatalf("expected no error but got %+v", err)
+	}
+	m.Unlock("example")
+
+	// the blocking API only locks within this process, since an error can't be returned
+	m.Lock("example")
+	if backend.locked["example"] {
+		t.Fatalf("expected the key not to be locked in the backend")
+	}
+	m.Unlock("example")
+}
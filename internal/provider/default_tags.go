@@ -0,0 +1,183 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func schemaDefaultTags() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"tags": {
+					Type:         pluginsdk.TypeMap,
+					Optional:     true,
+					ValidateFunc: tags.Validate,
+					Elem: &pluginsdk.Schema{
+						Type: pluginsdk.TypeString,
+					},
+					Description: "A mapping of tags which should be assigned to every resource which supports tags.",
+				},
+			},
+		},
+	}
+}
+
+func expandDefaultTags(input []interface{}) map[string]string {
+	output := make(map[string]string)
+	if len(input) == 0 || input[0] == nil {
+		return output
+	}
+
+	val := input[0].(map[string]interface{})
+	for k, v := range val["tags"].(map[string]interface{}) {
+		value, _ := tags.TagValueToString(v)
+		output[k] = value
+	}
+
+	return output
+}
+
+// withDefaultTags adds the computed `tags_all` field to a Resource which supports tags, which contains
+// all of the tags assigned to the resource (including the default tags from the Provider block and any
+// ignored tags) - and wraps the Create, Read and Update functions so that the default tags are sent to
+// Azure, but aren't set into the `tags` field unless these are also configured
+func withDefaultTags(resource *schema.Resource) {
+	tagsSchema, ok := resource.Schema["tags"]
+	if !ok || tagsSchema.Type != schema.TypeMap || !tagsSchema.Optional || tagsSchema.Computed {
+		return
+	}
+	if _, exists := resource.Schema["tags_all"]; exists {
+		return
+	}
+
+	resource.Schema["tags_all"] = tags.SchemaTagsAll()
+	wrapTagsFuncs(resource, writeTagsWithDefaults, readTagsWithDefaults)
+
+	// changes to the default tags can only be applied to resources whose tags can be updated
+	//lint:ignore SA1019 SDKv2 migration - staticcheck's own linter directives are currently being ignored under golanci-lint
+	if tagsSchema.ForceNew || (resource.Update == nil && resource.UpdateContext == nil) { //nolint:staticcheck
+		return
+	}
+
+	existing := resource.CustomizeDiff
+	resource.CustomizeDiff = func(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+		if existing != nil {
+			if err := existing(ctx, diff, meta); err != nil {
+				return err
+			}
+		}
+
+		return tagsConfig(meta).CustomizeDiffTagsAll(ctx, diff)
+	}
+}
+
+// writeTagsWithDefaults sets the `tags` field to the tags which should be sent to Azure - the configured tags
+// merged with the default tags, and any ignored tags already assigned to the resource (so that these are
+// preserved) - before creating/updating the resource
+func writeTagsWithDefaults(d *schema.ResourceData, meta interface{}, write func() error) error {
+	config := tagsConfig(meta)
+	configured, _ := d.Get("tags").(map[string]interface{})
+	existing, _ := d.GetChange("tags_all")
+	existingTags, _ := existing.(map[string]interface{})
+	if err := d.Set("tags", config.WithDefaults(config.PreserveIgnored(configured, existingTags))); err != nil {
+		return fmt.Errorf("setting `tags`: %+v", err)
+	}
+
+	err := write()
+
+	// this is done even if the write failed, so that the default tags aren't persisted into the `tags` field
+	if flattenErr := flattenTagsWithDefaults(d, config, configured); err == nil {
+		err = flattenErr
+	}
+	return err
+}
+
+// readTagsWithDefaults sets the `tags` and `tags_all` fields from the tags read from Azure
+func readTagsWithDefaults(d *schema.ResourceData, meta interface{}, read func() error) error {
+	configured, _ := d.Get("tags").(map[string]interface{})
+	if err := read(); err != nil {
+		return err
+	}
+
+	return flattenTagsWithDefaults(d, tagsConfig(meta), configured)
+}
+
+// flattenTagsWithDefaults sets all of the tags assigned to the resource into the `tags_all` field, and these
+// tags into the `tags` field excluding the ignored tags and any default tags which aren't configured - such
+// that these don't show as a diff
+func flattenTagsWithDefaults(d *schema.ResourceData, config *tags.Config, configured map[string]interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	all, _ := d.Get("tags").(map[string]interface{})
+	if err := d.Set("tags_all", all); err != nil {
+		return fmt.Errorf("setting `tags_all`: %+v", err)
+	}
+	if err := d.Set("tags", config.WithoutDefaults(all, configured)); err != nil {
+		return fmt.Errorf("setting `tags`: %+v", err)
+	}
+
+	return nil
+}
+
+// tagsConfig returns the tags configuration from the Provider block
+func tagsConfig(meta interface{}) *tags.Config {
+	if client, ok := meta.(*clients.Client); ok {
+		return client.Tags
+	}
+
+	return nil
+}
+
+// tagsFunc wraps a Create, Read or Update function (which is called via `inner`) for a Resource or Data Source
+// which supports tags
+type tagsFunc func(d *schema.ResourceData, meta interface{}, inner func() error) error
+
+// wrapTagsFuncs wraps the Create and Update functions of the Resource (or Data Source) with `write`, and the
+// Read function with `read`
+func wrapTagsFuncs(resource *schema.Resource, write tagsFunc, read tagsFunc) {
+	wrap := func(f tagsFunc, fn func(*schema.ResourceData, interface{}) error) func(*schema.ResourceData, interface{}) error {
+		if fn == nil {
+			return nil
+		}
+		return func(d *schema.ResourceData, meta interface{}) error {
+			return f(d, meta, func() error {
+				return fn(d, meta)
+			})
+		}
+	}
+	wrapContext := func(f tagsFunc, fn func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+		if fn == nil {
+			return nil
+		}
+		return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			var diags diag.Diagnostics
+			err := f(d, meta, func() error {
+				diags = fn(ctx, d, meta)
+				return nil
+			})
+			return append(diags, diag.FromErr(err)...)
+		}
+	}
+
+	//lint:ignore SA1019 SDKv2 migration - staticcheck's own linter directives are currently being ignored under golanci-lint
+	resource.Create = wrap(write, resource.Create) //nolint:staticcheck
+	resource.CreateContext = wrapContext(write, resource.CreateContext)
+	//lint:ignore SA1019 SDKv2 migration - staticcheck's own linter directives are currently being ignored under golanci-lint
+	resource.Read = wrap(read, resource.Read) //nolint:staticcheck
+	resource.ReadContext = wrapContext(read, resource.ReadContext)
+	//lint:ignore SA1019 SDKv2 migration - staticcheck's own linter directives are currently being ignored under golanci-lint
+	resource.Update = wrap(write, resource.Update) //nolint:staticcheck
+	resource.UpdateContext = wrapContext(write, resource.UpdateContext)
+}
@@ -0,0 +1,176 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+func TestExpandDefaultTags(t *testing.T) {
+	testData := []struct {
+		Name     string
+		Input    []interface{}
+		Expected map[string]string
+	}{
+		{
+			Name:     "Not Specified",
+			Input:    []interface{}{},
+			Expected: map[string]string{},
+		},
+		{
+			Name: "Tags",
+			Input: []interface{}{
+				map[string]interface{}{
+					"tags": map[string]interface{}{
+						"environment": "Production",
+						"cost_center": 123,
+					},
+				},
+			},
+			Expected: map[string]string{
+				"environment": "Production",
+				"cost_center": "123",
+			},
+		},
+	}
+
+	for _, testCase := range testData {
+		t.Logf("[DEBUG] Test Case: %q", testCase.Name)
+		actual := expandDefaultTags(testCase.Input)
+		if !reflect.DeepEqual(actual, testCase.Expected) {
+			t.Fatalf("expected %+v but got %+v", testCase.Expected, actual)
+		}
+	}
+}
+
+func TestResourcesSupportingTagsHaveTagsAll(t *testing.T) {
+	provider := AzureProvider()
+
+	for resourceType, resource := range provider.ResourcesMap {
+		tagsSchema, ok := resource.Schema["tags"]
+		if !ok || tagsSchema.Type != pluginsdk.TypeMap || !tagsSchema.Optional || tagsSchema.Computed {
+			continue
+		}
+
+		if _, ok := resource.Schema["tags_all"]; !ok {
+			t.Fatalf("expected the Resource %q to have a `tags_all` field", resourceType)
+		}
+	}
+
+	for dataSourceType, dataSource := range provider.DataSourcesMap {
+		if _, ok := dataSource.Schema["tags_all"]; ok {
+			t.Fatalf("expected the Data Source %q not to have a `tags_all` field", dataSourceType)
+		}
+	}
+}
+
+func TestWithDefaultTags(t *testing.T) {
+	resource := &pluginsdk.Resource{
+		Schema: map[string]*pluginsdk.Schema{
+			"name": {
+				Type:     pluginsdk.TypeString,
+				Required: true,
+			},
+			"tags": tags.Schema(),
+		},
+	}
+	withDefaultTags(resource)
+	if _, ok := resource.Schema["tags_all"]; !ok {
+		t.Fatalf("expected a `tags_all` field to be added")
+	}
+	if resource.CustomizeDiff != nil {
+		t.Fatalf("expected no CustomizeDiff for a Resource which can't be updated")
+	}
+
+	resource = &pluginsdk.Resource{
+		Schema: map[string]*pluginsdk.Schema{
+			"tags": tags.Schema(),
+		},
+		Update: func(d *pluginsdk.ResourceData, meta interface{}) error {
+			return nil
+		},
+	}
+	withDefaultTags(resource)
+	if resource.CustomizeDiff == nil {
+		t.Fatalf("expected a CustomizeDiff for a Resource which can be updated")
+	}
+
+	resource = &pluginsdk.Resource{
+		Schema: map[string]*pluginsdk.Schema{
+			"tags": {
+				Type:     pluginsdk.TypeList,
+				Optional: true,
+				Elem: &pluginsdk.Schema{
+					Type: pluginsdk.TypeString,
+				},
+			},
+		},
+	}
+	withDefaultTags(resource)
+	if _, ok := resource.Schema["tags_all"]; ok {
+		t.Fatalf("expected no `tags_all` field for a Resource which doesn't use a map of tags")
+	}
+}
+
+func TestWithDefaultTagsCreate(t *testing.T) {
+	// the tags assigned to the resource in Azure, which includes a tag added outside of Terraform
+	remote := map[string]*string{
+		"ms-resource-usage": utils.String("azure-cloud-shell"),
+	}
+	resource := &pluginsdk.Resource{
+		Schema: map[string]*pluginsdk.Schema{
+			"tags": tags.Schema(),
+		},
+		Create: func(d *pluginsdk.ResourceData, meta interface{}) error {
+			for k, v := range tags.Expand(d.Get("tags").(map[string]interface{})) {
+				remote[k] = v
+			}
+			d.SetId("example")
+			return tags.FlattenAndSet(d, remote)
+		},
+		Read: func(d *pluginsdk.ResourceData, meta interface{}) error {
+			return tags.FlattenAndSet(d, remote)
+		},
+		Update: func(d *pluginsdk.ResourceData, meta interface{}) error {
+			return nil
+		},
+	}
+	withDefaultTags(resource)
+
+	meta := &clients.Client{
+		Tags: tags.NewConfig(map[string]string{
+			"environment": "Production",
+		}, []string{"ms-resource-usage"}, nil),
+	}
+	d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{
+		"tags": map[string]interface{}{
+			"name": "example",
+		},
+	})
+	if err := resource.Create(d, meta); err != nil { //nolint:staticcheck
+		t.Fatalf("creating: %+v", err)
+	}
+
+	if v := remote["environment"]; v == nil || *v != "Production" {
+		t.Fatalf("expected the default tags to be sent but got %+v", remote)
+	}
+	expected := map[string]interface{}{
+		"name": "example",
+	}
+	if actual := d.Get("tags").(map[string]interface{}); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected `tags` to be %+v but got %+v", expected, actual)
+	}
+	expected = map[string]interface{}{
+		"environment":       "Production",
+		"ms-resource-usage": "azure-cloud-shell",
+		"name":              "example",
+	}
+	if actual := d.Get("tags_all").(map[string]interface{}); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected `tags_all` to be %+v but got %+v", expected, actual)
+	}
+}
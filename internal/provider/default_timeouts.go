@@ -0,0 +1,125 @@
+package provider
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
+)
+
+func schemaDefaultTimeouts() *pluginsdk.Schema {
+	timeout := func(operation string) *pluginsdk.Schema {
+		return &pluginsdk.Schema{
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ValidateFunc: validateTimeoutDuration,
+			Description:  fmt.Sprintf("The default timeout for %s operations on this Resource Type, for example `90m`.", operation),
+		}
+	}
+
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Optional: true,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"resource_type": {
+					Type:         pluginsdk.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+					Description:  "The Resource Type which these default timeouts apply to, for example `azurerm_kubernetes_cluster`.",
+				},
+
+				"create": timeout("Create"),
+
+				"read": timeout("Read"),
+
+				"update": timeout("Update"),
+
+				"delete": timeout("Delete"),
+			},
+		},
+	}
+}
+
+func validateTimeoutDuration(i interface{}, k string) ([]string, []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %q to be string", k)}
+	}
+
+	duration, err := time.ParseDuration(v)
+	if err != nil {
+		return nil, []error{fmt.Errorf("expected %q to be a duration (for example `90m`) but got %q: %+v", k, v, err)}
+	}
+	if duration <= 0 {
+		return nil, []error{fmt.Errorf("expected %q to be greater than zero but got %q", k, v)}
+	}
+
+	return nil, nil
+}
+
+func expandDefaultTimeouts(input []interface{}, resources map[string]*schema.Resource) (map[string]timeouts.Defaults, error) {
+	output := make(map[string]timeouts.Defaults)
+
+	parse := func(raw interface{}) (*time.Duration, error) {
+		v, ok := raw.(string)
+		if !ok || v == "" {
+			return nil, nil
+		}
+
+		duration, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, err
+		}
+		return &duration, nil
+	}
+
+	for _, item := range input {
+		if item == nil {
+			continue
+		}
+		val := item.(map[string]interface{})
+
+		resourceType := val["resource_type"].(string)
+		if _, ok := resources[resourceType]; !ok {
+			return nil, fmt.Errorf("the Resource Type %q specified in `default_timeouts` isn't supported by this Provider", resourceType)
+		}
+		if _, exists := output[resourceType]; exists {
+			return nil, fmt.Errorf("the Resource Type %q is specified more than once in `default_timeouts`", resourceType)
+		}
+
+		defaults := timeouts.Defaults{}
+		var err error
+		if defaults.Create, err = parse(val["create"]); err != nil {
+			return nil, fmt.Errorf("parsing `create` for %q: %+v", resourceType, err)
+		}
+		if defaults.Read, err = parse(val["read"]); err != nil {
+			return nil, fmt.Errorf("parsing `read` for %q: %+v", resourceType, err)
+		}
+		if defaults.Update, err = parse(val["update"]); err != nil {
+			return nil, fmt.Errorf("parsing `update` for %q: %+v", resourceType, err)
+		}
+		if defaults.Delete, err = parse(val["delete"]); err != nil {
+			return nil, fmt.Errorf("parsing `delete` for %q: %+v", resourceType, err)
+		}
+
+		output[resourceType] = defaults
+	}
+
+	return output, nil
+}
+
+// applyDefaultTimeouts applies the default timeouts from the Provider block to each Resource, resetting any
+// Resources which no longer have default timeouts to the timeouts defined by the Resource
+func applyDefaultTimeouts(resources map[string]*schema.Resource, defaultTimeouts map[string]timeouts.Defaults) {
+	for resourceType, resource := range resources {
+		var defaults *timeouts.Defaults
+		if v, ok := defaultTimeouts[resourceType]; ok {
+			defaults = &v
+		}
+		timeouts.ApplyDefaults(resourceType, resource, defaults)
+	}
+}
@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestExpandDefaultTimeouts(t *testing.T) {
+	resources := map[string]*schema.Resource{
+		"azurerm_kubernetes_cluster": {},
+	}
+
+	testData := []struct {
+		Name        string
+		Input       []interface{}
+		ShouldError bool
+		Expected    *time.Duration
+	}{
+		{
+			Name:  "Not Specified",
+			Input: []interface{}{},
+		},
+		{
+			Name: "Create Timeout",
+			Input: []interface{}{
+				map[string]interface{}{
+					"resource_type": "azurerm_kubernetes_cluster",
+					"create":        "90m",
+					"read":          "",
+					"update":        "",
+					"delete":        "",
+				},
+			},
+			Expected: func() *time.Duration {
+				v := 90 * time.Minute
+				return &v
+			}(),
+		},
+		{
+			Name: "Unsupported Resource Type",
+			Input: []interface{}{
+				map[string]interface{}{
+					"resource_type": "azurerm_does_not_exist",
+					"create":        "90m",
+				},
+			},
+			ShouldError: true,
+		},
+		{
+			Name: "Duplicate Resource Type",
+			Input: []interface{}{
+				map[string]interface{}{
+					"resource_type": "azurerm_kubernetes_cluster",
+					"create":        "90m",
+				},
+				map[string]interface{}{
+					"resource_type": "azurerm_kubernetes_cluster",
+					"delete":        "90m",
+				},
+			},
+			ShouldError: true,
+		},
+	}
+
+	for _, testCase := range testData {
+		t.Logf("[DEBUG] Test Case: %q", testCase.Name)
+		actual, err := expandDefaultTimeouts(testCase.Input, resources)
+		if testCase.ShouldError {
+			if err == nil {
+				t.Fatalf("expected an error but didn't get one")
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("expected no error but got %+v", err)
+		}
+
+		defaults, ok := actual["azurerm_kubernetes_cluster"]
+		if testCase.Expected == nil {
+			if ok {
+				t.Fatalf("expected no default timeouts but got %+v", defaults)
+			}
+			continue
+		}
+		if defaults.Create == nil || *defaults.Create != *testCase.Expected {
+			t.Fatalf("expected the Create timeout to be %s but got %+v", *testCase.Expected, defaults.Create)
+		}
+		if defaults.Read != nil || defaults.Update != nil || defaults.Delete != nil {
+			t.Fatalf("expected only the Create timeout to be set but got %+v", defaults)
+		}
+	}
+}
+
+func TestValidateTimeoutDuration(t *testing.T) {
+	testData := map[string]bool{
+		"":      false,
+		"90m":   true,
+		"1h30m": true,
+		"0s":    false,
+		"-5m":   false,
+		"90":    false,
+	}
+
+	for input, valid := range testData {
+		t.Logf("[DEBUG] Test Case: %q", input)
+		_, errors := validateTimeoutDuration(input, "create")
+		if valid && len(errors) > 0 {
+			t.Fatalf("expected %q to be valid but got %+v", input, errors)
+		}
+		if !valid && len(errors) == 0 {
+			t.Fatalf("expected %q to be invalid", input)
+		}
+	}
+}
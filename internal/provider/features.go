@@ -126,6 +126,12 @@ func schemaFeatures(supportLegacyTestSuite bool) *pluginsdk.Schema {
 			},
 		},
 
+		"read_only": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+
 		"resource_group": {
 			Type:     pluginsdk.TypeList,
 			Optional: true,
@@ -266,5 +272,9 @@ func expandFeatures(input []interface{}) features.UserFeatures {
 		}
 	}
 
+	if v, ok := val["read_only"]; ok {
+		features.ReadOnly = v.(bool)
+	}
+
 	return features
 }
@@ -73,6 +73,7 @@ func TestExpandFeatures(t *testing.T) {
 							"relaxed_locking": true,
 						},
 					},
+					"read_only": true,
 					"resource_group": []interface{}{
 						map[string]interface{}{
 							"prevent_deletion_if_contains_resources": true,
@@ -112,6 +113,7 @@ func TestExpandFeatures(t *testing.T) {
 				Network: features.NetworkFeatures{
 					RelaxedLocking: true,
 				},
+				ReadOnly: true,
 				ResourceGroup: features.ResourceGroupFeatures{
 					PreventDeletionIfContainsResources: true,
 				},
@@ -154,6 +156,7 @@ func TestExpandFeatures(t *testing.T) {
 							"relaxed_locking": false,
 						},
 					},
+					"read_only": false,
 					"resource_group": []interface{}{
 						map[string]interface{}{
 							"prevent_deletion_if_contains_resources": false,
@@ -820,3 +823,51 @@ func TestExpandFeaturesResourceGroup(t *testing.T) {
 		}
 	}
 }
+
+func TestExpandFeaturesReadOnly(t *testing.T) {
+	testData := []struct {
+		Name     string
+		Input    []interface{}
+		Expected features.UserFeatures
+	}{
+		{
+			Name: "Empty Block",
+			Input: []interface{}{
+				map[string]interface{}{},
+			},
+			Expected: features.UserFeatures{
+				ReadOnly: false,
+			},
+		},
+		{
+			Name: "Read Only Enabled",
+			Input: []interface{}{
+				map[string]interface{}{
+					"read_only": true,
+				},
+			},
+			Expected: features.UserFeatures{
+				ReadOnly: true,
+			},
+		},
+		{
+			Name: "Read Only Disabled",
+			Input: []interface{}{
+				map[string]interface{}{
+					"read_only": false,
+				},
+			},
+			Expected: features.UserFeatures{
+				ReadOnly: false,
+			},
+		},
+	}
+
+	for _, testCase := range testData {
+		t.Logf("[DEBUG] Test Case: %q", testCase.Name)
+		result := expandFeatures(testCase.Input)
+		if result.ReadOnly != testCase.Expected.ReadOnly {
+			t.Fatalf("Expected %t but got %t", testCase.Expected.ReadOnly, result.ReadOnly)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+func schemaIgnoreTags() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"keys": {
+					Type:     pluginsdk.TypeSet,
+					Optional: true,
+					Elem: &pluginsdk.Schema{
+						Type: pluginsdk.TypeString,
+					},
+					Description: "A list of tag keys which should be ignored by every resource.",
+				},
+
+				"key_prefixes": {
+					Type:     pluginsdk.TypeSet,
+					Optional: true,
+					Elem: &pluginsdk.Schema{
+						Type: pluginsdk.TypeString,
+					},
+					Description: "A list of tag key prefixes which should be ignored by every resource.",
+				},
+			},
+		},
+	}
+}
+
+func expandIgnoreTags(input []interface{}) (keys []string, keyPrefixes []string) {
+	if len(input) == 0 || input[0] == nil {
+		return []string{}, []string{}
+	}
+
+	val := input[0].(map[string]interface{})
+	keys = *utils.ExpandStringSlice(val["keys"].(*pluginsdk.Set).List())
+	keyPrefixes = *utils.ExpandStringSlice(val["key_prefixes"].(*pluginsdk.Set).List())
+	return keys, keyPrefixes
+}
+
+// withIgnoredTags wraps the Create, Read and Update functions for a Resource (or Data Source) which exposes tags
+// but doesn't support default tags (and so has no `tags_all` field) such that ignored tags are excluded from the
+// `tags` field - Resources with a `tags_all` field exclude (and preserve) the ignored tags in withDefaultTags.
+//
+// Resources whose tags can be updated are given a `tags_all` field containing all of the tags assigned to the
+// resource, so that the ignored tags can be sent to Azure along with the configured tags (rather than removed)
+func withIgnoredTags(resource *schema.Resource) {
+	tagsSchema, ok := resource.Schema["tags"]
+	if !ok || tagsSchema.Type != schema.TypeMap {
+		return
+	}
+	if _, ok := resource.Schema["tags_all"]; ok {
+		return
+	}
+
+	//lint:ignore SA1019 SDKv2 migration - staticcheck's own linter directives are currently being ignored under golanci-lint
+	preserve := tagsSchema.Optional && (resource.Update != nil || resource.UpdateContext != nil) //nolint:staticcheck
+	if preserve {
+		resource.Schema["tags_all"] = tags.SchemaTagsAll()
+	}
+
+	withoutIgnored := func(d *schema.ResourceData, meta interface{}, inner func() error) error {
+		err := inner()
+		if d.Id() == "" {
+			return err
+		}
+
+		all, _ := d.Get("tags").(map[string]interface{})
+		if preserve {
+			if setErr := d.Set("tags_all", all); setErr != nil && err == nil {
+				err = fmt.Errorf("setting `tags_all`: %+v", setErr)
+			}
+		}
+
+		config := tagsConfig(meta)
+		if !config.HasIgnoredTags() {
+			return err
+		}
+		if setErr := d.Set("tags", config.WithoutIgnored(all)); setErr != nil && err == nil {
+			err = fmt.Errorf("setting `tags`: %+v", setErr)
+		}
+		return err
+	}
+
+	preserveIgnored := func(d *schema.ResourceData, meta interface{}, inner func() error) error {
+		if config := tagsConfig(meta); preserve && config.HasIgnoredTags() {
+			configured, _ := d.Get("tags").(map[string]interface{})
+			existing, _ := d.GetChange("tags_all")
+			existingTags, _ := existing.(map[string]interface{})
+			if err := d.Set("tags", config.PreserveIgnored(configured, existingTags)); err != nil {
+				return fmt.Errorf("setting `tags`: %+v", err)
+			}
+		}
+
+		return withoutIgnored(d, meta, inner)
+	}
+
+	wrapTagsFuncs(resource, preserveIgnored, withoutIgnored)
+}
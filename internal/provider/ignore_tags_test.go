@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func TestExpandIgnoreTags(t *testing.T) {
+	testData := []struct {
+		Name                string
+		Input               []interface{}
+		ExpectedKeys        []string
+		ExpectedKeyPrefixes []string
+	}{
+		{
+			Name:                "Not Specified",
+			Input:               []interface{}{},
+			ExpectedKeys:        []string{},
+			ExpectedKeyPrefixes: []string{},
+		},
+		{
+			Name: "Keys and Prefixes",
+			Input: []interface{}{
+				map[string]interface{}{
+					"keys":         pluginsdk.NewSet(pluginsdk.HashString, []interface{}{"ms-resource-usage", "CreatedOnDate"}),
+					"key_prefixes": pluginsdk.NewSet(pluginsdk.HashString, []interface{}{"hidden-link:"}),
+				},
+			},
+			ExpectedKeys:        []string{"CreatedOnDate", "ms-resource-usage"},
+			ExpectedKeyPrefixes: []string{"hidden-link:"},
+		},
+	}
+
+	for _, testCase := range testData {
+		t.Logf("[DEBUG] Test Case: %q", testCase.Name)
+		keys, keyPrefixes := expandIgnoreTags(testCase.Input)
+		sort.Strings(keys)
+		if !reflect.DeepEqual(keys, testCase.ExpectedKeys) {
+			t.Fatalf("expected the keys %+v but got %+v", testCase.ExpectedKeys, keys)
+		}
+		if !reflect.DeepEqual(keyPrefixes, testCase.ExpectedKeyPrefixes) {
+			t.Fatalf("expected the key prefixes %+v but got %+v", testCase.ExpectedKeyPrefixes, keyPrefixes)
+		}
+	}
+}
+
+func TestWithIgnoredTagsUpdate(t *testing.T) {
+	// the tags assigned to the resource in Azure, which includes a tag added outside of Terraform
+	remote := map[string]*string{}
+	read := func(d *pluginsdk.ResourceData, meta interface{}) error {
+		return tags.FlattenAndSet(d, remote)
+	}
+	resource := &pluginsdk.Resource{
+		Schema: map[string]*pluginsdk.Schema{
+			"tags": {
+				Type:     pluginsdk.TypeMap,
+				Optional: true,
+				Computed: true,
+				Elem: &pluginsdk.Schema{
+					Type: pluginsdk.TypeString,
+				},
+			},
+		},
+		Read: read,
+		Update: func(d *pluginsdk.ResourceData, meta interface{}) error {
+			// the tags sent to Azure replace those assigned to the resource
+			remote = tags.Expand(d.Get("tags").(map[string]interface{}))
+			return read(d, meta)
+		},
+	}
+	withDefaultTags(resource)
+	withIgnoredTags(resource)
+	if _, ok := resource.Schema["tags_all"]; !ok {
+		t.Fatalf("expected a `tags_all` field for a Resource whose tags can be updated")
+	}
+
+	meta := &clients.Client{
+		Tags: tags.NewConfig(nil, []string{"ms-resource-usage"}, nil),
+	}
+	d := resource.Data(&terraform.InstanceState{
+		ID: "example",
+		Attributes: map[string]string{
+			"id":                         "example",
+			"tags.%":                     "1",
+			"tags.name":                  "old",
+			"tags_all.%":                 "2",
+			"tags_all.name":              "old",
+			"tags_all.ms-resource-usage": "azure-cloud-shell",
+		},
+	})
+	if err := d.Set("tags", map[string]interface{}{"name": "new"}); err != nil {
+		t.Fatalf("setting `tags`: %+v", err)
+	}
+	if err := resource.Update(d, meta); err != nil { //nolint:staticcheck
+		t.Fatalf("updating: %+v", err)
+	}
+
+	if v := remote["ms-resource-usage"]; v == nil || *v != "azure-cloud-shell" {
+		t.Fatalf("expected the ignored tag to be preserved but got %+v", remote)
+	}
+	expected := map[string]interface{}{
+		"name": "new",
+	}
+	if actual := d.Get("tags").(map[string]interface{}); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected `tags` to be %+v but got %+v", expected, actual)
+	}
+}
@@ -0,0 +1,117 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/locks"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+func schemaLockBackend() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"file": {
+					Type:     pluginsdk.TypeList,
+					Required: true,
+					MaxItems: 1,
+					Elem: &pluginsdk.Resource{
+						Schema: map[string]*pluginsdk.Schema{
+							"directory": {
+								Type:         pluginsdk.TypeString,
+								Required:     true,
+								ValidateFunc: validation.StringIsNotEmpty,
+								Description:  "The directory (which can be on a shared volume) where lock files should be created.",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func expandLockBackend(input []interface{}) (locks.Backend, error) {
+	if len(input) == 0 || input[0] == nil {
+		return nil, nil
+	}
+
+	val := input[0].(map[string]interface{})
+	if v, ok := val["file"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+		file := v[0].(map[string]interface{})
+		backend, err := locks.NewFileBackend(file["directory"].(string))
+		if err != nil {
+			return nil, fmt.Errorf("building File Lock Backend: %+v", err)
+		}
+		return backend, nil
+	}
+
+	return nil, nil
+}
+
+// withLockContext wraps the CRUD functions for the specified Resource such that the context used to acquire locks
+// records the Resource being managed as the holder (to diagnose contention) - and contains the Lock Backend from the
+// Client (if configured), which is used to lock keys across processes.
+//
+// Since the CRUD functions which don't receive a context use the StopContext from the Client, these are passed a
+// copy of the Client whose StopContext records the holder
+func withLockContext(resourceType string, resource *schema.Resource) {
+	wrap := func(f func(d *schema.ResourceData, meta interface{}) error) func(d *schema.ResourceData, meta interface{}) error {
+		if f == nil {
+			return nil
+		}
+
+		return func(d *schema.ResourceData, meta interface{}) error {
+			if client, ok := meta.(*clients.Client); ok && client.StopContext != nil {
+				withHolder := *client
+				withHolder.StopContext = locks.WithHolder(client.StopContext, lockHolder(resourceType, d))
+				meta = &withHolder
+			}
+
+			return f(d, meta)
+		}
+	}
+
+	wrapContext := func(f func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics) func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		if f == nil {
+			return nil
+		}
+
+		return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			if client, ok := meta.(*clients.Client); ok && client.LockBackend != nil {
+				ctx = locks.WithBackend(ctx, client.LockBackend)
+			}
+			ctx = locks.WithHolder(ctx, lockHolder(resourceType, d))
+
+			return f(ctx, d, meta)
+		}
+	}
+
+	//lint:ignore SA1019 SDKv2 migration - staticcheck's own linter directives are currently being ignored under golanci-lint
+	resource.Create = wrap(resource.Create) //nolint:staticcheck
+	resource.Read = wrap(resource.Read)     //nolint:staticcheck
+	resource.Update = wrap(resource.Update) //nolint:staticcheck
+	resource.Delete = wrap(resource.Delete) //nolint:staticcheck
+	resource.CreateContext = wrapContext(resource.CreateContext)
+	resource.ReadContext = wrapContext(resource.ReadContext)
+	resource.UpdateContext = wrapContext(resource.UpdateContext)
+	resource.DeleteContext = wrapContext(resource.DeleteContext)
+}
+
+// lockHolder returns the Resource Type and (once known) the Resource ID of the Resource being managed,
+// e.g. `azurerm_subnet "/subscriptions/.../subnets/subnet1"`
+func lockHolder(resourceType string, d *schema.ResourceData) string {
+	if d.Id() == "" {
+		return resourceType
+	}
+
+	return fmt.Sprintf("%s %q", resourceType, d.Id())
+}
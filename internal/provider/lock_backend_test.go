@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/locks"
+)
+
+func TestExpandLockBackend(t *testing.T) {
+	directory, err := ioutil.TempDir("", "locks")
+	if err != nil {
+		t.Fatalf("creating temp directory: %+v", err)
+	}
+	defer os.RemoveAll(directory)
+
+	testData := []struct {
+		Name     string
+		Input    []interface{}
+		Expected bool
+	}{
+		{
+			Name:     "Not Specified",
+			Input:    []interface{}{},
+			Expected: false,
+		},
+		{
+			Name: "File",
+			Input: []interface{}{
+				map[string]interface{}{
+					"file": []interface{}{
+						map[string]interface{}{
+							"directory": filepath.Join(directory, "nested"),
+						},
+					},
+				},
+			},
+			Expected: true,
+		},
+	}
+
+	for _, testCase := range testData {
+		t.Logf("[DEBUG] Test Case: %q", testCase.Name)
+		backend, err := expandLockBackend(testCase.Input)
+		if err != nil {
+			t.Fatalf("expected no error but got %+v", err)
+		}
+		if (backend != nil) != testCase.Expected {
+			t.Fatalf("expected a backend to be returned (%t) but got %+v", testCase.Expected, backend)
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(directory, "nested")); err != nil {
+		t.Fatalf("expected the lock directory to be created but got %+v", err)
+	}
+}
+
+func TestWithLockContextRecordsHolder(t *testing.T) {
+	var lockErr error
+	resource := &schema.Resource{
+		Schema: map[string]*schema.Schema{},
+		Update: func(d *schema.ResourceData, meta interface{}) error {
+			if err := locks.ByIDWithContext(meta.(*clients.Client).StopContext, "testLockHolder"); err != nil {
+				return err
+			}
+			defer locks.UnlockByID("testLockHolder")
+
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+			lockErr = locks.ByIDWithContext(ctx, "testLockHolder")
+			return nil
+		},
+	}
+	withLockContext("azurerm_example", resource)
+
+	d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{})
+	d.SetId("/some/resource/id")
+	//lint:ignore SA1019 SDKv2 migration - staticcheck's own linter directives are currently being ignored under golanci-lint
+	if err := resource.Update(d, &clients.Client{StopContext: context.Background()}); err != nil { //nolint:staticcheck
+		t.Fatalf("expected no error but got %+v", err)
+	}
+
+	if lockErr == nil {
+		t.Fatalf("expected an error but didn't get one")
+	}
+	if !strings.Contains(lockErr.Error(), `held by azurerm_example "/some/resource/id"`) {
+		t.Fatalf("expected the error to contain the holder but got %+v", lockErr)
+	}
+}
@@ -7,14 +7,17 @@ import (
 	"os"
 	"strings"
 
+	"github.com/Azure/go-autorest/autorest"
 	"github.com/hashicorp/go-azure-helpers/authentication"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/locks"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/resourceproviders"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
 	"github.com/hashicorp/terraform-provider-azurerm/utils"
 )
 
@@ -26,6 +29,14 @@ func TestAzureProvider() *schema.Provider {
 	return azureProvider(true)
 }
 
+// TestAzureProviderWithClientHooks returns the Provider used in the acceptance tests, whose clients
+// are customised using the specified hooks (for example to record and replay requests)
+func TestAzureProviderWithClientHooks(hooks ClientHooks) *schema.Provider {
+	p := azureProvider(true)
+	p.ConfigureContextFunc = providerConfigure(p, hooks)
+	return p
+}
+
 func azureProvider(supportLegacyTestSuite bool) *schema.Provider {
 	// avoids this showing up in test output
 	debugLog := func(f string, v ...interface{}) {
@@ -43,6 +54,14 @@ func azureProvider(supportLegacyTestSuite bool) *schema.Provider {
 	dataSources := make(map[string]*schema.Resource)
 	resources := make(map[string]*schema.Resource)
 
+	// resourceProviders are the Resource Providers used by each Data Source/Resource, keyed by the Resource Type
+	resourceProviders := make(map[string][]string)
+	recordResourceProviders := func(service interface{}, resourceType string) {
+		if v, ok := service.(sdk.ServiceRegistrationWithResourceProviders); ok {
+			resourceProviders[resourceType] = v.ResourceProviders()
+		}
+	}
+
 	// first handle the typed services
 	for _, service := range SupportedTypedServices() {
 		debugLog("[DEBUG] Registering Data Sources for %q..", service.Name())
@@ -59,6 +78,26 @@ func azureProvider(supportLegacyTestSuite bool) *schema.Provider {
 			}
 
 			dataSources[key] = dataSource
+			recordResourceProviders(service, key)
+		}
+
+		if v, ok := service.(sdk.TypedServiceRegistrationWithListDataSources); ok {
+			debugLog("[DEBUG] Registering List Data Sources for %q..", service.Name())
+			for _, ds := range v.ListDataSources() {
+				key := ds.ResourceType()
+				if existing := dataSources[key]; existing != nil {
+					panic(fmt.Sprintf("An existing Data Source exists for %q", key))
+				}
+
+				wrapper := sdk.NewListDataSourceWrapper(ds)
+				dataSource, err := wrapper.DataSource()
+				if err != nil {
+					panic(fmt.Errorf("creating Wrapper for List Data Source %q: %+v", key, err))
+				}
+
+				dataSources[key] = dataSource
+				recordResourceProviders(service, key)
+			}
 		}
 
 		debugLog("[DEBUG] Registering Resources for %q..", service.Name())
@@ -74,6 +113,7 @@ func azureProvider(supportLegacyTestSuite bool) *schema.Provider {
 				panic(fmt.Errorf("creating Wrapper for Resource %q: %+v", key, err))
 			}
 			resources[key] = resource
+			recordResourceProviders(service, key)
 		}
 	}
 
@@ -86,6 +126,7 @@ func azureProvider(supportLegacyTestSuite bool) *schema.Provider {
 			}
 
 			dataSources[k] = v
+			recordResourceProviders(service, k)
 		}
 
 		debugLog("[DEBUG] Registering Resources for %q..", service.Name())
@@ -95,9 +136,21 @@ func azureProvider(supportLegacyTestSuite bool) *schema.Provider {
 			}
 
 			resources[k] = v
+			recordResourceProviders(service, k)
 		}
 	}
 
+	for resourceType, dataSource := range dataSources {
+		withResourceProviderRegistration(resourceType, resourceProviders[resourceType], dataSource)
+		withIgnoredTags(dataSource)
+	}
+	for resourceType, resource := range resources {
+		withResourceProviderRegistration(resourceType, resourceProviders[resourceType], resource)
+		withDefaultTags(resource)
+		withIgnoredTags(resource)
+		withLockContext(resourceType, resource)
+	}
+
 	p := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"subscription_id": {
@@ -222,12 +275,29 @@ func azureProvider(supportLegacyTestSuite bool) *schema.Provider {
 				Description: "Should the AzureRM Provider skip registering all of the Resource Providers that it supports, if they're not already registered?",
 			},
 
+			"selective_provider_registration": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ARM_SELECTIVE_PROVIDER_REGISTRATION", false),
+				Description: "Should the AzureRM Provider only register the Resource Providers used by the resources in the configuration when they're first used, rather than all of the Resource Providers that it supports?",
+			},
+
 			"storage_use_azuread": {
 				Type:        schema.TypeBool,
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("ARM_STORAGE_USE_AZUREAD", false),
 				Description: "Should the AzureRM Provider use AzureAD to access the Storage Data Plane API's?",
 			},
+
+			"default_timeouts": schemaDefaultTimeouts(),
+
+			"default_tags": schemaDefaultTags(),
+
+			"ignore_tags": schemaIgnoreTags(),
+
+			"lock_backend": schemaLockBackend(),
+
+			"throttling": schemaThrottling(),
 		},
 
 		DataSourcesMap: dataSources,
@@ -243,12 +313,22 @@ func azureProvider(supportLegacyTestSuite bool) *schema.Provider {
 		}
 	}
 
-	p.ConfigureContextFunc = providerConfigure(p)
+	p.ConfigureContextFunc = providerConfigure(p, ClientHooks{})
 
 	return p
 }
 
-func providerConfigure(p *schema.Provider) schema.ConfigureContextFunc {
+// ClientHooks allow the clients used by the Provider to be customised, which is used
+// in the acceptance tests to record and replay requests
+type ClientHooks struct {
+	// AuthConfig (if specified) is called to customise the Authentication Config prior to building the clients
+	AuthConfig func(config *authentication.Config)
+
+	// SendDecorators are applied to the Sender used by each client
+	SendDecorators []autorest.SendDecorator
+}
+
+func providerConfigure(p *schema.Provider, hooks ClientHooks) schema.ConfigureContextFunc {
 	return func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 		var auxTenants []string
 		if v, ok := d.Get("auxiliary_tenant_ids").([]interface{}); ok && len(v) > 0 {
@@ -297,6 +377,9 @@ func providerConfigure(p *schema.Provider) schema.ConfigureContextFunc {
 		if err != nil {
 			return nil, diag.FromErr(fmt.Errorf("building AzureRM Client: %s", err))
 		}
+		if hooks.AuthConfig != nil {
+			hooks.AuthConfig(config)
+		}
 
 		terraformVersion := p.TerraformVersion
 		if terraformVersion == "" {
@@ -305,20 +388,45 @@ func providerConfigure(p *schema.Provider) schema.ConfigureContextFunc {
 			terraformVersion = "0.11+compatible"
 		}
 
-		skipProviderRegistration := d.Get("skip_provider_registration").(bool)
+		userFeatures := expandFeatures(d.Get("features").([]interface{}))
+
+		lockBackend, err := expandLockBackend(d.Get("lock_backend").([]interface{}))
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		ignoredTagKeys, ignoredTagKeyPrefixes := expandIgnoreTags(d.Get("ignore_tags").([]interface{}))
+
+		defaultTimeouts, err := expandDefaultTimeouts(d.Get("default_timeouts").([]interface{}), p.ResourcesMap)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		applyDefaultTimeouts(p.ResourcesMap, defaultTimeouts)
+
+		// registering a Resource Provider is a write operation, so isn't possible in Read-Only mode
+		skipProviderRegistration := d.Get("skip_provider_registration").(bool) || userFeatures.ReadOnly
+		selectiveProviderRegistration := d.Get("selective_provider_registration").(bool) && !skipProviderRegistration
 		clientBuilder := clients.ClientBuilder{
-			AuthConfig:                  config,
-			SkipProviderRegistration:    skipProviderRegistration,
+			AuthConfig: config,
+			// when registering selectively the clients mustn't register Resource Providers, since these are
+			// registered on-demand by the Registrar (which surfaces a clearer error if this isn't possible)
+			SkipProviderRegistration:    skipProviderRegistration || selectiveProviderRegistration,
 			TerraformVersion:            terraformVersion,
 			PartnerId:                   d.Get("partner_id").(string),
 			DisableCorrelationRequestID: d.Get("disable_correlation_request_id").(bool),
 			DisableTerraformPartnerID:   d.Get("disable_terraform_partner_id").(bool),
-			Features:                    expandFeatures(d.Get("features").([]interface{})),
+			Features:                    userFeatures,
 			StorageUseAzureAD:           d.Get("storage_use_azuread").(bool),
+			Throttling:                  expandThrottling(d.Get("throttling").([]interface{})),
+			LockBackend:                 lockBackend,
+			Tags:                        tags.NewConfig(expandDefaultTags(d.Get("default_tags").([]interface{})), ignoredTagKeys, ignoredTagKeyPrefixes),
+			SendDecorators:              hooks.SendDecorators,
 
 			// this field is intentionally not exposed in the provider block, since it's only used for
 			// platform level tracing
 			CustomCorrelationRequestID: os.Getenv("ARM_CORRELATION_REQUEST_ID"),
+
+			// this field is intentionally not exposed in the provider block, since it's only used for debugging
+			TraceFile: os.Getenv("ARM_PROVIDER_TRACE_FILE"),
 		}
 
 		//lint:ignore SA1019 SDKv2 migration - staticcheck's own linter directives are currently being ignored under golanci-lint
@@ -332,7 +440,8 @@ func providerConfigure(p *schema.Provider) schema.ConfigureContextFunc {
 			return nil, diag.FromErr(err)
 		}
 
-		client.StopContext = stopCtx
+		// the Lock Backend is made available via the StopContext, which the context used by most resources is derived from
+		client.StopContext = locks.WithBackend(stopCtx, client.LockBackend)
 
 		if !skipProviderRegistration {
 			// List all the available providers and their registration state to avoid unnecessary
@@ -345,8 +454,12 @@ func providerConfigure(p *schema.Provider) schema.ConfigureContextFunc {
 			}
 
 			availableResourceProviders := providerList.Values()
-			requiredResourceProviders := resourceproviders.Required()
+			if selectiveProviderRegistration {
+				client.ResourceProviderRegistrar = resourceproviders.NewRegistrar(client.Resource.ProvidersClient, client.Account.SubscriptionId, availableResourceProviders)
+				return client, nil
+			}
 
+			requiredResourceProviders := resourceproviders.Required()
 			if err := resourceproviders.EnsureRegistered(ctx, *client.Resource.ProvidersClient, availableResourceProviders, requiredResourceProviders); err != nil {
 				return nil, diag.FromErr(fmt.Errorf(resourceProviderRegistrationErrorFmt, err))
 			}
@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+)
+
+// withResourceProviderRegistration wraps the CRUD functions for the specified Data Source/Resource so that
+// (when Selective Resource Provider Registration is enabled) the Resource Providers it uses are registered
+// before it's first used
+func withResourceProviderRegistration(resourceType string, resourceProviders []string, resource *schema.Resource) {
+	if len(resourceProviders) == 0 {
+		return
+	}
+
+	ensureRegistered := func(ctx context.Context, meta interface{}) error {
+		client, ok := meta.(*clients.Client)
+		if !ok || client.ResourceProviderRegistrar == nil {
+			return nil
+		}
+
+		return client.ResourceProviderRegistrar.EnsureRegistered(ctx, resourceType, resourceProviders)
+	}
+
+	wrap := func(f func(d *schema.ResourceData, meta interface{}) error) func(d *schema.ResourceData, meta interface{}) error {
+		if f == nil {
+			return nil
+		}
+
+		return func(d *schema.ResourceData, meta interface{}) error {
+			ctx := context.Background()
+			if client, ok := meta.(*clients.Client); ok && client.StopContext != nil {
+				ctx = client.StopContext
+			}
+			if err := ensureRegistered(ctx, meta); err != nil {
+				return err
+			}
+
+			return f(d, meta)
+		}
+	}
+
+	wrapContext := func(f func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics) func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		if f == nil {
+			return nil
+		}
+
+		return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			if err := ensureRegistered(ctx, meta); err != nil {
+				return diag.FromErr(err)
+			}
+
+			return f(ctx, d, meta)
+		}
+	}
+
+	//lint:ignore SA1019 SDKv2 migration - staticcheck's own linter directives are currently being ignored under golanci-lint
+	resource.Create = wrap(resource.Create) //nolint:staticcheck
+	resource.Read = wrap(resource.Read)     //nolint:staticcheck
+	resource.Update = wrap(resource.Update) //nolint:staticcheck
+	resource.Delete = wrap(resource.Delete) //nolint:staticcheck
+	resource.CreateContext = wrapContext(resource.CreateContext)
+	resource.ReadContext = wrapContext(resource.ReadContext)
+	resource.UpdateContext = wrapContext(resource.UpdateContext)
+	resource.DeleteContext = wrapContext(resource.DeleteContext)
+}
@@ -3,7 +3,9 @@ package provider
 import (
 	"testing"
 
+	"github.com/hashicorp/terraform-provider-azurerm/internal/resourceproviders"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 )
 
 func TestTypedDataSourcesContainValidModelObjects(t *testing.T) {
@@ -31,3 +33,79 @@ func TestTypedResourcesContainValidModelObjects(t *testing.T) {
 		}
 	}
 }
+
+func TestTypedDataSourcesModelObjectsMatchSchema(t *testing.T) {
+	for _, service := range SupportedTypedServices() {
+		t.Logf("Service %q..", service.Name())
+		for _, resource := range service.DataSources() {
+			t.Logf("- DataSources %q..", resource.ResourceType())
+			obj := resource.ModelObject()
+			if obj == nil {
+				continue
+			}
+
+			if err := sdk.ValidateModelObjectMatchesSchema(obj, combinedSchema(resource.Arguments(), resource.Attributes())); err != nil {
+				t.Fatalf("validating model matches the schema: %+v", err)
+			}
+		}
+	}
+}
+
+func TestTypedResourcesModelObjectsMatchSchema(t *testing.T) {
+	for _, service := range SupportedTypedServices() {
+		t.Logf("Service %q..", service.Name())
+		for _, resource := range service.Resources() {
+			t.Logf("- Resource %q..", resource.ResourceType())
+			obj := resource.ModelObject()
+			if obj == nil {
+				continue
+			}
+
+			if err := sdk.ValidateModelObjectMatchesSchema(obj, combinedSchema(resource.Arguments(), resource.Attributes())); err != nil {
+				t.Fatalf("validating model matches the schema: %+v", err)
+			}
+		}
+	}
+}
+
+func combinedSchema(arguments, attributes map[string]*pluginsdk.Schema) map[string]*pluginsdk.Schema {
+	out := make(map[string]*pluginsdk.Schema)
+	for k, v := range arguments {
+		out[k] = v
+	}
+	for k, v := range attributes {
+		out[k] = v
+	}
+	return out
+}
+
+func TestServicesDeclareResourceProviders(t *testing.T) {
+	services := make([]interface{}, 0)
+	for _, service := range SupportedTypedServices() {
+		services = append(services, service)
+	}
+	for _, service := range SupportedUntypedServices() {
+		services = append(services, service)
+	}
+
+	declared := make(map[string]struct{})
+	for _, service := range services {
+		v, ok := service.(sdk.ServiceRegistrationWithResourceProviders)
+		if !ok {
+			t.Fatalf("expected the Service %T to declare the Resource Providers it uses", service)
+		}
+		if len(v.ResourceProviders()) == 0 {
+			t.Fatalf("expected the Service %T to use at least one Resource Provider", service)
+		}
+		for _, resourceProvider := range v.ResourceProviders() {
+			declared[resourceProvider] = struct{}{}
+		}
+	}
+
+	// otherwise enabling Selective Resource Provider Registration wouldn't register a Resource Provider which is used
+	for resourceProvider := range resourceproviders.Required() {
+		if _, ok := declared[resourceProvider]; !ok {
+			t.Errorf("expected the Resource Provider %q to be declared by a Service", resourceProvider)
+		}
+	}
+}
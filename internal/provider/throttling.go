@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/throttling"
+)
+
+func schemaThrottling() *pluginsdk.Schema {
+	defaults := throttling.DefaultOptions()
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"adaptive": {
+					Type:        pluginsdk.TypeBool,
+					Optional:    true,
+					Default:     defaults.Adaptive,
+					Description: "Should the request rate be reduced based on the remaining quota returned by Azure Resource Manager?",
+				},
+
+				"burst": {
+					Type:         pluginsdk.TypeInt,
+					Optional:     true,
+					Default:      defaults.Burst,
+					ValidateFunc: validation.IntAtLeast(1),
+					Description:  "The number of requests which can be sent at once for each Subscription and Resource Provider.",
+				},
+
+				"requests_per_second": {
+					Type:         pluginsdk.TypeFloat,
+					Optional:     true,
+					Default:      defaults.RequestsPerSecond,
+					ValidateFunc: validation.FloatAtLeast(0.1),
+					Description:  "The sustained number of requests per second which can be sent for each Subscription and Resource Provider.",
+				},
+			},
+		},
+	}
+}
+
+func expandThrottling(input []interface{}) *throttling.Options {
+	if len(input) == 0 || input[0] == nil {
+		return nil
+	}
+
+	// these are the defaults if omitted from the config
+	options := throttling.DefaultOptions()
+
+	val := input[0].(map[string]interface{})
+	if v, ok := val["adaptive"]; ok {
+		options.Adaptive = v.(bool)
+	}
+	if v, ok := val["burst"]; ok {
+		options.Burst = v.(int)
+	}
+	if v, ok := val["requests_per_second"]; ok {
+		options.RequestsPerSecond = v.(float64)
+	}
+
+	return &options
+}
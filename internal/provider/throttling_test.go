@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/throttling"
+)
+
+func TestExpandThrottling(t *testing.T) {
+	testData := []struct {
+		Name     string
+		Input    []interface{}
+		Expected *throttling.Options
+	}{
+		{
+			Name:     "Not Specified",
+			Input:    []interface{}{},
+			Expected: nil,
+		},
+		{
+			Name: "Empty Block",
+			Input: []interface{}{
+				map[string]interface{}{},
+			},
+			Expected: &throttling.Options{
+				RequestsPerSecond: 10,
+				Burst:             20,
+				Adaptive:          true,
+			},
+		},
+		{
+			Name: "Complete",
+			Input: []interface{}{
+				map[string]interface{}{
+					"adaptive":            false,
+					"burst":               5,
+					"requests_per_second": 2.5,
+				},
+			},
+			Expected: &throttling.Options{
+				RequestsPerSecond: 2.5,
+				Burst:             5,
+				Adaptive:          false,
+			},
+		},
+	}
+
+	for _, testCase := range testData {
+		t.Logf("[DEBUG] Test Case: %q", testCase.Name)
+		result := expandThrottling(testCase.Input)
+		if !reflect.DeepEqual(result, testCase.Expected) {
+			t.Fatalf("Expected %+v but got %+v", testCase.Expected, result)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package resourceproviders
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2017-03-09/resources/mgmt/resources"
+)
+
+// Registrar registers Resource Providers on-demand as the resources using them are used, rather than
+// registering every Resource Provider supported by the Provider - meaning that only the Resource
+// Providers used in the configuration need to be registered (or registerable by the principal).
+type Registrar struct {
+	client         *resources.ProvidersClient
+	subscriptionId string
+
+	lock sync.Mutex
+
+	// states is the registration state of each available Resource Provider, keyed by the lower-cased namespace
+	states map[string]registrationState
+}
+
+type registrationState struct {
+	namespace  string
+	registered bool
+
+	// err is the error returned when attempting to register this Resource Provider,
+	// which is cached to avoid attempting this for every resource
+	err error
+}
+
+// NewRegistrar returns a Registrar for the Resource Providers available in the specified Subscription
+func NewRegistrar(client *resources.ProvidersClient, subscriptionId string, availableRPs []resources.Provider) *Registrar {
+	states := make(map[string]registrationState)
+	for _, rp := range availableRPs {
+		if rp.Namespace == nil {
+			continue
+		}
+
+		registered := false
+		if rp.RegistrationState != nil {
+			registered = strings.EqualFold(*rp.RegistrationState, "Registered")
+		}
+		states[strings.ToLower(*rp.Namespace)] = registrationState{
+			namespace:  *rp.Namespace,
+			registered: registered,
+		}
+	}
+
+	return &Registrar{
+		client:         client,
+		subscriptionId: subscriptionId,
+		states:         states,
+	}
+}
+
+// EnsureRegistered ensures that the Resource Providers used by the specified Resource Type are registered,
+// returning an error describing how to resolve this if a Resource Provider can't be registered
+func (r *Registrar) EnsureRegistered(ctx context.Context, resourceType string, resourceProviders []string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for _, namespace := range resourceProviders {
+		key := strings.ToLower(namespace)
+		state, ok := r.states[key]
+		if !ok {
+			// this Resource Provider isn't available in this Cloud/Subscription, so can't be registered
+			log.Printf("[DEBUG] Resource Provider %q (used by %q) isn't available - skipping registration", namespace, resourceType)
+			continue
+		}
+		if state.registered {
+			continue
+		}
+
+		if state.err == nil {
+			log.Printf("[DEBUG] Registering Resource Provider %q (used by %q)..", state.namespace, resourceType)
+			if _, err := r.client.Register(ctx, state.namespace); err != nil {
+				state.err = err
+			} else {
+				state.registered = true
+			}
+			r.states[key] = state
+		}
+
+		if state.err != nil {
+			return fmt.Errorf(registrationFailedErrorFmt, state.namespace, resourceType, r.subscriptionId, state.namespace, state.namespace, state.err)
+		}
+	}
+
+	return nil
+}
+
+const registrationFailedErrorFmt = `the Resource Provider %q (used by %q) isn't registered in Subscription %q and couldn't be registered automatically.
+
+To use this resource either register this Resource Provider, for example using:
+
+> az provider register --namespace %s
+
+or grant the principal used by Terraform permission to register it (the "%s/register/action" permission).
+
+Original Error: %+v`
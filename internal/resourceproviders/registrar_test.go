@@ -0,0 +1,82 @@
+package resourceproviders
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2017-03-09/resources/mgmt/resources"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+func TestRegistrarEnsureRegistered(t *testing.T) {
+	lock := sync.Mutex{}
+	registrations := make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// e.g. /subscriptions/000/providers/Microsoft.Foo/register
+		segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+		namespace := segments[3]
+
+		lock.Lock()
+		registrations[namespace]++
+		lock.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		if namespace == "Microsoft.Forbidden" {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"error":{"code":"AuthorizationFailed","message":"no permission to register"}}`))
+			return
+		}
+		w.Write([]byte(`{"namespace":"` + namespace + `","registrationState":"Registering"}`))
+	}))
+	defer server.Close()
+
+	client := resources.NewProvidersClientWithBaseURI(server.URL, "000")
+	client.RetryAttempts = 1
+	registrar := NewRegistrar(&client, "000", []resources.Provider{
+		{Namespace: utils.String("Microsoft.Registered"), RegistrationState: utils.String("Registered")},
+		{Namespace: utils.String("Microsoft.NotRegistered"), RegistrationState: utils.String("NotRegistered")},
+		{Namespace: utils.String("Microsoft.Forbidden"), RegistrationState: utils.String("NotRegistered")},
+		{Namespace: utils.String("Microsoft.Unused"), RegistrationState: utils.String("NotRegistered")},
+	})
+	ctx := context.TODO()
+
+	// Resource Providers which are registered (or unavailable) aren't registered
+	if err := registrar.EnsureRegistered(ctx, "azurerm_registered", []string{"microsoft.registered", "Microsoft.Unavailable"}); err != nil {
+		t.Fatalf("expected no error but got %+v", err)
+	}
+
+	// those which aren't are registered once
+	for i := 0; i < 2; i++ {
+		if err := registrar.EnsureRegistered(ctx, "azurerm_not_registered", []string{"Microsoft.NotRegistered"}); err != nil {
+			t.Fatalf("expected no error but got %+v", err)
+		}
+	}
+
+	// and failures are surfaced (and cached)
+	for i := 0; i < 2; i++ {
+		err := registrar.EnsureRegistered(ctx, "azurerm_forbidden", []string{"Microsoft.Forbidden"})
+		if err == nil {
+			t.Fatalf("expected an error but didn't get one")
+		}
+		if !strings.Contains(err.Error(), "az provider register --namespace Microsoft.Forbidden") {
+			t.Fatalf("expected the error to describe how to register the Resource Provider but got %+v", err)
+		}
+	}
+
+	expected := map[string]int{
+		"Microsoft.NotRegistered": 1,
+		"Microsoft.Forbidden":     1,
+	}
+	if len(registrations) != len(expected) {
+		t.Fatalf("expected %d Resource Providers to be registered but got %+v", len(expected), registrations)
+	}
+	for namespace, count := range expected {
+		if registrations[namespace] != count {
+			t.Fatalf("expected %q to be registered %d times but got %d", namespace, count, registrations[namespace])
+		}
+	}
+}
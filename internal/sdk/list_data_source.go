@@ -0,0 +1,148 @@
+package sdk
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// A ListDataSource is an object which looks up all of the existing resources of a given type
+// matching the specified filters, for example `azurerm_example_things`
+//
+// The `name_regex`, `resource_group_name` and `required_tags` filters are added to the Schema
+// and applied to each of the items automatically, as is paging through the results.
+type ListDataSource interface {
+	// Arguments is a list of additional user-configurable filters for this Data Source
+	// NOTE: the `name_regex`, `resource_group_name` and `required_tags` arguments are
+	// added automatically and so shouldn't be specified here
+	Arguments() map[string]*schema.Schema
+
+	// ItemAttributes is a list of read-only (e.g. Computed-only) attributes for each
+	// of the items returned from this Data Source
+	ItemAttributes() map[string]*schema.Schema
+
+	// ItemModelObject is an instance of the object each item is encoded from
+	ItemModelObject() interface{}
+
+	// ItemsAttributeName is the name of the attribute the matching items are
+	// exposed in (e.g. `things`)
+	ItemsAttributeName() string
+
+	// ResourceType is the exposed name of this Data Source (e.g. `azurerm_example_things`)
+	ResourceType() string
+
+	// List returns the function used to retrieve the first page of results
+	List() ListFunc
+}
+
+// ListRunFunc retrieves the first page of results for a ListDataSource
+// ctx provides a Context instance with the user-provided timeout
+// metadata is a reference to an object containing the Client, ResourceData and a Logger
+// filter contains the filters specified by the user - which can be used to scope the API call
+// (for example to a Resource Group) but are also applied to each item by the wrapper
+type ListRunFunc func(ctx context.Context, metadata ResourceMetaData, filter ListFilter) (ListPage, error)
+
+type ListFunc struct {
+	// Func is the function which should be called to retrieve the first page of results
+	Func ListRunFunc
+
+	// Timeout is the default timeout, which can be overridden by users
+	// for this method - in-turn used for the Azure API
+	Timeout time.Duration
+}
+
+// ListPage is a page of results from a List operation
+//
+// This is intentionally compatible with the Page types within the Azure SDK, which means
+// that implementations only need to embed the Page and add an Items function
+type ListPage interface {
+	// Items returns the items within the current page of results
+	Items() ([]ListItem, error)
+
+	// NotDone returns whether the current page contains any results
+	NotDone() bool
+
+	// NextWithContext advances to the next page of results
+	NextWithContext(ctx context.Context) error
+}
+
+// ListItem is an item within a page of results from a List operation
+type ListItem struct {
+	// Name is the name of this item, used for the `name_regex` filter
+	Name string
+
+	// ResourceGroup is the name of the Resource Group this item exists within,
+	// used for the `resource_group_name` filter
+	ResourceGroup string
+
+	// Tags are the Tags assigned to this item, used for the `required_tags` filter
+	Tags map[string]string
+
+	// Model is the object which is Encoded for this item
+	// NOTE: this must be of the same type as the ItemModelObject
+	Model interface{}
+}
+
+// ListFilter contains the filters specified by the user for a ListDataSource
+type ListFilter struct {
+	// NameRegex is the regular expression the name of the items must match, if specified
+	NameRegex *regexp.Regexp
+
+	// ResourceGroup is the name of the Resource Group items must exist within, if specified
+	ResourceGroup string
+
+	// RequiredTags are the Tags which must be assigned to the items (with the same values)
+	RequiredTags map[string]string
+}
+
+// Matches returns whether the specified item matches each of the filters
+func (f ListFilter) Matches(item ListItem) bool {
+	if f.NameRegex != nil && !f.NameRegex.MatchString(item.Name) {
+		return false
+	}
+
+	if f.ResourceGroup != "" && !strings.EqualFold(f.ResourceGroup, item.ResourceGroup) {
+		return false
+	}
+
+	for k, v := range f.RequiredTags {
+		if val, ok := item.Tags[k]; !ok || val != v {
+			return false
+		}
+	}
+
+	return true
+}
+
+// NewStaticListPage returns a ListPage containing the specified items, for use
+// with API's which return all of the results in a single response
+func NewStaticListPage(items []ListItem) ListPage {
+	return &staticListPage{
+		items: items,
+	}
+}
+
+type staticListPage struct {
+	items []ListItem
+	done  bool
+}
+
+func (p *staticListPage) Items() ([]ListItem, error) {
+	if p.done {
+		return []ListItem{}, nil
+	}
+
+	return p.items, nil
+}
+
+func (p *staticListPage) NotDone() bool {
+	return !p.done && len(p.items) > 0
+}
+
+func (p *staticListPage) NextWithContext(_ context.Context) error {
+	p.done = true
+	return nil
+}
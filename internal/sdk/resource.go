@@ -63,10 +63,6 @@ type Resource interface {
 	IDValidationFunc() pluginsdk.SchemaValidateFunc
 }
 
-// TODO: ResourceWithCustomizeDiff
-// TODO: ResourceWithStateMigration
-// TODO: a generic state migration for updating ID's
-
 type ResourceWithCustomImporter interface {
 	Resource
 
@@ -88,6 +84,32 @@ type ResourceWithUpdate interface {
 	Update() ResourceFunc
 }
 
+// ResourceWithCustomizeDiff is an optional interface
+//
+// Resources implementing this interface can validate (and amend) the planned
+// changes for this resource at plan time - for example to check that a field
+// is only specified when a SKU supports it.
+type ResourceWithCustomizeDiff interface {
+	Resource
+
+	// CustomizeDiff returns a ResourceFunc which runs the Custom Diff logic
+	// NOTE: within this function the ResourceData is nil - use the ResourceDiff
+	// (or Decode, which reads from the ResourceDiff) to access the planned values
+	CustomizeDiff() ResourceFunc
+}
+
+// ResourceWithStateMigration is an optional interface
+//
+// Resources implementing this interface declare the current version of their
+// Schema, along with the State Upgraders used to migrate the state from older
+// versions of this Resource to the current version.
+type ResourceWithStateMigration interface {
+	Resource
+
+	// StateUpgraders returns the Schema Version and the State Upgraders for this Resource
+	StateUpgraders() StateUpgradeData
+}
+
 // ResourceWithDeprecation is an optional interface
 //
 // Resources implementing this interface will be marked as Deprecated
@@ -127,6 +149,10 @@ type ResourceMetaData struct {
 	// for example, to determine if a field has changes
 	ResourceData *schema.ResourceData
 
+	// ResourceDiff is a reference to the ResourceDiff object from Terraform's Plugin SDK
+	// This is only populated during CustomizeDiff, where it provides access to the planned values
+	ResourceDiff *schema.ResourceDiff
+
 	// serializationDebugLogger is used for testing purposes
 	serializationDebugLogger Logger
 }
@@ -3,6 +3,7 @@ package sdk
 import (
 	"fmt"
 	"reflect"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -13,13 +14,33 @@ import (
 //
 // Example Usage:
 //
-// type Person struct {
-//	 Name string `tfschema:"name"
-// }
-// var person Person
-// if err := metadata.Decode(&person); err != nil { .. }
+//	type Person struct {
+//		Name string `tfschema:"name"`
+//	}
+//	var person Person
+//	if err := metadata.Decode(&person); err != nil { .. }
+//
+// When called from within a CustomizeDiff function, the values are
+// decoded from the planned changes (the ResourceDiff) instead.
+//
+// In addition to the primitive types (and lists/maps of them) the following are supported:
+//
+//   - Nested blocks as a slice of structs (`[]Inner`) or a single struct (`Inner` / `*Inner`),
+//     the latter being used for blocks with `MaxItems: 1`
+//   - Pointers to primitive types (e.g. `*string`), which are left nil when a top-level value isn't set -
+//     within a nested block these are always set (to the zero value when omitted), since the Plugin SDK
+//     doesn't expose whether a nested value was set
+//   - `time.Time`, which is parsed from an RFC3339 formatted string
 func (rmd ResourceMetaData) Decode(input interface{}) error {
-	return decodeReflectedType(input, rmd.ResourceData, rmd.serializationDebugLogger)
+	if rmd.ResourceData != nil {
+		return decodeReflectedType(input, rmd.ResourceData, rmd.serializationDebugLogger)
+	}
+
+	if rmd.ResourceDiff != nil {
+		return decodeReflectedType(input, rmd.ResourceDiff, rmd.serializationDebugLogger)
+	}
+
+	return fmt.Errorf("neither ResourceData nor ResourceDiff were set")
 }
 
 // stateRetriever is a convenience wrapper around the Plugin SDK to be able to test it more accurately
@@ -29,12 +50,19 @@ type stateRetriever interface {
 	GetOkExists(key string) (interface{}, bool)
 }
 
+var timeType = reflect.TypeOf(time.Time{})
+
 func decodeReflectedType(input interface{}, stateRetriever stateRetriever, debugLogger Logger) error {
 	if reflect.TypeOf(input).Kind() != reflect.Ptr {
 		return fmt.Errorf("need a pointer")
 	}
 
 	objType := reflect.TypeOf(input).Elem()
+	if objType.Kind() != reflect.Struct {
+		return fmt.Errorf("need a pointer to a struct but got a pointer to %s", objType.Kind())
+	}
+
+	objVal := reflect.ValueOf(input).Elem()
 	for i := 0; i < objType.NumField(); i++ {
 		field := objType.Field(i)
 		debugLogger.Infof("Field", field)
@@ -46,10 +74,9 @@ func decodeReflectedType(input interface{}, stateRetriever stateRetriever, debug
 			}
 
 			debugLogger.Infof("TFSchemaValue: ", tfschemaValue)
-			debugLogger.Infof("Input Type: ", reflect.ValueOf(input).Elem().Field(i).Type())
+			debugLogger.Infof("Input Type: ", objVal.Field(i).Type())
 
-			fieldName := reflect.ValueOf(input).Elem().Field(i).String()
-			if err := setValue(input, tfschemaValue, i, fieldName, debugLogger); err != nil {
+			if err := setValue(objVal.Field(i), tfschemaValue, val, debugLogger); err != nil {
 				return err
 			}
 		}
@@ -57,149 +84,228 @@ func decodeReflectedType(input interface{}, stateRetriever stateRetriever, debug
 	return nil
 }
 
-func setValue(input, tfschemaValue interface{}, index int, fieldName string, debugLogger Logger) (errOut error) {
-	debugLogger.Infof("setting list value for %q..", fieldName)
+// setValue decodes the value from the Terraform Schema into the field, where fieldPath
+// is the path to this field within the Schema (e.g. `block.0.name`) used in error messages
+func setValue(field reflect.Value, tfschemaValue interface{}, fieldPath string, debugLogger Logger) (errOut error) {
+	debugLogger.Infof("setting value for %q..", fieldPath)
 	defer func() {
 		if r := recover(); r != nil {
-			debugLogger.Warnf("error setting value for %q: %+v", fieldName, r)
-			out, ok := r.(error)
-			if !ok {
-				return
-			}
-
-			errOut = out
+			debugLogger.Warnf("error setting value for %q: %+v", fieldPath, r)
+			errOut = fmt.Errorf("setting value for %q: %+v", fieldPath, r)
 		}
 	}()
 
-	if v, ok := tfschemaValue.(string); ok {
-		debugLogger.Infof("[String] Decode %+v", v)
-		debugLogger.Infof("Input %+v", reflect.ValueOf(input))
-		debugLogger.Infof("Input Elem %+v", reflect.ValueOf(input).Elem())
-		reflect.ValueOf(input).Elem().Field(index).SetString(v)
+	if tfschemaValue == nil {
 		return nil
 	}
 
-	if v, ok := tfschemaValue.(int); ok {
-		debugLogger.Infof("[INT] Decode %+v", v)
-		reflect.ValueOf(input).Elem().Field(index).SetInt(int64(v))
+	if field.Type() == timeType {
+		v, ok := tfschemaValue.(string)
+		if !ok {
+			return fmt.Errorf("field %q: expected an RFC3339 formatted string but got %T", fieldPath, tfschemaValue)
+		}
+		if v == "" {
+			return nil
+		}
+
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return fmt.Errorf("field %q: parsing %q as an RFC3339 formatted date: %+v", fieldPath, v, err)
+		}
+
+		debugLogger.Infof("[TIME] Decode %+v", t)
+		field.Set(reflect.ValueOf(t))
 		return nil
 	}
 
-	if v, ok := tfschemaValue.(int32); ok {
-		debugLogger.Infof("[INT] Decode %+v", v)
-		reflect.ValueOf(input).Elem().Field(index).SetInt(int64(v))
+	switch field.Kind() {
+	case reflect.String:
+		v, ok := tfschemaValue.(string)
+		if !ok {
+			return fmt.Errorf("field %q: expected a string but got %T", fieldPath, tfschemaValue)
+		}
+
+		debugLogger.Infof("[String] Decode %+v", v)
+		field.SetString(v)
 		return nil
-	}
 
-	if v, ok := tfschemaValue.(int64); ok {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var v int64
+		switch iv := tfschemaValue.(type) {
+		case int:
+			v = int64(iv)
+		case int32:
+			v = int64(iv)
+		case int64:
+			v = iv
+		default:
+			return fmt.Errorf("field %q: expected an int but got %T", fieldPath, tfschemaValue)
+		}
+
 		debugLogger.Infof("[INT] Decode %+v", v)
-		reflect.ValueOf(input).Elem().Field(index).SetInt(v)
+		field.SetInt(v)
 		return nil
-	}
 
-	if v, ok := tfschemaValue.(float64); ok {
+	case reflect.Float32, reflect.Float64:
+		var v float64
+		switch fv := tfschemaValue.(type) {
+		case float32:
+			v = float64(fv)
+		case float64:
+			v = fv
+		case int:
+			v = float64(fv)
+		default:
+			return fmt.Errorf("field %q: expected a float but got %T", fieldPath, tfschemaValue)
+		}
+
 		debugLogger.Infof("[Float] Decode %+v", v)
-		reflect.ValueOf(input).Elem().Field(index).SetFloat(v)
+		field.SetFloat(v)
 		return nil
-	}
 
-	// Doesn't work for empty bools?
-	if v, ok := tfschemaValue.(bool); ok {
+	case reflect.Bool:
+		v, ok := tfschemaValue.(bool)
+		if !ok {
+			return fmt.Errorf("field %q: expected a bool but got %T", fieldPath, tfschemaValue)
+		}
+
 		debugLogger.Infof("[BOOL] Decode %+v", v)
+		field.SetBool(v)
+		return nil
+
+	case reflect.Ptr:
+		// a single nested block which hasn't been specified should remain nil
+		if items, ok := listItems(tfschemaValue); ok && len(items) == 0 {
+			return nil
+		}
 
-		reflect.ValueOf(input).Elem().Field(index).SetBool(v)
+		elem := reflect.New(field.Type().Elem())
+		if err := setValue(elem.Elem(), tfschemaValue, fieldPath, debugLogger); err != nil {
+			return err
+		}
+
+		field.Set(elem)
 		return nil
-	}
 
-	if v, ok := tfschemaValue.(*schema.Set); ok {
-		return setListValue(input, index, fieldName, v.List(), debugLogger)
-	}
+	case reflect.Struct:
+		// a single nested block (e.g. `MaxItems: 1`) is represented as a list in the Schema
+		if items, ok := listItems(tfschemaValue); ok {
+			switch len(items) {
+			case 0:
+				return nil
+			case 1:
+				return setValue(field, items[0], fmt.Sprintf("%s.0", fieldPath), debugLogger)
+			default:
+				return fmt.Errorf("field %q: expected at most 1 item for a nested object but got %d", fieldPath, len(items))
+			}
+		}
+
+		return setStructValue(field, tfschemaValue, fieldPath, debugLogger)
+
+	case reflect.Slice:
+		if items, ok := listItems(tfschemaValue); ok {
+			return setListValue(field, items, fieldPath, debugLogger)
+		}
 
-	if mapConfig, ok := tfschemaValue.(map[string]interface{}); ok {
-		mapOutput := reflect.MakeMap(reflect.ValueOf(input).Elem().Field(index).Type())
+		// the Plugin SDK returns lists as `[]interface{}`, typed lists can safely be ignored
+		return nil
+
+	case reflect.Map:
+		mapConfig, ok := tfschemaValue.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("field %q: expected a map but got %T", fieldPath, tfschemaValue)
+		}
+
+		if field.Type().Key().Kind() != reflect.String {
+			return fmt.Errorf("field %q: maps must be keyed by a string but got %s", fieldPath, field.Type().Key())
+		}
+		// a Map in the Schema can only contain primitive types
+		if isNestedObjectType(field.Type().Elem()) {
+			return fmt.Errorf("field %q: maps of nested objects aren't supported", fieldPath)
+		}
+
+		mapOutput := reflect.MakeMapWithSize(field.Type(), len(mapConfig))
 		for key, val := range mapConfig {
-			mapOutput.SetMapIndex(reflect.ValueOf(key), reflect.ValueOf(val))
+			elem := reflect.New(field.Type().Elem()).Elem()
+			if err := setValue(elem, val, fmt.Sprintf("%s.%s", fieldPath, key), debugLogger); err != nil {
+				return err
+			}
+			mapOutput.SetMapIndex(reflect.ValueOf(key).Convert(field.Type().Key()), elem)
 		}
 
-		reflect.ValueOf(input).Elem().Field(index).Set(mapOutput)
+		field.Set(mapOutput)
 		return nil
+
+	case reflect.Interface:
+		field.Set(reflect.ValueOf(tfschemaValue))
+		return nil
+	}
+
+	return fmt.Errorf("field %q: unsupported type %s", fieldPath, field.Type())
+}
+
+func setStructValue(field reflect.Value, tfschemaValue interface{}, fieldPath string, debugLogger Logger) error {
+	values, ok := tfschemaValue.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("field %q: expected a nested object but got %T", fieldPath, tfschemaValue)
 	}
 
-	if v, ok := tfschemaValue.([]interface{}); ok {
-		return setListValue(input, index, fieldName, v, debugLogger)
+	for i := 0; i < field.NumField(); i++ {
+		nestedField := field.Type().Field(i)
+		debugLogger.Infof("nestedField ", nestedField)
+
+		if val, exists := nestedField.Tag.Lookup("tfschema"); exists {
+			nestedTFSchemaValue := values[val]
+			if err := setValue(field.Field(i), nestedTFSchemaValue, fmt.Sprintf("%s.%s", fieldPath, val), debugLogger); err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
 }
 
-func setListValue(input interface{}, index int, fieldName string, v []interface{}, debugLogger Logger) error {
-	switch fieldType := reflect.ValueOf(input).Elem().Field(index).Type(); fieldType {
-	case reflect.TypeOf([]string{}):
-		stringSlice := reflect.MakeSlice(reflect.TypeOf([]string{}), len(v), len(v))
-		for i, stringVal := range v {
-			stringSlice.Index(i).SetString(stringVal.(string))
-		}
-		reflect.ValueOf(input).Elem().Field(index).Set(stringSlice)
-
-	case reflect.TypeOf([]int{}):
-		iSlice := reflect.MakeSlice(reflect.TypeOf([]int{}), len(v), len(v))
-		for i, iVal := range v {
-			iSlice.Index(i).SetInt(int64(iVal.(int)))
-		}
-		reflect.ValueOf(input).Elem().Field(index).Set(iSlice)
-
-	case reflect.TypeOf([]float64{}):
-		fSlice := reflect.MakeSlice(reflect.TypeOf([]float64{}), len(v), len(v))
-		for i, fVal := range v {
-			fSlice.Index(i).SetFloat(fVal.(float64))
-		}
-		reflect.ValueOf(input).Elem().Field(index).Set(fSlice)
-
-	case reflect.TypeOf([]bool{}):
-		bSlice := reflect.MakeSlice(reflect.TypeOf([]bool{}), len(v), len(v))
-		for i, bVal := range v {
-			bSlice.Index(i).SetBool(bVal.(bool))
-		}
-		reflect.ValueOf(input).Elem().Field(index).Set(bSlice)
-
-	default:
-		valueToSet := reflect.MakeSlice(reflect.ValueOf(input).Elem().Field(index).Type(), 0, 0)
-		debugLogger.Infof("List Type", valueToSet.Type())
-
-		for _, mapVal := range v {
-			if test, ok := mapVal.(map[string]interface{}); ok && test != nil {
-				elem := reflect.New(fieldType.Elem())
-				debugLogger.Infof("element ", elem)
-				for j := 0; j < elem.Type().Elem().NumField(); j++ {
-					nestedField := elem.Type().Elem().Field(j)
-					debugLogger.Infof("nestedField ", nestedField)
-
-					if val, exists := nestedField.Tag.Lookup("tfschema"); exists {
-						nestedTFSchemaValue := test[val]
-						if err := setValue(elem.Interface(), nestedTFSchemaValue, j, fieldName, debugLogger); err != nil {
-							return err
-						}
-					}
-				}
-
-				if !elem.CanSet() {
-					elem = elem.Elem()
-				}
-
-				if valueToSet.Kind() == reflect.Ptr {
-					valueToSet.Elem().Set(reflect.Append(valueToSet.Elem(), elem))
-				} else {
-					valueToSet = reflect.Append(valueToSet, elem)
-				}
-
-				debugLogger.Infof("value to set type after changes", valueToSet.Type())
-			}
+func setListValue(field reflect.Value, items []interface{}, fieldPath string, debugLogger Logger) error {
+	elemType := field.Type().Elem()
+	valueToSet := reflect.MakeSlice(field.Type(), 0, len(items))
+	debugLogger.Infof("List Type", valueToSet.Type())
+
+	for i, item := range items {
+		// nested blocks where every field is omitted come through as nil, so aren't decoded
+		if item == nil && isNestedObjectType(elemType) {
+			continue
 		}
 
-		valueToSet = reflect.Indirect(valueToSet)
-		fieldToSet := reflect.ValueOf(input).Elem().Field(index)
-		fieldToSet.Set(reflect.Indirect(valueToSet))
+		elem := reflect.New(elemType).Elem()
+		if err := setValue(elem, item, fmt.Sprintf("%s.%d", fieldPath, i), debugLogger); err != nil {
+			return err
+		}
+
+		valueToSet = reflect.Append(valueToSet, elem)
 	}
 
+	field.Set(valueToSet)
 	return nil
 }
+
+// listItems returns the items within either a List or a Set from the Terraform Schema
+func listItems(input interface{}) ([]interface{}, bool) {
+	if v, ok := input.(*schema.Set); ok {
+		return v.List(), true
+	}
+
+	if v, ok := input.([]interface{}); ok {
+		return v, true
+	}
+
+	return nil, false
+}
+
+// isNestedObjectType returns whether the specified type represents a nested block
+func isNestedObjectType(input reflect.Type) bool {
+	if input.Kind() == reflect.Ptr {
+		input = input.Elem()
+	}
+
+	return input.Kind() == reflect.Struct && input != timeType
+}
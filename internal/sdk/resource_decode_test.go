@@ -2,7 +2,9 @@ package sdk
 
 import (
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
 type decodeTestData struct {
@@ -698,6 +700,207 @@ func TestResourceDecode_NestedThreeLevelsDeepMultipleItems(t *testing.T) {
 	}.test(t)
 }
 
+func TestResourceDecode_Pointers(t *testing.T) {
+	type Inner struct {
+		Value *string `tfschema:"value"`
+	}
+	type Type struct {
+		String       *string  `tfschema:"string"`
+		Number       *int     `tfschema:"number"`
+		Price        *float64 `tfschema:"price"`
+		Enabled      *bool    `tfschema:"enabled"`
+		Unset        *string  `tfschema:"unset"`
+		NestedObject *Inner   `tfschema:"inner"`
+		EmptyObject  *Inner   `tfschema:"empty"`
+	}
+	str := "hello"
+	number := 0
+	price := 1.5
+	enabled := false
+	value := "world"
+	decodeTestData{
+		State: map[string]interface{}{
+			"string":  "hello",
+			"number":  0,
+			"price":   1.5,
+			"enabled": false,
+			"inner": []interface{}{
+				map[string]interface{}{
+					"value": "world",
+				},
+			},
+			"empty": []interface{}{},
+		},
+		Input: &Type{},
+		Expected: &Type{
+			String:  &str,
+			Number:  &number,
+			Price:   &price,
+			Enabled: &enabled,
+			NestedObject: &Inner{
+				Value: &value,
+			},
+		},
+	}.test(t)
+}
+
+func TestResourceDecode_NestedSingleObject(t *testing.T) {
+	type Inner struct {
+		Name  string `tfschema:"name"`
+		Count int    `tfschema:"count"`
+	}
+	type Type struct {
+		NestedObject Inner `tfschema:"inner"`
+		EmptyObject  Inner `tfschema:"empty"`
+	}
+	decodeTestData{
+		State: map[string]interface{}{
+			"inner": []interface{}{
+				map[string]interface{}{
+					"name":  "bingo",
+					"count": 3,
+				},
+			},
+			"empty": []interface{}{},
+		},
+		Input: &Type{},
+		Expected: &Type{
+			NestedObject: Inner{
+				Name:  "bingo",
+				Count: 3,
+			},
+		},
+	}.test(t)
+
+	t.Log("Too Many Items")
+	decodeTestData{
+		State: map[string]interface{}{
+			"inner": []interface{}{
+				map[string]interface{}{
+					"name": "bingo",
+				},
+				map[string]interface{}{
+					"name": "bango",
+				},
+			},
+		},
+		Input:       &Type{},
+		ExpectError: true,
+	}.test(t)
+}
+
+func TestResourceDecode_NestedListOfPointers(t *testing.T) {
+	type Inner struct {
+		Name string `tfschema:"name"`
+	}
+	type Type struct {
+		NestedObjects []*Inner `tfschema:"inner"`
+	}
+	decodeTestData{
+		State: map[string]interface{}{
+			"inner": []interface{}{
+				map[string]interface{}{
+					"name": "bingo",
+				},
+				map[string]interface{}{
+					"name": "bango",
+				},
+			},
+		},
+		Input: &Type{},
+		Expected: &Type{
+			NestedObjects: []*Inner{
+				{
+					Name: "bingo",
+				},
+				{
+					Name: "bango",
+				},
+			},
+		},
+	}.test(t)
+}
+
+func TestResourceDecode_MapOfObjectsUnsupported(t *testing.T) {
+	type Inner struct {
+		Name string `tfschema:"name"`
+	}
+	type Type struct {
+		MapOfObjects map[string]Inner `tfschema:"map_of_objects"`
+	}
+	decodeTestData{
+		State: map[string]interface{}{
+			"map_of_objects": map[string]interface{}{
+				"first": map[string]interface{}{
+					"name": "bingo",
+				},
+			},
+		},
+		Input:       &Type{},
+		Expected:    &Type{},
+		ExpectError: true,
+	}.test(t)
+}
+
+func TestResourceDecode_Time(t *testing.T) {
+	type Type struct {
+		Start time.Time  `tfschema:"start"`
+		End   *time.Time `tfschema:"end"`
+		Empty time.Time  `tfschema:"empty"`
+	}
+	start := time.Date(2021, 8, 2, 10, 30, 0, 0, time.UTC)
+	end := time.Date(2021, 8, 3, 10, 30, 0, 0, time.UTC)
+	decodeTestData{
+		State: map[string]interface{}{
+			"start": "2021-08-02T10:30:00Z",
+			"end":   "2021-08-03T10:30:00Z",
+			"empty": "",
+		},
+		Input: &Type{},
+		Expected: &Type{
+			Start: start,
+			End:   &end,
+		},
+	}.test(t)
+
+	t.Log("Invalid Format")
+	decodeTestData{
+		State: map[string]interface{}{
+			"start": "02/08/2021",
+		},
+		Input:       &Type{},
+		ExpectError: true,
+	}.test(t)
+}
+
+func TestResourceDecode_ErrorContainsFieldPath(t *testing.T) {
+	type Inner struct {
+		Number int `tfschema:"number"`
+	}
+	type Type struct {
+		NestedObject []Inner `tfschema:"inner"`
+	}
+	state := testDataGetter{
+		values: map[string]interface{}{
+			"inner": []interface{}{
+				map[string]interface{}{
+					"number": 1,
+				},
+				map[string]interface{}{
+					"number": "two",
+				},
+			},
+		},
+	}
+	err := decodeReflectedType(&Type{}, state, NullLogger{})
+	if err == nil {
+		t.Fatalf("expected an error but didn't get one")
+	}
+	if !strings.Contains(err.Error(), `"inner.1.number"`) {
+		t.Fatalf("expected the error to contain the field path but got: %+v", err)
+	}
+}
+
 func (testData decodeTestData) test(t *testing.T) {
 	debugLogger := ConsoleLogger{}
 	state := testData.stateWrapper()
@@ -3,17 +3,26 @@ package sdk
 import (
 	"fmt"
 	"reflect"
+	"time"
 )
 
 // Encode will encode the specified object into the Terraform State
 // NOTE: this requires that the object passed in is a pointer and
 // all fields contain `tfschema` struct tags
+//
+// Nested structs, pointers, maps of structs, `interface{}` and `time.Time` fields are
+// supported in the same manner as by Decode - with nil pointers to primitive types being
+// unset in the State (or omitted from maps) and `time.Time` fields being output as an
+// RFC3339 string.
 func (rmd ResourceMetaData) Encode(input interface{}) error {
 	if reflect.TypeOf(input).Kind() != reflect.Ptr {
 		return fmt.Errorf("need a pointer")
 	}
 
 	objType := reflect.TypeOf(input).Elem()
+	if objType.Kind() != reflect.Struct {
+		return fmt.Errorf("need a pointer to a struct but got a pointer to %s", objType.Kind())
+	}
 	objVal := reflect.ValueOf(input).Elem()
 
 	fieldName := reflect.ValueOf(input).Elem().String()
@@ -35,107 +44,195 @@ func recurse(objType reflect.Type, objVal reflect.Value, fieldName string, debug
 	defer func() {
 		if r := recover(); r != nil {
 			debugLogger.Warnf("error setting value for %q: %+v", fieldName, r)
-			out, ok := r.(error)
-			if !ok {
-				return
-			}
-
-			errOut = out
+			errOut = fmt.Errorf("serializing %q: %+v", fieldName, r)
 		}
 	}()
 
-	output = make(map[string]interface{})
+	return encodeStruct(objType, objVal, "", debugLogger)
+}
+
+// encodeStruct encodes the fields of the struct into a map keyed by the `tfschema` tag, where
+// prefix is the path to this struct within the Schema (e.g. `block.0`) used in error messages
+func encodeStruct(objType reflect.Type, objVal reflect.Value, prefix string, debugLogger Logger) (map[string]interface{}, error) {
+	output := make(map[string]interface{})
 	for i := 0; i < objType.NumField(); i++ {
 		field := objType.Field(i)
 		fieldVal := objVal.Field(i)
 		if tfschemaTag, exists := field.Tag.Lookup("tfschema"); exists {
-			switch field.Type.Kind() {
-			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-				iv := fieldVal.Int()
-				debugLogger.Infof("Setting %q to %d", tfschemaTag, iv)
-				output[tfschemaTag] = iv
-
-			case reflect.Float32, reflect.Float64:
-				fv := fieldVal.Float()
-				debugLogger.Infof("Setting %q to %f", tfschemaTag, fv)
-				output[tfschemaTag] = fv
-
-			case reflect.String:
-				sv := fieldVal.String()
-				debugLogger.Infof("Setting %q to %q", tfschemaTag, sv)
-				output[tfschemaTag] = sv
-
-			case reflect.Bool:
-				bv := fieldVal.Bool()
-				debugLogger.Infof("Setting %q to %t", tfschemaTag, bv)
-				output[tfschemaTag] = bv
-
-			case reflect.Map:
-				iter := fieldVal.MapRange()
-				attr := make(map[string]interface{})
-				for iter.Next() {
-					attr[iter.Key().String()] = iter.Value().Interface()
-				}
-				output[tfschemaTag] = attr
-
-			case reflect.Slice:
-				sv := fieldVal.Slice(0, fieldVal.Len())
-				attr := make([]interface{}, sv.Len())
-				switch sv.Type() {
-				case reflect.TypeOf([]string{}):
-					debugLogger.Infof("Setting %q to []string", tfschemaTag)
-					if sv.Len() > 0 {
-						output[tfschemaTag] = sv.Interface()
-					} else {
-						output[tfschemaTag] = make([]string, 0)
-					}
-
-				case reflect.TypeOf([]int{}):
-					debugLogger.Infof("Setting %q to []int", tfschemaTag)
-					if sv.Len() > 0 {
-						output[tfschemaTag] = sv.Interface()
-					} else {
-						output[tfschemaTag] = make([]int, 0)
-					}
-
-				case reflect.TypeOf([]float64{}):
-					debugLogger.Infof("Setting %q to []float64", tfschemaTag)
-					if sv.Len() > 0 {
-						output[tfschemaTag] = sv.Interface()
-					} else {
-						output[tfschemaTag] = make([]float64, 0)
-					}
-
-				case reflect.TypeOf([]bool{}):
-					debugLogger.Infof("Setting %q to []bool", tfschemaTag)
-					if sv.Len() > 0 {
-						output[tfschemaTag] = sv.Interface()
-					} else {
-						output[tfschemaTag] = make([]bool, 0)
-					}
-
-				default:
-					for i := 0; i < sv.Len(); i++ {
-						debugLogger.Infof("[SLICE] Index %d is %q", i, sv.Index(i).Interface())
-						debugLogger.Infof("[SLICE] Type %+v", sv.Type())
-						nestedType := sv.Index(i).Type()
-						nestedValue := sv.Index(i)
-
-						fieldName := field.Name
-						serialized, err := recurse(nestedType, nestedValue, fieldName, debugLogger)
-						if err != nil {
-							return nil, fmt.Errorf("serializing nested object %q: %+v", sv.Type(), exists)
-						}
-						attr[i] = serialized
-					}
-					debugLogger.Infof("[SLICE] Setting %q to %+v", tfschemaTag, attr)
-					output[tfschemaTag] = attr
-				}
-			default:
-				return output, fmt.Errorf("unknown type %+v for key %q", field.Type.Kind(), tfschemaTag)
+			fieldPath := tfschemaTag
+			if prefix != "" {
+				fieldPath = fmt.Sprintf("%s.%s", prefix, tfschemaTag)
 			}
+
+			val, err := encodeValue(fieldVal, fieldPath, debugLogger)
+			if err != nil {
+				return nil, err
+			}
+
+			output[tfschemaTag] = val
 		}
 	}
 
 	return output, nil
 }
+
+func encodeValue(fieldVal reflect.Value, fieldPath string, debugLogger Logger) (interface{}, error) {
+	if fieldVal.Type() == timeType {
+		tv := fieldVal.Interface().(time.Time)
+		debugLogger.Infof("Setting %q to %s", fieldPath, tv)
+		if tv.IsZero() {
+			return "", nil
+		}
+		return tv.Format(time.RFC3339), nil
+	}
+
+	switch fieldVal.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		iv := fieldVal.Int()
+		debugLogger.Infof("Setting %q to %d", fieldPath, iv)
+		return iv, nil
+
+	case reflect.Float32, reflect.Float64:
+		fv := fieldVal.Float()
+		debugLogger.Infof("Setting %q to %f", fieldPath, fv)
+		return fv, nil
+
+	case reflect.String:
+		sv := fieldVal.String()
+		debugLogger.Infof("Setting %q to %q", fieldPath, sv)
+		return sv, nil
+
+	case reflect.Bool:
+		bv := fieldVal.Bool()
+		debugLogger.Infof("Setting %q to %t", fieldPath, bv)
+		return bv, nil
+
+	case reflect.Ptr:
+		if fieldVal.IsNil() {
+			debugLogger.Infof("Setting %q to nil", fieldPath)
+			if isNestedObjectType(fieldVal.Type()) {
+				return []interface{}{}, nil
+			}
+			return nil, nil
+		}
+
+		return encodeValue(fieldVal.Elem(), fieldPath, debugLogger)
+
+	case reflect.Struct:
+		// a single nested block (e.g. `MaxItems: 1`) is represented as a list in the Schema
+		serialized, err := encodeStruct(fieldVal.Type(), fieldVal, fmt.Sprintf("%s.0", fieldPath), debugLogger)
+		if err != nil {
+			return nil, err
+		}
+		return []interface{}{serialized}, nil
+
+	case reflect.Map:
+		if fieldVal.Type().Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("field %q: maps must be keyed by a string but got %s", fieldPath, fieldVal.Type().Key())
+		}
+
+		// a Map in the Schema can only contain primitive types
+		if isNestedObjectType(fieldVal.Type().Elem()) {
+			return nil, fmt.Errorf("field %q: maps of nested objects aren't supported", fieldPath)
+		}
+
+		attr := make(map[string]interface{})
+		iter := fieldVal.MapRange()
+		for iter.Next() {
+			key := iter.Key().String()
+			elemVal := iter.Value()
+			if elemVal.Kind() == reflect.Ptr {
+				// a map can't contain a nil value in the State, so these are omitted
+				if elemVal.IsNil() {
+					continue
+				}
+				elemVal = elemVal.Elem()
+			}
+
+			if elemVal.Type() != timeType {
+				attr[key] = elemVal.Interface()
+				continue
+			}
+
+			serialized, err := encodeValue(elemVal, fmt.Sprintf("%s.%s", fieldPath, key), debugLogger)
+			if err != nil {
+				return nil, err
+			}
+			attr[key] = serialized
+		}
+		return attr, nil
+
+	case reflect.Slice:
+		sv := fieldVal.Slice(0, fieldVal.Len())
+		switch sv.Type() {
+		case reflect.TypeOf([]string{}):
+			debugLogger.Infof("Setting %q to []string", fieldPath)
+			if sv.Len() > 0 {
+				return sv.Interface(), nil
+			}
+			return make([]string, 0), nil
+
+		case reflect.TypeOf([]int{}):
+			debugLogger.Infof("Setting %q to []int", fieldPath)
+			if sv.Len() > 0 {
+				return sv.Interface(), nil
+			}
+			return make([]int, 0), nil
+
+		case reflect.TypeOf([]float64{}):
+			debugLogger.Infof("Setting %q to []float64", fieldPath)
+			if sv.Len() > 0 {
+				return sv.Interface(), nil
+			}
+			return make([]float64, 0), nil
+
+		case reflect.TypeOf([]bool{}):
+			debugLogger.Infof("Setting %q to []bool", fieldPath)
+			if sv.Len() > 0 {
+				return sv.Interface(), nil
+			}
+			return make([]bool, 0), nil
+		}
+
+		attr := make([]interface{}, sv.Len())
+		for i := 0; i < sv.Len(); i++ {
+			debugLogger.Infof("[SLICE] Index %d is %q", i, sv.Index(i).Interface())
+			debugLogger.Infof("[SLICE] Type %+v", sv.Type())
+
+			serialized, err := encodeElement(sv.Index(i), fmt.Sprintf("%s.%d", fieldPath, i), debugLogger)
+			if err != nil {
+				return nil, err
+			}
+			attr[i] = serialized
+		}
+		debugLogger.Infof("[SLICE] Setting %q to %+v", fieldPath, attr)
+		return attr, nil
+
+	case reflect.Interface:
+		if fieldVal.IsNil() {
+			debugLogger.Infof("Setting %q to nil", fieldPath)
+			return nil, nil
+		}
+
+		return encodeValue(fieldVal.Elem(), fieldPath, debugLogger)
+	}
+
+	return nil, fmt.Errorf("field %q: unsupported type %s", fieldPath, fieldVal.Type())
+}
+
+// encodeElement encodes an item within a List, Set or Map - where nested objects
+// are output as a map rather than being wrapped in a list
+func encodeElement(elemVal reflect.Value, fieldPath string, debugLogger Logger) (interface{}, error) {
+	if elemVal.Kind() == reflect.Ptr && isNestedObjectType(elemVal.Type()) {
+		if elemVal.IsNil() {
+			return nil, nil
+		}
+		elemVal = elemVal.Elem()
+	}
+
+	if isNestedObjectType(elemVal.Type()) {
+		return encodeStruct(elemVal.Type(), elemVal, fieldPath, debugLogger)
+	}
+
+	return encodeValue(elemVal, fieldPath, debugLogger)
+}
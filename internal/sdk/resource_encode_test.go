@@ -2,7 +2,9 @@ package sdk
 
 import (
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 )
@@ -647,6 +649,206 @@ func TestResourceEncode_NestedThreeLevelsDeepMultipleItems(t *testing.T) {
 	}.test(t)
 }
 
+func TestResourceEncode_Pointers(t *testing.T) {
+	type Inner struct {
+		Value *string `tfschema:"value"`
+	}
+	type Type struct {
+		String       *string  `tfschema:"string"`
+		Number       *int     `tfschema:"number"`
+		Price        *float64 `tfschema:"price"`
+		Enabled      *bool    `tfschema:"enabled"`
+		Unset        *string  `tfschema:"unset"`
+		NestedObject *Inner   `tfschema:"inner"`
+		EmptyObject  *Inner   `tfschema:"empty"`
+	}
+	str := "hello"
+	number := 0
+	price := 1.5
+	enabled := false
+	value := "world"
+	encodeTestData{
+		Input: &Type{
+			String:  &str,
+			Number:  &number,
+			Price:   &price,
+			Enabled: &enabled,
+			NestedObject: &Inner{
+				Value: &value,
+			},
+		},
+		Expected: map[string]interface{}{
+			"string":  "hello",
+			"number":  int64(0),
+			"price":   1.5,
+			"enabled": false,
+			"unset":   nil,
+			"inner": []interface{}{
+				map[string]interface{}{
+					"value": "world",
+				},
+			},
+			"empty": []interface{}{},
+		},
+	}.test(t)
+}
+
+func TestResourceEncode_NestedSingleObject(t *testing.T) {
+	type Inner struct {
+		Name  string `tfschema:"name"`
+		Count int    `tfschema:"count"`
+	}
+	type Type struct {
+		NestedObject Inner `tfschema:"inner"`
+	}
+	encodeTestData{
+		Input: &Type{
+			NestedObject: Inner{
+				Name:  "bingo",
+				Count: 3,
+			},
+		},
+		Expected: map[string]interface{}{
+			"inner": []interface{}{
+				map[string]interface{}{
+					"name":  "bingo",
+					"count": int64(3),
+				},
+			},
+		},
+	}.test(t)
+}
+
+func TestResourceEncode_NestedListOfPointers(t *testing.T) {
+	type Inner struct {
+		Name string `tfschema:"name"`
+	}
+	type Type struct {
+		NestedObjects []*Inner `tfschema:"inner"`
+	}
+	encodeTestData{
+		Input: &Type{
+			NestedObjects: []*Inner{
+				{
+					Name: "bingo",
+				},
+				{
+					Name: "bango",
+				},
+			},
+		},
+		Expected: map[string]interface{}{
+			"inner": []interface{}{
+				map[string]interface{}{
+					"name": "bingo",
+				},
+				map[string]interface{}{
+					"name": "bango",
+				},
+			},
+		},
+	}.test(t)
+}
+
+func TestResourceEncode_MapOfObjectsUnsupported(t *testing.T) {
+	type Inner struct {
+		Name string `tfschema:"name"`
+	}
+	type Type struct {
+		MapOfObjects map[string]Inner `tfschema:"map_of_objects"`
+	}
+	encodeTestData{
+		Input: &Type{
+			MapOfObjects: map[string]Inner{
+				"first": {
+					Name: "bingo",
+				},
+			},
+		},
+		ExpectError: true,
+	}.test(t)
+}
+
+func TestResourceEncode_MapOfPointers(t *testing.T) {
+	type Type struct {
+		Tags map[string]*string `tfschema:"tags"`
+	}
+	value := "world"
+	encodeTestData{
+		Input: &Type{
+			Tags: map[string]*string{
+				"hello": &value,
+				"unset": nil,
+			},
+		},
+		Expected: map[string]interface{}{
+			"tags": map[string]interface{}{
+				"hello": "world",
+			},
+		},
+	}.test(t)
+}
+
+func TestResourceEncode_Interface(t *testing.T) {
+	type Type struct {
+		Value interface{} `tfschema:"value"`
+		List  interface{} `tfschema:"list"`
+		Unset interface{} `tfschema:"unset"`
+	}
+	encodeTestData{
+		Input: &Type{
+			Value: "hello",
+			List:  []interface{}{"first", "second"},
+		},
+		Expected: map[string]interface{}{
+			"value": "hello",
+			"list":  []interface{}{"first", "second"},
+			"unset": nil,
+		},
+	}.test(t)
+}
+
+func TestResourceEncode_Time(t *testing.T) {
+	type Type struct {
+		Start time.Time  `tfschema:"start"`
+		End   *time.Time `tfschema:"end"`
+		Empty time.Time  `tfschema:"empty"`
+	}
+	end := time.Date(2021, 8, 3, 10, 30, 0, 0, time.UTC)
+	encodeTestData{
+		Input: &Type{
+			Start: time.Date(2021, 8, 2, 10, 30, 0, 0, time.UTC),
+			End:   &end,
+		},
+		Expected: map[string]interface{}{
+			"start": "2021-08-02T10:30:00Z",
+			"end":   "2021-08-03T10:30:00Z",
+			"empty": "",
+		},
+	}.test(t)
+}
+
+func TestResourceEncode_UnsupportedTypeContainsFieldPath(t *testing.T) {
+	type Inner struct {
+		Channel chan string `tfschema:"channel"`
+	}
+	type Type struct {
+		NestedObject []Inner `tfschema:"inner"`
+	}
+	input := &Type{
+		NestedObject: []Inner{
+			{},
+		},
+	}
+	_, err := recurse(reflect.TypeOf(input).Elem(), reflect.ValueOf(input).Elem(), "Type", NullLogger{})
+	if err == nil {
+		t.Fatalf("expected an error but didn't get one")
+	}
+	if !strings.Contains(err.Error(), `"inner.0.channel"`) {
+		t.Fatalf("expected the error to contain the field path but got: %+v", err)
+	}
+}
+
 func (testData encodeTestData) test(t *testing.T) {
 	objType := reflect.TypeOf(testData.Input).Elem()
 	objVal := reflect.ValueOf(testData.Input).Elem()
@@ -21,6 +21,23 @@ type TypedServiceRegistration interface {
 	WebsiteCategories() []string
 }
 
+// TypedServiceRegistrationWithListDataSources is an optional interface for a
+// TypedServiceRegistration which also exposes List Data Sources
+type TypedServiceRegistrationWithListDataSources interface {
+	TypedServiceRegistration
+
+	// ListDataSources returns a list of List Data Sources supported by this Service
+	ListDataSources() []ListDataSource
+}
+
+// ServiceRegistrationWithResourceProviders is an optional interface for a Service Registration
+// (either Typed or Untyped) which declares the Resource Providers used by the Service, which
+// are registered on-demand when Selective Resource Provider Registration is enabled
+type ServiceRegistrationWithResourceProviders interface {
+	// ResourceProviders returns a list of the Resource Providers used by this Service
+	ResourceProviders() []string
+}
+
 // UntypedServiceRegistration is the interface used for untyped/raw Plugin SDK resources
 // in the future this'll be superseded by the TypedServiceRegistration which allows for
 // stronger Typed resources to be used.
@@ -0,0 +1,98 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/resourceid"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+// StateUpgradeData defines the current Schema Version for a Resource along with
+// the State Upgraders required to migrate older versions of the state
+type StateUpgradeData struct {
+	// SchemaVersion is the current version of the Schema for this Resource
+	SchemaVersion int
+
+	// Upgraders is a map of the Schema Version to the StateUpgrade which upgrades
+	// the state from that version to the next one, e.g. `0` upgrades from v0 to v1
+	Upgraders map[int]pluginsdk.StateUpgrade
+}
+
+func (d StateUpgradeData) validate() error {
+	if d.SchemaVersion < 0 {
+		return fmt.Errorf("the SchemaVersion must be zero or greater but got %d", d.SchemaVersion)
+	}
+
+	if len(d.Upgraders) != d.SchemaVersion {
+		return fmt.Errorf("expected %d State Upgraders for Schema Version %d but got %d", d.SchemaVersion, d.SchemaVersion, len(d.Upgraders))
+	}
+
+	for i := 0; i < d.SchemaVersion; i++ {
+		upgrader, ok := d.Upgraders[i]
+		if !ok {
+			return fmt.Errorf("missing a State Upgrader for version %d", i)
+		}
+		if upgrader == nil {
+			return fmt.Errorf("the State Upgrader for version %d was nil", i)
+		}
+	}
+
+	return nil
+}
+
+// ResourceIDParserFunc parses the specified Resource ID into a Formatter which
+// can be used to output the Resource ID in the canonical casing
+//
+// NOTE: since this is used to fix the casing of existing Resource ID's, this
+// function should parse the Resource ID in a case-insensitive manner
+type ResourceIDParserFunc func(input string) (resourceid.Formatter, error)
+
+var _ pluginsdk.StateUpgrade = idCasingStateUpgrade{}
+
+type idCasingStateUpgrade struct {
+	schema map[string]*pluginsdk.Schema
+	parser ResourceIDParserFunc
+}
+
+// NewIDCasingStateUpgrade returns a generic StateUpgrade which rewrites the Resource ID
+// stored in the state into the canonical casing, for example
+//
+//	/subscriptions/{subscriptionId}/resourcegroups/{resourceGroupName}/providers/Microsoft.Cdn/profiles/{profileName}
+//
+// becomes:
+//
+//	/subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}/providers/Microsoft.Cdn/profiles/{profileName}
+//
+// The schema is a point-in-time reference to the Schema for the version being upgraded.
+func NewIDCasingStateUpgrade(schema map[string]*pluginsdk.Schema, parser ResourceIDParserFunc) pluginsdk.StateUpgrade {
+	return idCasingStateUpgrade{
+		schema: schema,
+		parser: parser,
+	}
+}
+
+func (u idCasingStateUpgrade) Schema() map[string]*pluginsdk.Schema {
+	return u.schema
+}
+
+func (u idCasingStateUpgrade) UpgradeFunc() pluginsdk.StateUpgraderFunc {
+	return func(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+		oldId, ok := rawState["id"].(string)
+		if !ok || oldId == "" {
+			return rawState, fmt.Errorf("`id` was not found in the state")
+		}
+
+		id, err := u.parser(oldId)
+		if err != nil {
+			return rawState, fmt.Errorf("parsing %q: %+v", oldId, err)
+		}
+
+		newId := id.ID()
+		log.Printf("[DEBUG] Updating ID from %q to %q", oldId, newId)
+		rawState["id"] = newId
+
+		return rawState, nil
+	}
+}
@@ -0,0 +1,146 @@
+package sdk
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/migration"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/resourceid"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+type stateMigrationTestId struct {
+	SubscriptionId string
+	ResourceGroup  string
+	Name           string
+}
+
+func (id stateMigrationTestId) ID() string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Example/things/%s", id.SubscriptionId, id.ResourceGroup, id.Name)
+}
+
+func parseStateMigrationTestIdInsensitively(input string) (resourceid.Formatter, error) {
+	segments := strings.Split(strings.TrimPrefix(input, "/"), "/")
+	if len(segments) != 8 || !strings.EqualFold(segments[0], "subscriptions") || !strings.EqualFold(segments[2], "resourceGroups") || !strings.EqualFold(segments[6], "things") {
+		return nil, fmt.Errorf("unexpected format for ID %q", input)
+	}
+
+	return stateMigrationTestId{
+		SubscriptionId: segments[1],
+		ResourceGroup:  segments[3],
+		Name:           segments[7],
+	}, nil
+}
+
+func stateMigrationTestSchema() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+		},
+	}
+}
+
+func TestIDCasingStateUpgrade(t *testing.T) {
+	upgrade := NewIDCasingStateUpgrade(stateMigrationTestSchema(), parseStateMigrationTestIdInsensitively)
+	migration.TestStateUpgrade(t, upgrade, []migration.StateUpgradeTestData{
+		{
+			Name: "missing id",
+			Input: map[string]interface{}{
+				"name": "thing1",
+			},
+			ExpectError: true,
+		},
+		{
+			Name: "invalid id",
+			Input: map[string]interface{}{
+				"id":   "/subscriptions/12345678-1234-5678-1234-123456789012/resourceGroups/group1",
+				"name": "thing1",
+			},
+			ExpectError: true,
+		},
+		{
+			Name: "old id",
+			Input: map[string]interface{}{
+				"id":   "/subscriptions/12345678-1234-5678-1234-123456789012/resourcegroups/group1/providers/Microsoft.Example/Things/thing1",
+				"name": "thing1",
+			},
+			Expected: map[string]interface{}{
+				"id":   "/subscriptions/12345678-1234-5678-1234-123456789012/resourceGroups/group1/providers/Microsoft.Example/things/thing1",
+				"name": "thing1",
+			},
+		},
+		{
+			Name: "new id",
+			Input: map[string]interface{}{
+				"id":   "/subscriptions/12345678-1234-5678-1234-123456789012/resourceGroups/group1/providers/Microsoft.Example/things/thing1",
+				"name": "thing1",
+			},
+			Expected: map[string]interface{}{
+				"id":   "/subscriptions/12345678-1234-5678-1234-123456789012/resourceGroups/group1/providers/Microsoft.Example/things/thing1",
+				"name": "thing1",
+			},
+		},
+	})
+}
+
+func TestStateUpgradeDataValidate(t *testing.T) {
+	upgrade := NewIDCasingStateUpgrade(stateMigrationTestSchema(), parseStateMigrationTestIdInsensitively)
+	testData := []struct {
+		name        string
+		input       StateUpgradeData
+		expectError bool
+	}{
+		{
+			name: "no upgrades",
+			input: StateUpgradeData{
+				SchemaVersion: 0,
+			},
+			expectError: false,
+		},
+		{
+			name: "single upgrade",
+			input: StateUpgradeData{
+				SchemaVersion: 1,
+				Upgraders: map[int]pluginsdk.StateUpgrade{
+					0: upgrade,
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "missing upgrade",
+			input: StateUpgradeData{
+				SchemaVersion: 2,
+				Upgraders: map[int]pluginsdk.StateUpgrade{
+					0: upgrade,
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "gap in upgrades",
+			input: StateUpgradeData{
+				SchemaVersion: 2,
+				Upgraders: map[int]pluginsdk.StateUpgrade{
+					0: upgrade,
+					2: upgrade,
+				},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		err := v.input.validate()
+		if v.expectError && err == nil {
+			t.Fatalf("expected an error but didn't get one")
+		}
+		if !v.expectError && err != nil {
+			t.Fatalf("expected no error but got: %+v", err)
+		}
+	}
+}
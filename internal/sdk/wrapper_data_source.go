@@ -35,6 +35,10 @@ func (dw *DataSourceWrapper) DataSource() (*schema.Resource, error) {
 		if err := ValidateModelObject(&modelObj); err != nil {
 			return nil, fmt.Errorf("validating model for %q: %+v", dw.dataSource.ResourceType(), err)
 		}
+
+		if err := ValidateModelObjectMatchesSchema(modelObj, *resourceSchema); err != nil {
+			return nil, fmt.Errorf("validating model for %q matches the schema: %+v", dw.dataSource.ResourceType(), err)
+		}
 	}
 
 	d := func(duration time.Duration) *time.Duration {
@@ -0,0 +1,203 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+const (
+	listFilterNameRegex     = "name_regex"
+	listFilterResourceGroup = "resource_group_name"
+	listFilterRequiredTags  = "required_tags"
+)
+
+// ListDataSourceWrapper is a wrapper for converting a ListDataSource implementation
+// into the object used by the Terraform Plugin SDK
+type ListDataSourceWrapper struct {
+	dataSource ListDataSource
+	logger     Logger
+}
+
+// NewListDataSourceWrapper returns a ListDataSourceWrapper for this List Data Source implementation
+func NewListDataSourceWrapper(dataSource ListDataSource) ListDataSourceWrapper {
+	return ListDataSourceWrapper{
+		dataSource: dataSource,
+		logger:     &DiagnosticsLogger{},
+	}
+}
+
+// DataSource returns the Terraform Plugin SDK type for this ListDataSource implementation
+func (lw *ListDataSourceWrapper) DataSource() (*schema.Resource, error) {
+	itemsAttributeName := lw.dataSource.ItemsAttributeName()
+	if itemsAttributeName == "" {
+		return nil, fmt.Errorf("ItemsAttributeName for %q must be a non-empty string", lw.dataSource.ResourceType())
+	}
+
+	itemSchema, err := combineSchema(map[string]*schema.Schema{}, lw.dataSource.ItemAttributes())
+	if err != nil {
+		return nil, fmt.Errorf("building Item Schema: %+v", err)
+	}
+
+	modelObj := lw.dataSource.ItemModelObject()
+	if modelObj == nil {
+		return nil, fmt.Errorf("ItemModelObject for %q must be non-nil", lw.dataSource.ResourceType())
+	}
+	if err := ValidateModelObject(&modelObj); err != nil {
+		return nil, fmt.Errorf("validating item model for %q: %+v", lw.dataSource.ResourceType(), err)
+	}
+	if err := ValidateModelObjectMatchesSchema(modelObj, *itemSchema); err != nil {
+		return nil, fmt.Errorf("validating item model for %q matches the schema: %+v", lw.dataSource.ResourceType(), err)
+	}
+
+	attributes := map[string]*schema.Schema{
+		itemsAttributeName: {
+			Type: schema.TypeList,
+			Elem: &schema.Resource{
+				Schema: *itemSchema,
+			},
+		},
+	}
+	resourceSchema, err := combineSchema(listDataSourceArguments(lw.dataSource.Arguments()), attributes)
+	if err != nil {
+		return nil, fmt.Errorf("building Schema: %+v", err)
+	}
+
+	d := func(duration time.Duration) *time.Duration {
+		return &duration
+	}
+
+	resource := schema.Resource{
+		Schema: *resourceSchema,
+		ReadContext: lw.diagnosticsWrapper(func(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+			metaData := runArgs(d, meta, lw.logger)
+			return lw.read(ctx, metaData)
+		}),
+		Timeouts: &schema.ResourceTimeout{
+			Read: d(lw.dataSource.List().Timeout),
+		},
+	}
+
+	return &resource, nil
+}
+
+func (lw *ListDataSourceWrapper) read(ctx context.Context, metadata ResourceMetaData) error {
+	filter, err := expandListFilter(metadata.ResourceData)
+	if err != nil {
+		return err
+	}
+
+	page, err := lw.dataSource.List().Func(ctx, metadata, *filter)
+	if err != nil {
+		return err
+	}
+
+	modelType := reflect.Indirect(reflect.ValueOf(lw.dataSource.ItemModelObject())).Type()
+	items := make([]interface{}, 0)
+	for page != nil && page.NotDone() {
+		pageItems, err := page.Items()
+		if err != nil {
+			return fmt.Errorf("retrieving items: %+v", err)
+		}
+
+		for _, item := range pageItems {
+			if !filter.Matches(item) {
+				metadata.Logger.Infof("[DEBUG] %s - %q skipped since it doesn't match the filters", lw.dataSource.ResourceType(), item.Name)
+				continue
+			}
+
+			serialized, err := encodeListItem(item, modelType, metadata.serializationDebugLogger)
+			if err != nil {
+				return fmt.Errorf("encoding %q: %+v", item.Name, err)
+			}
+			items = append(items, serialized)
+		}
+
+		if err := page.NextWithContext(ctx); err != nil {
+			return fmt.Errorf("retrieving the next page of results: %+v", err)
+		}
+	}
+
+	metadata.ResourceData.SetId(fmt.Sprintf("%s-%s", lw.dataSource.ResourceType(), uuid.New().String()))
+	// lintignore:R001
+	if err := metadata.ResourceData.Set(lw.dataSource.ItemsAttributeName(), items); err != nil {
+		return fmt.Errorf("setting `%s`: %+v", lw.dataSource.ItemsAttributeName(), err)
+	}
+
+	return nil
+}
+
+func (lw *ListDataSourceWrapper) diagnosticsWrapper(in func(ctx context.Context, d *schema.ResourceData, meta interface{}) error) schema.ReadContextFunc {
+	return diagnosticsWrapper(in, lw.logger)
+}
+
+// listDataSourceArguments combines the additional arguments for this ListDataSource with the common filters
+func listDataSourceArguments(input map[string]*schema.Schema) map[string]*schema.Schema {
+	out := map[string]*schema.Schema{
+		listFilterNameRegex: {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringIsValidRegExp,
+		},
+
+		listFilterResourceGroup: {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		listFilterRequiredTags: {
+			Type:     schema.TypeMap,
+			Optional: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+	}
+
+	for k, v := range input {
+		out[k] = v
+	}
+
+	return out
+}
+
+func expandListFilter(d *schema.ResourceData) (*ListFilter, error) {
+	filter := ListFilter{
+		ResourceGroup: d.Get(listFilterResourceGroup).(string),
+		RequiredTags:  make(map[string]string),
+	}
+
+	if v := d.Get(listFilterNameRegex).(string); v != "" {
+		nameRegex, err := regexp.Compile(v)
+		if err != nil {
+			return nil, fmt.Errorf("compiling `%s`: %+v", listFilterNameRegex, err)
+		}
+		filter.NameRegex = nameRegex
+	}
+
+	for k, v := range d.Get(listFilterRequiredTags).(map[string]interface{}) {
+		filter.RequiredTags[k] = v.(string)
+	}
+
+	return &filter, nil
+}
+
+func encodeListItem(item ListItem, modelType reflect.Type, debugLogger Logger) (map[string]interface{}, error) {
+	if item.Model == nil {
+		return nil, fmt.Errorf("the Model for this item was nil")
+	}
+
+	modelVal := reflect.Indirect(reflect.ValueOf(item.Model))
+	if modelVal.Type() != modelType {
+		return nil, fmt.Errorf("expected the Model to be a %s but got %s", modelType, modelVal.Type())
+	}
+
+	return recurse(modelType, modelVal, item.Name, debugLogger)
+}
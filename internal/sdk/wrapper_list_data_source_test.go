@@ -0,0 +1,244 @@
+package sdk
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type listDataSourceModel struct {
+	Name          string            `tfschema:"name"`
+	ResourceGroup string            `tfschema:"resource_group_name"`
+	Tags          map[string]string `tfschema:"tags"`
+}
+
+type listDataSourcePage struct {
+	pages [][]ListItem
+	index int
+}
+
+func (p *listDataSourcePage) Items() ([]ListItem, error) {
+	return p.pages[p.index], nil
+}
+
+func (p *listDataSourcePage) NotDone() bool {
+	return p.index < len(p.pages)
+}
+
+func (p *listDataSourcePage) NextWithContext(_ context.Context) error {
+	p.index++
+	return nil
+}
+
+type listDataSource struct {
+	pages [][]ListItem
+}
+
+func (l listDataSource) Arguments() map[string]*schema.Schema {
+	return map[string]*schema.Schema{}
+}
+
+func (l listDataSource) ItemAttributes() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"resource_group_name": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"tags": {
+			Type:     schema.TypeMap,
+			Computed: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+	}
+}
+
+func (l listDataSource) ItemModelObject() interface{} {
+	return &listDataSourceModel{}
+}
+
+func (l listDataSource) ItemsAttributeName() string {
+	return "things"
+}
+
+func (l listDataSource) ResourceType() string {
+	return "validator_things"
+}
+
+func (l listDataSource) List() ListFunc {
+	return ListFunc{
+		Func: func(ctx context.Context, metadata ResourceMetaData, filter ListFilter) (ListPage, error) {
+			return &listDataSourcePage{
+				pages: l.pages,
+			}, nil
+		},
+		Timeout: time.Minute,
+	}
+}
+
+func listDataSourceItem(name, resourceGroup string, tags map[string]string) ListItem {
+	return ListItem{
+		Name:          name,
+		ResourceGroup: resourceGroup,
+		Tags:          tags,
+		Model: listDataSourceModel{
+			Name:          name,
+			ResourceGroup: resourceGroup,
+			Tags:          tags,
+		},
+	}
+}
+
+func TestListDataSourceWrapper(t *testing.T) {
+	dataSource := listDataSource{
+		pages: [][]ListItem{
+			{
+				listDataSourceItem("first", "group1", map[string]string{"env": "prod"}),
+				listDataSourceItem("second", "group1", map[string]string{"env": "test"}),
+			},
+			{
+				listDataSourceItem("third", "Group2", map[string]string{"env": "prod"}),
+				listDataSourceItem("fourth", "group2", map[string]string{}),
+			},
+		},
+	}
+
+	testData := []struct {
+		name     string
+		config   map[string]interface{}
+		expected []string
+	}{
+		{
+			name:     "no filters",
+			config:   map[string]interface{}{},
+			expected: []string{"first", "second", "third", "fourth"},
+		},
+		{
+			name: "name regex",
+			config: map[string]interface{}{
+				"name_regex": "^(first|fourth)$",
+			},
+			expected: []string{"first", "fourth"},
+		},
+		{
+			name: "resource group",
+			config: map[string]interface{}{
+				"resource_group_name": "group2",
+			},
+			expected: []string{"third", "fourth"},
+		},
+		{
+			name: "required tags",
+			config: map[string]interface{}{
+				"required_tags": map[string]interface{}{
+					"env": "prod",
+				},
+			},
+			expected: []string{"first", "third"},
+		},
+		{
+			name: "combined",
+			config: map[string]interface{}{
+				"resource_group_name": "group1",
+				"required_tags": map[string]interface{}{
+					"env": "prod",
+				},
+			},
+			expected: []string{"first"},
+		},
+	}
+
+	wrapper := NewListDataSourceWrapper(dataSource)
+	resource, err := wrapper.DataSource()
+	if err != nil {
+		t.Fatalf("building Data Source: %+v", err)
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		d := schema.TestResourceDataRaw(t, resource.Schema, v.config)
+		metadata := ResourceMetaData{
+			Logger:                   NullLogger{},
+			ResourceData:             d,
+			serializationDebugLogger: NullLogger{},
+		}
+		if err := wrapper.read(context.TODO(), metadata); err != nil {
+			t.Fatalf("reading: %+v", err)
+		}
+
+		if d.Id() == "" {
+			t.Fatalf("expected an ID to be set but it wasn't")
+		}
+
+		actual := make([]string, 0)
+		for _, item := range d.Get("things").([]interface{}) {
+			actual = append(actual, item.(map[string]interface{})["name"].(string))
+		}
+		if !reflect.DeepEqual(v.expected, actual) {
+			t.Fatalf("expected %+v but got %+v", v.expected, actual)
+		}
+	}
+}
+
+func TestListDataSourceWrapperInvalidModel(t *testing.T) {
+	dataSource := listDataSource{
+		pages: [][]ListItem{
+			{
+				{
+					Name:  "first",
+					Model: "not-a-model",
+				},
+			},
+		},
+	}
+
+	wrapper := NewListDataSourceWrapper(dataSource)
+	resource, err := wrapper.DataSource()
+	if err != nil {
+		t.Fatalf("building Data Source: %+v", err)
+	}
+
+	metadata := ResourceMetaData{
+		Logger:                   NullLogger{},
+		ResourceData:             schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{}),
+		serializationDebugLogger: NullLogger{},
+	}
+	if err := wrapper.read(context.TODO(), metadata); err == nil {
+		t.Fatalf("expected an error but didn't get one")
+	}
+}
+
+func TestStaticListPage(t *testing.T) {
+	page := NewStaticListPage([]ListItem{
+		listDataSourceItem("first", "group1", nil),
+	})
+
+	pages := 0
+	for page.NotDone() {
+		items, err := page.Items()
+		if err != nil {
+			t.Fatalf("retrieving items: %+v", err)
+		}
+		if len(items) != 1 {
+			t.Fatalf("expected 1 item but got %d", len(items))
+		}
+		pages++
+
+		if err := page.NextWithContext(context.TODO()); err != nil {
+			t.Fatalf("retrieving next page: %+v", err)
+		}
+	}
+
+	if pages != 1 {
+		t.Fatalf("expected 1 page but got %d", pages)
+	}
+}
@@ -37,6 +37,10 @@ func (rw *ResourceWrapper) Resource() (*schema.Resource, error) {
 		if err := ValidateModelObject(&modelObj); err != nil {
 			return nil, fmt.Errorf("validating model for %q: %+v", rw.resource.ResourceType(), err)
 		}
+
+		if err := ValidateModelObjectMatchesSchema(modelObj, *resourceSchema); err != nil {
+			return nil, fmt.Errorf("validating model for %q matches the schema: %+v", rw.resource.ResourceType(), err)
+		}
 	}
 
 	d := func(duration time.Duration) *time.Duration {
@@ -133,8 +137,29 @@ func (rw *ResourceWrapper) Resource() (*schema.Resource, error) {
 		resource.DeprecationMessage = message
 	}
 
-	// TODO: CustomizeDiff
-	// TODO: State Migrations
+	if v, ok := rw.resource.(ResourceWithCustomizeDiff); ok {
+		customizeDiff := v.CustomizeDiff()
+		resource.CustomizeDiff = func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+			if customizeDiff.Timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, customizeDiff.Timeout)
+				defer cancel()
+			}
+
+			metaData := runArgsDiff(d, meta, rw.logger)
+			return customizeDiff.Func(ctx, metaData)
+		}
+	}
+
+	if v, ok := rw.resource.(ResourceWithStateMigration); ok {
+		upgradeData := v.StateUpgraders()
+		if err := upgradeData.validate(); err != nil {
+			return nil, fmt.Errorf("validating State Upgraders for %q: %+v", rw.resource.ResourceType(), err)
+		}
+
+		resource.SchemaVersion = upgradeData.SchemaVersion
+		resource.StateUpgraders = pluginsdk.StateUpgrades(upgradeData.Upgraders)
+	}
 
 	return &resource, nil
 }
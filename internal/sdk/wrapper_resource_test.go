@@ -0,0 +1,161 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+type customizeDiffModel struct {
+	Name string `tfschema:"name"`
+	Sku  string `tfschema:"sku"`
+}
+
+type customizeDiffResource struct{}
+
+func (r customizeDiffResource) Arguments() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"sku": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+	}
+}
+
+func (r customizeDiffResource) Attributes() map[string]*schema.Schema {
+	return map[string]*schema.Schema{}
+}
+
+func (r customizeDiffResource) ModelObject() interface{} {
+	return &customizeDiffModel{}
+}
+
+func (r customizeDiffResource) ResourceType() string {
+	return "validator_customize_diff"
+}
+
+func (r customizeDiffResource) Create() ResourceFunc {
+	return ResourceFunc{Func: noopFunc, Timeout: time.Minute}
+}
+
+func (r customizeDiffResource) Read() ResourceFunc {
+	return ResourceFunc{Func: noopFunc, Timeout: time.Minute}
+}
+
+func (r customizeDiffResource) Delete() ResourceFunc {
+	return ResourceFunc{Func: noopFunc, Timeout: time.Minute}
+}
+
+func (r customizeDiffResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return nil
+}
+
+func (r customizeDiffResource) CustomizeDiff() ResourceFunc {
+	return ResourceFunc{
+		Func: func(ctx context.Context, metadata ResourceMetaData) error {
+			if metadata.ResourceDiff == nil {
+				return fmt.Errorf("expected the ResourceDiff to be set")
+			}
+
+			var model customizeDiffModel
+			if err := metadata.Decode(&model); err != nil {
+				return err
+			}
+
+			if model.Sku == "Basic" {
+				return fmt.Errorf("%q cannot use the Basic SKU", model.Name)
+			}
+
+			return nil
+		},
+		Timeout: time.Minute,
+	}
+}
+
+func noopFunc(_ context.Context, _ ResourceMetaData) error {
+	return nil
+}
+
+func TestResourceWrapperCustomizeDiff(t *testing.T) {
+	testData := []struct {
+		config      map[string]interface{}
+		expectError bool
+	}{
+		{
+			config: map[string]interface{}{
+				"name": "example",
+				"sku":  "Standard",
+			},
+			expectError: false,
+		},
+		{
+			config: map[string]interface{}{
+				"name": "example",
+				"sku":  "Basic",
+			},
+			expectError: true,
+		},
+	}
+
+	wrapper := NewResourceWrapper(customizeDiffResource{})
+	resource, err := wrapper.Resource()
+	if err != nil {
+		t.Fatalf("building Resource: %+v", err)
+	}
+	if resource.CustomizeDiff == nil {
+		t.Fatalf("expected CustomizeDiff to be set but it wasn't")
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %+v", v.config)
+
+		_, err := resource.SimpleDiff(context.TODO(), nil, terraform.NewResourceConfigRaw(v.config), &clients.Client{})
+		if v.expectError && err == nil {
+			t.Fatalf("expected an error but didn't get one")
+		}
+		if !v.expectError && err != nil {
+			t.Fatalf("expected no error but got: %+v", err)
+		}
+	}
+}
+
+type stateMigrationResource struct {
+	customizeDiffResource
+}
+
+func (r stateMigrationResource) StateUpgraders() StateUpgradeData {
+	return StateUpgradeData{
+		SchemaVersion: 1,
+		Upgraders: map[int]pluginsdk.StateUpgrade{
+			0: NewIDCasingStateUpgrade(stateMigrationTestSchema(), parseStateMigrationTestIdInsensitively),
+		},
+	}
+}
+
+func TestResourceWrapperStateMigration(t *testing.T) {
+	wrapper := NewResourceWrapper(stateMigrationResource{})
+	resource, err := wrapper.Resource()
+	if err != nil {
+		t.Fatalf("building Resource: %+v", err)
+	}
+
+	if resource.SchemaVersion != 1 {
+		t.Fatalf("expected the SchemaVersion to be 1 but got %d", resource.SchemaVersion)
+	}
+	if len(resource.StateUpgraders) != 1 {
+		t.Fatalf("expected 1 State Upgrader but got %d", len(resource.StateUpgraders))
+	}
+	if resource.StateUpgraders[0].Version != 0 {
+		t.Fatalf("expected the State Upgrader to be for version 0 but got %d", resource.StateUpgraders[0].Version)
+	}
+}
@@ -3,7 +3,11 @@ package sdk
 import (
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 // ValidateModelObject validates that the object contains the specified `tfschema` tags
@@ -13,8 +17,6 @@ func ValidateModelObject(input interface{}) error {
 		return fmt.Errorf("need a pointer")
 	}
 
-	// TODO: could we also validate that each `tfschema` tag exists in the schema?
-
 	objType := reflect.TypeOf(input).Elem()
 	objVal := reflect.ValueOf(input).Elem()
 	return validateModelObjectRecursively("", objType, objVal)
@@ -35,22 +37,116 @@ func validateModelObjectRecursively(prefix string, objType reflect.Type, objVal
 	for i := 0; i < objType.NumField(); i++ {
 		field := objType.Field(i)
 		fieldVal := objVal.Field(i)
+		fieldName := strings.TrimPrefix(fmt.Sprintf("%s.%s", prefix, field.Name), ".")
 
-		if field.Type.Kind() == reflect.Slice {
+		if innerType, ok := nestedModelObjectType(field.Type); ok {
+			innerVal := reflect.Indirect(reflect.New(innerType))
+			if err := validateModelObjectRecursively(fieldName, innerType, innerVal); err != nil {
+				return err
+			}
+		} else if field.Type.Kind() == reflect.Slice {
 			sv := fieldVal.Slice(0, fieldVal.Len())
 			innerType := sv.Type().Elem()
 			innerVal := reflect.Indirect(reflect.New(innerType))
-			fieldName := strings.TrimPrefix(fmt.Sprintf("%s.%s", prefix, field.Name), ".")
 			if err := validateModelObjectRecursively(fieldName, innerType, innerVal); err != nil {
 				return err
 			}
 		}
 
 		if _, exists := field.Tag.Lookup("tfschema"); !exists {
-			fieldName := strings.TrimPrefix(fmt.Sprintf("%s.%s", prefix, field.Name), ".")
 			return fmt.Errorf("field %q is missing an `tfschema` label", fieldName)
 		}
 	}
 
 	return nil
 }
+
+// nestedModelObjectType returns the struct type used for a nested block, which can be
+// a struct, a pointer to a struct, or a slice/map of either of those
+func nestedModelObjectType(input reflect.Type) (reflect.Type, bool) {
+	switch input.Kind() {
+	case reflect.Slice, reflect.Map:
+		input = input.Elem()
+	}
+
+	if isNestedObjectType(input) {
+		if input.Kind() == reflect.Ptr {
+			input = input.Elem()
+		}
+		return input, true
+	}
+
+	return nil, false
+}
+
+// ValidateModelObjectMatchesSchema validates that each `tfschema` tag within the model object
+// exists within the Schema, and that each field within the Schema is present in the model
+// object, recursing into nested blocks - returning an error naming each field which is missing
+func ValidateModelObjectMatchesSchema(model interface{}, fields map[string]*schema.Schema) error {
+	if model == nil {
+		return fmt.Errorf("model was nil")
+	}
+
+	objType := reflect.TypeOf(model)
+	for objType.Kind() == reflect.Ptr {
+		objType = objType.Elem()
+	}
+	if objType.Kind() != reflect.Struct {
+		return fmt.Errorf("expected the model to be a struct but got %s", objType.Kind())
+	}
+
+	var errs *multierror.Error
+	for _, err := range validateModelObjectMatchesSchemaRecursively("", objType, fields) {
+		errs = multierror.Append(errs, err)
+	}
+	return errs.ErrorOrNil()
+}
+
+func validateModelObjectMatchesSchemaRecursively(prefix string, objType reflect.Type, fields map[string]*schema.Schema) []error {
+	errs := make([]error, 0)
+	fieldPath := func(name string) string {
+		return strings.TrimPrefix(fmt.Sprintf("%s.%s", prefix, name), ".")
+	}
+
+	tagsInModel := make(map[string]struct{})
+	for i := 0; i < objType.NumField(); i++ {
+		field := objType.Field(i)
+		tag, exists := field.Tag.Lookup("tfschema")
+		if !exists {
+			continue
+		}
+		tagsInModel[tag] = struct{}{}
+
+		item, existsInSchema := fields[tag]
+		if !existsInSchema {
+			errs = append(errs, fmt.Errorf("field %q (%s) exists in the model but not in the schema", fieldPath(tag), field.Name))
+			continue
+		}
+
+		nestedSchema, isNestedBlock := item.Elem.(*schema.Resource)
+		innerType, isNestedModel := nestedModelObjectType(field.Type)
+		switch {
+		case isNestedBlock && isNestedModel:
+			errs = append(errs, validateModelObjectMatchesSchemaRecursively(fieldPath(tag), innerType, nestedSchema.Schema)...)
+
+		case isNestedBlock:
+			errs = append(errs, fmt.Errorf("field %q is a nested block in the schema but %s in the model", fieldPath(tag), field.Type))
+
+		case isNestedModel:
+			errs = append(errs, fmt.Errorf("field %q is a nested object in the model but not a nested block in the schema", fieldPath(tag)))
+		}
+	}
+
+	keys := make([]string, 0)
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if _, exists := tagsInModel[key]; !exists {
+			errs = append(errs, fmt.Errorf("field %q exists in the schema but not in the model", fieldPath(key)))
+		}
+	}
+
+	return errs
+}
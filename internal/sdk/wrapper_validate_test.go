@@ -1,6 +1,10 @@
 package sdk
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
 
 func TestValidateTopLevelObjectValid(t *testing.T) {
 	type Person struct {
@@ -56,3 +60,154 @@ func TestValidateNestedObjectInvalid(t *testing.T) {
 		t.Fatalf("expected an error but didn't get one")
 	}
 }
+
+func TestValidateNestedSingleObjectInvalid(t *testing.T) {
+	type Pet struct {
+		Name string `tfschema:"name"`
+		Age  int
+	}
+	type Person struct {
+		Name string `tfschema:"name"`
+		Pet  *Pet   `tfschema:"pet"`
+	}
+	if err := ValidateModelObject(&Person{}); err == nil {
+		t.Fatalf("expected an error but didn't get one")
+	}
+}
+
+func TestValidateNestedMapOfObjectsInvalid(t *testing.T) {
+	type Pet struct {
+		Name string `tfschema:"name"`
+		Age  int
+	}
+	type Person struct {
+		Name string         `tfschema:"name"`
+		Pets map[string]Pet `tfschema:"pets"`
+	}
+	if err := ValidateModelObject(&Person{}); err == nil {
+		t.Fatalf("expected an error but didn't get one")
+	}
+}
+
+func TestValidateModelObjectMatchesSchema(t *testing.T) {
+	type Pet struct {
+		Name string `tfschema:"name"`
+	}
+	type Person struct {
+		Name string `tfschema:"name"`
+		Pets []Pet  `tfschema:"pets"`
+	}
+	petsSchema := func(fields map[string]*schema.Schema) *schema.Schema {
+		return &schema.Schema{
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: fields,
+			},
+		}
+	}
+	testData := []struct {
+		name        string
+		schema      map[string]*schema.Schema
+		expectError bool
+	}{
+		{
+			name: "matches",
+			schema: map[string]*schema.Schema{
+				"name": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"pets": petsSchema(map[string]*schema.Schema{
+					"name": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+				}),
+			},
+			expectError: false,
+		},
+		{
+			name: "missing from the schema",
+			schema: map[string]*schema.Schema{
+				"pets": petsSchema(map[string]*schema.Schema{
+					"name": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+				}),
+			},
+			expectError: true,
+		},
+		{
+			name: "missing from the model",
+			schema: map[string]*schema.Schema{
+				"name": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"age": {
+					Type:     schema.TypeInt,
+					Optional: true,
+				},
+				"pets": petsSchema(map[string]*schema.Schema{
+					"name": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+				}),
+			},
+			expectError: true,
+		},
+		{
+			name: "nested field missing from the model",
+			schema: map[string]*schema.Schema{
+				"name": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"pets": petsSchema(map[string]*schema.Schema{
+					"name": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+					"age": {
+						Type:     schema.TypeInt,
+						Optional: true,
+					},
+				}),
+			},
+			expectError: true,
+		},
+		{
+			name: "nested block which isn't a nested object",
+			schema: map[string]*schema.Schema{
+				"name": petsSchema(map[string]*schema.Schema{
+					"name": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+				}),
+				"pets": petsSchema(map[string]*schema.Schema{
+					"name": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+				}),
+			},
+			expectError: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		err := ValidateModelObjectMatchesSchema(&Person{}, v.schema)
+		if v.expectError && err == nil {
+			t.Fatalf("expected an error but didn't get one")
+		}
+		if !v.expectError && err != nil {
+			t.Fatalf("expected no error but got: %+v", err)
+		}
+	}
+}
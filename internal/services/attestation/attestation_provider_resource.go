@@ -167,7 +167,7 @@ func resourceAttestationProviderUpdate(d *pluginsdk.ResourceData, meta interface
 	}
 
 	updateParams := attestation.ServicePatchParams{}
-	if d.HasChange("tags") {
+	if d.HasChanges("tags", "tags_all") {
 		updateParams.Tags = tags.Expand(d.Get("tags").(map[string]interface{}))
 	}
 
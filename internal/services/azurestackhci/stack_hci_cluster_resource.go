@@ -161,7 +161,7 @@ func resourceArmStackHCIClusterUpdate(d *pluginsdk.ResourceData, meta interface{
 
 	cluster := azurestackhci.ClusterUpdate{}
 
-	if d.HasChange("tags") {
+	if d.HasChanges("tags", "tags_all") {
 		cluster.Tags = tags.Expand(d.Get("tags").(map[string]interface{}))
 	}
 
@@ -6,7 +6,6 @@ import (
 
 	batchDataplane "github.com/Azure/azure-sdk-for-go/services/batch/2020-03-01.11.0/batch"
 	"github.com/Azure/azure-sdk-for-go/services/batch/mgmt/2020-03-01/batch"
-	"github.com/Azure/go-autorest/autorest"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/batch/parse"
 )
@@ -17,10 +16,13 @@ type Client struct {
 	CertificateClient *batch.CertificateClient
 	PoolClient        *batch.PoolClient
 
-	BatchManagementAuthorizer autorest.Authorizer
+	options *common.ClientOptions
 }
 
 func NewClient(o *common.ClientOptions) *Client {
+	// the Batch Account Keys are returned as `primary` and `secondary`
+	common.RegisterRedactedFields("primary", "secondary")
+
 	accountClient := batch.NewAccountClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
 	o.ConfigureClient(&accountClient.Client, o.ResourceManagerAuthorizer)
 
@@ -34,11 +36,11 @@ func NewClient(o *common.ClientOptions) *Client {
 	o.ConfigureClient(&poolClient.Client, o.ResourceManagerAuthorizer)
 
 	return &Client{
-		AccountClient:             &accountClient,
-		ApplicationClient:         &applicationClient,
-		CertificateClient:         &certificateClient,
-		PoolClient:                &poolClient,
-		BatchManagementAuthorizer: o.BatchManagementAuthorizer,
+		AccountClient:     &accountClient,
+		ApplicationClient: &applicationClient,
+		CertificateClient: &certificateClient,
+		PoolClient:        &poolClient,
+		options:           o,
 	}
 }
 
@@ -59,6 +61,6 @@ func (r *Client) JobClient(ctx context.Context, accountId parse.AccountId) (*bat
 	// Copy the client since we'll manipulate its BatchURL
 	endpoint := "https://" + *account.AccountProperties.AccountEndpoint
 	c := batchDataplane.NewJobClient(endpoint)
-	c.BaseClient.Client.Authorizer = r.BatchManagementAuthorizer
+	r.options.ConfigureClient(&c.BaseClient.Client, r.options.BatchManagementAuthorizer)
 	return &c, nil
 }
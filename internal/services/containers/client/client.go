@@ -25,6 +25,9 @@ type Client struct {
 }
 
 func NewClient(o *common.ClientOptions) *Client {
+	// the Container Registry Admin Passwords are returned as `passwords[].value`
+	common.RegisterRedactedFields("passwords.value")
+
 	registriesClient := containerregistry.NewRegistriesClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
 	o.ConfigureClient(&registriesClient.Client, o.ResourceManagerAuthorizer)
 
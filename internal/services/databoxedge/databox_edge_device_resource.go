@@ -227,7 +227,7 @@ func resourceDataboxEdgeDeviceUpdate(d *pluginsdk.ResourceData, meta interface{}
 	}
 
 	parameters := databoxedge.DevicePatch{}
-	if d.HasChange("tags") {
+	if d.HasChanges("tags", "tags_all") {
 		parameters.Tags = tags.Expand(d.Get("tags").(map[string]interface{}))
 	}
 
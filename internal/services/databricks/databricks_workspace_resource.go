@@ -350,10 +350,14 @@ func resourceDatabricksWorkspaceCreateUpdate(d *pluginsdk.ResourceData, meta int
 		backendPoolName = backendPoolId.BackendAddressPoolName
 		loadBalancerId = lbId.ID()
 
-		locks.ByID(backendPoolId.ID())
+		if err := locks.ByIDWithContext(ctx, backendPoolId.ID()); err != nil {
+			return err
+		}
 		defer locks.UnlockByID(backendPoolId.ID())
 
-		locks.ByID(lbId.ID())
+		if err := locks.ByIDWithContext(ctx, lbId.ID()); err != nil {
+			return err
+		}
 		defer locks.UnlockByID(lbId.ID())
 
 		// check to make sure the load balancer exists as referred to by the Backend Address Pool...
@@ -467,7 +471,7 @@ func resourceDatabricksWorkspaceCreateUpdate(d *pluginsdk.ResourceData, meta int
 	// this will cause the updated tags to be propagated to all of the connected
 	// workspace resources.
 	// TODO: can be removed once https://github.com/Azure/azure-sdk-for-go/issues/14571 is fixed
-	if !d.IsNewResource() && d.HasChange("tags") {
+	if !d.IsNewResource() && d.HasChanges("tags", "tags_all") {
 		workspaceUpdate := databricks.WorkspaceUpdate{
 			Tags: expandedTags,
 		}
@@ -175,7 +175,7 @@ func resourceDataProtectionBackupVaultUpdate(d *pluginsdk.ResourceData, meta int
 	if d.HasChange("identity") {
 		parameters.Identity = expandBackupVaultDppIdentityDetails(d.Get("identity").([]interface{}))
 	}
-	if d.HasChange("tags") {
+	if d.HasChanges("tags", "tags_all") {
 		parameters.Tags = tags.Expand(d.Get("tags").(map[string]interface{}))
 	}
 
@@ -231,7 +231,9 @@ func resourceFirewallPolicyCreateUpdate(d *pluginsdk.ResourceData, meta interfac
 		}
 	}
 
-	locks.ByName(name, azureFirewallPolicyResourceName)
+	if err := locks.ByNameWithContext(ctx, name, azureFirewallPolicyResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(name, azureFirewallPolicyResourceName)
 
 	if _, err := client.CreateOrUpdate(ctx, resourceGroup, name, props); err != nil {
@@ -330,7 +332,9 @@ func resourceFirewallPolicyDelete(d *pluginsdk.ResourceData, meta interface{}) e
 		return err
 	}
 
-	locks.ByName(id.Name, azureFirewallPolicyResourceName)
+	if err := locks.ByNameWithContext(ctx, id.Name, azureFirewallPolicyResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.Name, azureFirewallPolicyResourceName)
 
 	future, err := client.Delete(ctx, id.ResourceGroup, id.Name)
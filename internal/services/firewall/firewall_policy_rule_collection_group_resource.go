@@ -399,7 +399,9 @@ func resourceFirewallPolicyRuleCollectionGroupCreateUpdate(d *pluginsdk.Resource
 		}
 	}
 
-	locks.ByName(policyId.Name, azureFirewallPolicyResourceName)
+	if err := locks.ByNameWithContext(ctx, policyId.Name, azureFirewallPolicyResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(policyId.Name, azureFirewallPolicyResourceName)
 
 	param := network.FirewallPolicyRuleCollectionGroup{
@@ -491,7 +493,9 @@ func resourceFirewallPolicyRuleCollectionGroupDelete(d *pluginsdk.ResourceData,
 		return err
 	}
 
-	locks.ByName(id.FirewallPolicyName, azureFirewallPolicyResourceName)
+	if err := locks.ByNameWithContext(ctx, id.FirewallPolicyName, azureFirewallPolicyResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.FirewallPolicyName, azureFirewallPolicyResourceName)
 
 	future, err := client.Delete(ctx, id.ResourceGroup, id.FirewallPolicyName, id.RuleCollectionGroupName)
@@ -323,13 +323,19 @@ func resourceFirewallCreateUpdate(d *pluginsdk.ResourceData, meta interface{}) e
 		}
 	}
 
-	locks.ByName(name, azureFirewallResourceName)
+	if err := locks.ByNameWithContext(ctx, name, azureFirewallResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(name, azureFirewallResourceName)
 
-	locks.MultipleByName(vnetToLock, VirtualNetworkResourceName)
+	if err := locks.MultipleByNameWithContext(ctx, vnetToLock, VirtualNetworkResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockMultipleByName(vnetToLock, VirtualNetworkResourceName)
 
-	locks.MultipleByName(subnetToLock, SubnetResourceName)
+	if err := locks.MultipleByNameWithContext(ctx, subnetToLock, SubnetResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockMultipleByName(subnetToLock, SubnetResourceName)
 
 	if !d.IsNewResource() {
@@ -515,13 +521,19 @@ func resourceFirewallDelete(d *pluginsdk.ResourceData, meta interface{}) error {
 		}
 	}
 
-	locks.ByName(name, azureFirewallResourceName)
+	if err := locks.ByNameWithContext(ctx, name, azureFirewallResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(name, azureFirewallResourceName)
 
-	locks.MultipleByName(&virtualNetworkNamesToLock, VirtualNetworkResourceName)
+	if err := locks.MultipleByNameWithContext(ctx, &virtualNetworkNamesToLock, VirtualNetworkResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockMultipleByName(&virtualNetworkNamesToLock, VirtualNetworkResourceName)
 
-	locks.MultipleByName(&subnetNamesToLock, SubnetResourceName)
+	if err := locks.MultipleByNameWithContext(ctx, &subnetNamesToLock, SubnetResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockMultipleByName(&subnetNamesToLock, SubnetResourceName)
 
 	future, err := client.Delete(ctx, resourceGroup, name)
@@ -29,3 +29,10 @@ func (r Registration) WebsiteCategories() []string {
 		"IoT Central",
 	}
 }
+
+// ResourceProviders returns a list of the Resource Providers used by this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"Microsoft.IoTCentral",
+	}
+}
@@ -14,6 +14,9 @@ type Client struct {
 }
 
 func NewClient(o *common.ClientOptions) *Client {
+	// the values of Secrets (and Certificates being imported) are returned in a top-level `value` field
+	common.RegisterRedactedFieldsForHost(o.Environment.KeyVaultDNSSuffix, "value")
+
 	managedHsmClient := keyvault.NewManagedHsmsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
 	o.ConfigureClient(&managedHsmClient.Client, o.ResourceManagerAuthorizer)
 
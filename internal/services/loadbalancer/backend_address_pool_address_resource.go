@@ -86,7 +86,9 @@ func (r BackendAddressPoolAddressResource) Create() sdk.ResourceFunc {
 				return err
 			}
 
-			locks.ByName(poolId.BackendAddressPoolName, backendAddressPoolResourceName)
+			if err := locks.ByNameWithContext(ctx, poolId.BackendAddressPoolName, backendAddressPoolResourceName); err != nil {
+				return err
+			}
 			defer locks.UnlockByName(poolId.BackendAddressPoolName, backendAddressPoolResourceName)
 
 			// Backend Addresses can only be created for Standard LB's - not Basic, so we have to check
@@ -219,7 +221,9 @@ func (r BackendAddressPoolAddressResource) Delete() sdk.ResourceFunc {
 				return err
 			}
 
-			locks.ByName(id.BackendAddressPoolName, backendAddressPoolResourceName)
+			if err := locks.ByNameWithContext(ctx, id.BackendAddressPoolName, backendAddressPoolResourceName); err != nil {
+				return err
+			}
 			defer locks.UnlockByName(id.BackendAddressPoolName, backendAddressPoolResourceName)
 
 			pool, err := client.Get(ctx, id.ResourceGroup, id.LoadBalancerName, id.BackendAddressPoolName)
@@ -274,7 +278,9 @@ func (r BackendAddressPoolAddressResource) Update() sdk.ResourceFunc {
 				return err
 			}
 
-			locks.ByName(id.BackendAddressPoolName, backendAddressPoolResourceName)
+			if err := locks.ByNameWithContext(ctx, id.BackendAddressPoolName, backendAddressPoolResourceName); err != nil {
+				return err
+			}
 			defer locks.UnlockByName(id.BackendAddressPoolName, backendAddressPoolResourceName)
 
 			var model BackendAddressPoolAddressModel
@@ -14,6 +14,7 @@ type Client struct {
 	DatabaseThreatDetectionPoliciesClient              *sql.DatabaseThreatDetectionPoliciesClient
 	DatabasesClient                                    *sql.DatabasesClient
 	ElasticPoolsClient                                 *sql.ElasticPoolsClient
+	FailoverGroupsClient                               *sql.FailoverGroupsClient
 	FirewallRulesClient                                *sql.FirewallRulesClient
 	JobAgentsClient                                    *sql.JobAgentsClient
 	JobCredentialsClient                               *sql.JobCredentialsClient
@@ -30,6 +31,16 @@ type Client struct {
 	GeoBackupPoliciesClient                            *sql.GeoBackupPoliciesClient
 	EncryptionProtectorClient                          *sql.EncryptionProtectorsClient
 	ServerKeysClient                                   *sql.ServerKeysClient
+
+	InstanceFailoverGroupsClient                    *sql.InstanceFailoverGroupsClient
+	ManagedBackupShortTermRetentionPoliciesClient   *sql.ManagedBackupShortTermRetentionPoliciesClient
+	ManagedDatabasesClient                          *sql.ManagedDatabasesClient
+	ManagedInstanceAdministratorsClient             *sql.ManagedInstanceAdministratorsClient
+	ManagedInstanceAzureADOnlyAuthenticationsClient *sql.ManagedInstanceAzureADOnlyAuthenticationsClient
+	ManagedInstanceEncryptionProtectorsClient       *sql.ManagedInstanceEncryptionProtectorsClient
+	ManagedInstanceKeysClient                       *sql.ManagedInstanceKeysClient
+	ManagedInstanceLongTermRetentionPoliciesClient  *sql.ManagedInstanceLongTermRetentionPoliciesClient
+	ManagedInstancesClient                          *sql.ManagedInstancesClient
 }
 
 func NewClient(o *common.ClientOptions) *Client {
@@ -54,6 +65,9 @@ func NewClient(o *common.ClientOptions) *Client {
 	elasticPoolsClient := sql.NewElasticPoolsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
 	o.ConfigureClient(&elasticPoolsClient.Client, o.ResourceManagerAuthorizer)
 
+	failoverGroupsClient := sql.NewFailoverGroupsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	o.ConfigureClient(&failoverGroupsClient.Client, o.ResourceManagerAuthorizer)
+
 	jobAgentsClient := sql.NewJobAgentsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
 	o.ConfigureClient(&jobAgentsClient.Client, o.ResourceManagerAuthorizer)
 
@@ -102,6 +116,33 @@ func NewClient(o *common.ClientOptions) *Client {
 	sqlServerKeysClient := sql.NewServerKeysClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
 	o.ConfigureClient(&sqlServerKeysClient.Client, o.ResourceManagerAuthorizer)
 
+	instanceFailoverGroupsClient := sql.NewInstanceFailoverGroupsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	o.ConfigureClient(&instanceFailoverGroupsClient.Client, o.ResourceManagerAuthorizer)
+
+	managedBackupShortTermRetentionPoliciesClient := sql.NewManagedBackupShortTermRetentionPoliciesClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	o.ConfigureClient(&managedBackupShortTermRetentionPoliciesClient.Client, o.ResourceManagerAuthorizer)
+
+	managedDatabasesClient := sql.NewManagedDatabasesClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	o.ConfigureClient(&managedDatabasesClient.Client, o.ResourceManagerAuthorizer)
+
+	managedInstanceAdministratorsClient := sql.NewManagedInstanceAdministratorsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	o.ConfigureClient(&managedInstanceAdministratorsClient.Client, o.ResourceManagerAuthorizer)
+
+	managedInstanceAzureADOnlyAuthenticationsClient := sql.NewManagedInstanceAzureADOnlyAuthenticationsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	o.ConfigureClient(&managedInstanceAzureADOnlyAuthenticationsClient.Client, o.ResourceManagerAuthorizer)
+
+	managedInstanceEncryptionProtectorsClient := sql.NewManagedInstanceEncryptionProtectorsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	o.ConfigureClient(&managedInstanceEncryptionProtectorsClient.Client, o.ResourceManagerAuthorizer)
+
+	managedInstanceKeysClient := sql.NewManagedInstanceKeysClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	o.ConfigureClient(&managedInstanceKeysClient.Client, o.ResourceManagerAuthorizer)
+
+	managedInstanceLongTermRetentionPoliciesClient := sql.NewManagedInstanceLongTermRetentionPoliciesClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	o.ConfigureClient(&managedInstanceLongTermRetentionPoliciesClient.Client, o.ResourceManagerAuthorizer)
+
+	managedInstancesClient := sql.NewManagedInstancesClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	o.ConfigureClient(&managedInstancesClient.Client, o.ResourceManagerAuthorizer)
+
 	return &Client{
 		BackupLongTermRetentionPoliciesClient:              &BackupLongTermRetentionPoliciesClient,
 		BackupShortTermRetentionPoliciesClient:             &BackupShortTermRetentionPoliciesClient,
@@ -111,6 +152,7 @@ func NewClient(o *common.ClientOptions) *Client {
 		DatabaseVulnerabilityAssessmentRuleBaselinesClient: &databaseVulnerabilityAssessmentRuleBaselinesClient,
 		EncryptionProtectorClient:                          &sqlEncryptionProtectorClient,
 		ElasticPoolsClient:                                 &elasticPoolsClient,
+		FailoverGroupsClient:                               &failoverGroupsClient,
 		JobAgentsClient:                                    &jobAgentsClient,
 		JobCredentialsClient:                               &jobCredentialsClient,
 		FirewallRulesClient:                                &firewallRulesClient,
@@ -126,5 +168,15 @@ func NewClient(o *common.ClientOptions) *Client {
 		VirtualNetworkRulesClient:                          &sqlVirtualNetworkRulesClient,
 		GeoBackupPoliciesClient:                            &geoBackupPoliciesClient,
 		ServerKeysClient:                                   &sqlServerKeysClient,
+
+		InstanceFailoverGroupsClient:                    &instanceFailoverGroupsClient,
+		ManagedBackupShortTermRetentionPoliciesClient:   &managedBackupShortTermRetentionPoliciesClient,
+		ManagedDatabasesClient:                          &managedDatabasesClient,
+		ManagedInstanceAdministratorsClient:             &managedInstanceAdministratorsClient,
+		ManagedInstanceAzureADOnlyAuthenticationsClient: &managedInstanceAzureADOnlyAuthenticationsClient,
+		ManagedInstanceEncryptionProtectorsClient:       &managedInstanceEncryptionProtectorsClient,
+		ManagedInstanceKeysClient:                       &managedInstanceKeysClient,
+		ManagedInstanceLongTermRetentionPoliciesClient:  &managedInstanceLongTermRetentionPoliciesClient,
+		ManagedInstancesClient:                          &managedInstancesClient,
 	}
 }
@@ -32,17 +32,20 @@ func resourceMsSqlDatabase() *pluginsdk.Resource {
 		Update: resourceMsSqlDatabaseCreateUpdate,
 		Delete: resourceMsSqlDatabaseDelete,
 
+		// the legacy `azurerm_sql_database` resource stores the ID as returned by the API,
+		// which can differ in casing - so these are accepted and rewritten during import
 		Importer: pluginsdk.ImporterValidatingResourceIdThen(func(id string) error {
-			_, err := parse.DatabaseID(id)
+			_, err := parse.DatabaseIDInsensitively(id)
 			return err
 		}, func(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) ([]*pluginsdk.ResourceData, error) {
 			replicationLinksClient := meta.(*clients.Client).MSSQL.ReplicationLinksClient
 			resourceClient := meta.(*clients.Client).Resource.ResourcesClient
 
-			id, err := parse.DatabaseID(d.Id())
+			id, err := parse.DatabaseIDInsensitively(d.Id())
 			if err != nil {
 				return nil, err
 			}
+			d.SetId(id.ID())
 			resp, err := replicationLinksClient.ListByDatabase(ctx, id.ResourceGroup, id.ServerName, id.Name)
 			if err != nil {
 				return nil, fmt.Errorf("reading Replication Links for MsSql Database %s (MsSql Server Name %q / Resource Group %q): %s", id.Name, id.ServerName, id.ResourceGroup, err)
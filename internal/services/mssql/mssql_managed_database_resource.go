@@ -0,0 +1,321 @@
+package mssql
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/sql/mgmt/v3.0/sql"
+	"github.com/Azure/go-autorest/autorest/date"
+	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/mssql/helper"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/mssql/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/mssql/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/suppress"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+func resourceMsSqlManagedDatabase() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Create: resourceMsSqlManagedDatabaseCreate,
+		Read:   resourceMsSqlManagedDatabaseRead,
+		Update: resourceMsSqlManagedDatabaseUpdate,
+		Delete: resourceMsSqlManagedDatabaseDelete,
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			_, err := parse.ManagedDatabaseID(id)
+			return err
+		}),
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(60 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(60 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(60 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.ValidateMsSqlDatabaseName,
+			},
+
+			"managed_instance_id": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.ManagedInstanceID,
+			},
+
+			"collation": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.DatabaseCollation(),
+			},
+
+			"point_in_time_restore": {
+				Type:     pluginsdk.TypeList,
+				Optional: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"restore_point_in_time": {
+							Type:             pluginsdk.TypeString,
+							Required:         true,
+							ForceNew:         true,
+							DiffSuppressFunc: suppress.RFC3339Time,
+							ValidateFunc:     validation.IsRFC3339Time,
+						},
+
+						"source_database_id": {
+							Type:         pluginsdk.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validate.ManagedDatabaseID,
+						},
+					},
+				},
+			},
+
+			"long_term_retention_policy": helper.LongTermRetentionPolicySchema(),
+
+			"short_term_retention_days": {
+				Type:         pluginsdk.TypeInt,
+				Optional:     true,
+				Default:      7,
+				ValidateFunc: validation.IntBetween(1, 35),
+			},
+		},
+	}
+}
+
+func resourceMsSqlManagedDatabaseCreate(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).MSSQL.ManagedDatabasesClient
+	instancesClient := meta.(*clients.Client).MSSQL.ManagedInstancesClient
+	ctx, cancel := timeouts.ForCreate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	instanceId, err := parse.ManagedInstanceID(d.Get("managed_instance_id").(string))
+	if err != nil {
+		return err
+	}
+
+	id := parse.NewManagedDatabaseID(instanceId.SubscriptionId, instanceId.ResourceGroup, instanceId.Name, d.Get("name").(string))
+
+	existing, err := client.Get(ctx, id.ResourceGroup, id.ManagedInstanceName, id.DatabaseName)
+	if err != nil {
+		if !utils.ResponseWasNotFound(existing.Response) {
+			return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+		}
+	}
+
+	if existing.ID != nil && *existing.ID != "" {
+		return tf.ImportAsExistsError("azurerm_mssql_managed_database", id.ID())
+	}
+
+	// databases have to be created in the same location as the Managed Instance
+	instance, err := instancesClient.Get(ctx, instanceId.ResourceGroup, instanceId.Name)
+	if err != nil {
+		return fmt.Errorf("retrieving %s: %+v", *instanceId, err)
+	}
+
+	parameters := sql.ManagedDatabase{
+		Location: instance.Location,
+		ManagedDatabaseProperties: &sql.ManagedDatabaseProperties{
+			CreateMode: sql.ManagedDatabaseCreateModeDefault,
+		},
+	}
+
+	if v := d.Get("collation").(string); v != "" {
+		parameters.ManagedDatabaseProperties.Collation = utils.String(v)
+	}
+
+	if v := d.Get("point_in_time_restore").([]interface{}); len(v) > 0 && v[0] != nil {
+		restore := v[0].(map[string]interface{})
+		restorePointInTime, _ := time.Parse(time.RFC3339, restore["restore_point_in_time"].(string))
+
+		parameters.ManagedDatabaseProperties.CreateMode = sql.ManagedDatabaseCreateModePointInTimeRestore
+		parameters.ManagedDatabaseProperties.RestorePointInTime = &date.Time{Time: restorePointInTime}
+		parameters.ManagedDatabaseProperties.SourceDatabaseID = utils.String(restore["source_database_id"].(string))
+	}
+
+	future, err := client.CreateOrUpdate(ctx, id.ResourceGroup, id.ManagedInstanceName, id.DatabaseName, parameters)
+	if err != nil {
+		return fmt.Errorf("creating %s: %+v", id, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for creation of %s: %+v", id, err)
+	}
+
+	d.SetId(id.ID())
+
+	if err := updateMsSqlManagedDatabaseRetentionPolicies(ctx, d, meta, id); err != nil {
+		return err
+	}
+
+	return resourceMsSqlManagedDatabaseRead(d, meta)
+}
+
+func resourceMsSqlManagedDatabaseUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
+	ctx, cancel := timeouts.ForUpdate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.ManagedDatabaseID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	if err := updateMsSqlManagedDatabaseRetentionPolicies(ctx, d, meta, *id); err != nil {
+		return err
+	}
+
+	return resourceMsSqlManagedDatabaseRead(d, meta)
+}
+
+func updateMsSqlManagedDatabaseRetentionPolicies(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}, id parse.ManagedDatabaseId) error {
+	longTermRetentionClient := meta.(*clients.Client).MSSQL.ManagedInstanceLongTermRetentionPoliciesClient
+	shortTermRetentionClient := meta.(*clients.Client).MSSQL.ManagedBackupShortTermRetentionPoliciesClient
+
+	if d.HasChange("short_term_retention_days") {
+		policy := sql.ManagedBackupShortTermRetentionPolicy{
+			ManagedBackupShortTermRetentionPolicyProperties: &sql.ManagedBackupShortTermRetentionPolicyProperties{
+				RetentionDays: utils.Int32(int32(d.Get("short_term_retention_days").(int))),
+			},
+		}
+
+		future, err := shortTermRetentionClient.CreateOrUpdate(ctx, id.ResourceGroup, id.ManagedInstanceName, id.DatabaseName, policy)
+		if err != nil {
+			return fmt.Errorf("updating Short Term Retention Policy for %s: %+v", id, err)
+		}
+
+		if err = future.WaitForCompletionRef(ctx, shortTermRetentionClient.Client); err != nil {
+			return fmt.Errorf("waiting for update of Short Term Retention Policy for %s: %+v", id, err)
+		}
+	}
+
+	if d.HasChange("long_term_retention_policy") {
+		if props := helper.ExpandLongTermRetentionPolicy(d.Get("long_term_retention_policy").([]interface{})); props != nil {
+			policy := sql.ManagedInstanceLongTermRetentionPolicy{
+				BaseLongTermRetentionPolicyProperties: &sql.BaseLongTermRetentionPolicyProperties{
+					WeeklyRetention:  props.WeeklyRetention,
+					MonthlyRetention: props.MonthlyRetention,
+					YearlyRetention:  props.YearlyRetention,
+					WeekOfYear:       props.WeekOfYear,
+				},
+			}
+
+			future, err := longTermRetentionClient.CreateOrUpdate(ctx, id.ResourceGroup, id.ManagedInstanceName, id.DatabaseName, policy)
+			if err != nil {
+				return fmt.Errorf("updating Long Term Retention Policy for %s: %+v", id, err)
+			}
+
+			if err = future.WaitForCompletionRef(ctx, longTermRetentionClient.Client); err != nil {
+				return fmt.Errorf("waiting for update of Long Term Retention Policy for %s: %+v", id, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func resourceMsSqlManagedDatabaseRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).MSSQL.ManagedDatabasesClient
+	longTermRetentionClient := meta.(*clients.Client).MSSQL.ManagedInstanceLongTermRetentionPoliciesClient
+	shortTermRetentionClient := meta.(*clients.Client).MSSQL.ManagedBackupShortTermRetentionPoliciesClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.ManagedDatabaseID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Get(ctx, id.ResourceGroup, id.ManagedInstanceName, id.DatabaseName)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[INFO] %s was not found - removing from state", *id)
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	d.Set("name", id.DatabaseName)
+	d.Set("managed_instance_id", parse.NewManagedInstanceID(id.SubscriptionId, id.ResourceGroup, id.ManagedInstanceName).ID())
+
+	if props := resp.ManagedDatabaseProperties; props != nil {
+		d.Set("collation", props.Collation)
+	}
+
+	shortTermPolicy, err := shortTermRetentionClient.Get(ctx, id.ResourceGroup, id.ManagedInstanceName, id.DatabaseName)
+	if err != nil {
+		return fmt.Errorf("retrieving Short Term Retention Policy for %s: %+v", *id, err)
+	}
+
+	retentionDays := 7
+	if props := shortTermPolicy.ManagedBackupShortTermRetentionPolicyProperties; props != nil && props.RetentionDays != nil {
+		retentionDays = int(*props.RetentionDays)
+	}
+	d.Set("short_term_retention_days", retentionDays)
+
+	longTermPolicy, err := longTermRetentionClient.Get(ctx, id.ResourceGroup, id.ManagedInstanceName, id.DatabaseName)
+	if err != nil {
+		return fmt.Errorf("retrieving Long Term Retention Policy for %s: %+v", *id, err)
+	}
+
+	if err := d.Set("long_term_retention_policy", flattenMsSqlManagedDatabaseLongTermRetentionPolicy(longTermPolicy.BaseLongTermRetentionPolicyProperties)); err != nil {
+		return fmt.Errorf("setting `long_term_retention_policy`: %+v", err)
+	}
+
+	return nil
+}
+
+func resourceMsSqlManagedDatabaseDelete(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).MSSQL.ManagedDatabasesClient
+	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.ManagedDatabaseID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	future, err := client.Delete(ctx, id.ResourceGroup, id.ManagedInstanceName, id.DatabaseName)
+	if err != nil {
+		return fmt.Errorf("deleting %s: %+v", *id, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for deletion of %s: %+v", *id, err)
+	}
+
+	return nil
+}
+
+func flattenMsSqlManagedDatabaseLongTermRetentionPolicy(input *sql.BaseLongTermRetentionPolicyProperties) []interface{} {
+	if input == nil {
+		return []interface{}{}
+	}
+
+	// the Managed Instance policy shares its properties with the SQL Database one, so the same defaults apply
+	return helper.FlattenLongTermRetentionPolicy(&sql.BackupLongTermRetentionPolicy{
+		LongTermRetentionPolicyProperties: &sql.LongTermRetentionPolicyProperties{
+			WeeklyRetention:  input.WeeklyRetention,
+			MonthlyRetention: input.MonthlyRetention,
+			YearlyRetention:  input.YearlyRetention,
+			WeekOfYear:       input.WeekOfYear,
+		},
+	}, nil)
+}
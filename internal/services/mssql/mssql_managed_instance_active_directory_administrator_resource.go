@@ -0,0 +1,238 @@
+package mssql
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/sql/mgmt/v3.0/sql"
+	"github.com/gofrs/uuid"
+	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/mssql/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/mssql/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+func resourceMsSqlManagedInstanceActiveDirectoryAdministrator() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Create: resourceMsSqlManagedInstanceActiveDirectoryAdministratorCreateUpdate,
+		Read:   resourceMsSqlManagedInstanceActiveDirectoryAdministratorRead,
+		Update: resourceMsSqlManagedInstanceActiveDirectoryAdministratorCreateUpdate,
+		Delete: resourceMsSqlManagedInstanceActiveDirectoryAdministratorDelete,
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			_, err := parse.ManagedInstanceAzureActiveDirectoryAdministratorID(id)
+			return err
+		}),
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"managed_instance_id": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.ManagedInstanceID,
+			},
+
+			"login_username": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"object_id": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+
+			"tenant_id": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+
+			"azuread_authentication_only": {
+				Type:     pluginsdk.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+		},
+	}
+}
+
+func resourceMsSqlManagedInstanceActiveDirectoryAdministratorCreateUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).MSSQL.ManagedInstanceAdministratorsClient
+	aadOnlyClient := meta.(*clients.Client).MSSQL.ManagedInstanceAzureADOnlyAuthenticationsClient
+	ctx, cancel := timeouts.ForCreateUpdate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	instanceId, err := parse.ManagedInstanceID(d.Get("managed_instance_id").(string))
+	if err != nil {
+		return err
+	}
+
+	// the API only supports a single administrator, which is always named `ActiveDirectory`
+	id := parse.NewManagedInstanceAzureActiveDirectoryAdministratorID(instanceId.SubscriptionId, instanceId.ResourceGroup, instanceId.Name, "ActiveDirectory")
+
+	if d.IsNewResource() {
+		existing, err := client.Get(ctx, id.ResourceGroup, id.ManagedInstanceName)
+		if err != nil {
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+			}
+		}
+
+		if existing.ID != nil && *existing.ID != "" {
+			return tf.ImportAsExistsError("azurerm_mssql_managed_instance_active_directory_administrator", id.ID())
+		}
+	}
+
+	sid, err := uuid.FromString(d.Get("object_id").(string))
+	if err != nil {
+		return fmt.Errorf("parsing `object_id`: %+v", err)
+	}
+
+	tenantId, err := uuid.FromString(d.Get("tenant_id").(string))
+	if err != nil {
+		return fmt.Errorf("parsing `tenant_id`: %+v", err)
+	}
+
+	parameters := sql.ManagedInstanceAdministrator{
+		ManagedInstanceAdministratorProperties: &sql.ManagedInstanceAdministratorProperties{
+			AdministratorType: utils.String("ActiveDirectory"),
+			Login:             utils.String(d.Get("login_username").(string)),
+			Sid:               &sid,
+			TenantID:          &tenantId,
+		},
+	}
+
+	future, err := client.CreateOrUpdate(ctx, id.ResourceGroup, id.ManagedInstanceName, parameters)
+	if err != nil {
+		return fmt.Errorf("creating/updating %s: %+v", id, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for creation/update of %s: %+v", id, err)
+	}
+
+	if err := setMsSqlManagedInstanceAzureADOnlyAuthentication(ctx, aadOnlyClient, id, d.Get("azuread_authentication_only").(bool)); err != nil {
+		return err
+	}
+
+	d.SetId(id.ID())
+
+	return resourceMsSqlManagedInstanceActiveDirectoryAdministratorRead(d, meta)
+}
+
+func resourceMsSqlManagedInstanceActiveDirectoryAdministratorRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).MSSQL.ManagedInstanceAdministratorsClient
+	aadOnlyClient := meta.(*clients.Client).MSSQL.ManagedInstanceAzureADOnlyAuthenticationsClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.ManagedInstanceAzureActiveDirectoryAdministratorID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Get(ctx, id.ResourceGroup, id.ManagedInstanceName)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[INFO] %s was not found - removing from state", *id)
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	d.Set("managed_instance_id", parse.NewManagedInstanceID(id.SubscriptionId, id.ResourceGroup, id.ManagedInstanceName).ID())
+
+	if props := resp.ManagedInstanceAdministratorProperties; props != nil {
+		d.Set("login_username", props.Login)
+
+		objectId := ""
+		if props.Sid != nil {
+			objectId = props.Sid.String()
+		}
+		d.Set("object_id", objectId)
+
+		tenantId := ""
+		if props.TenantID != nil {
+			tenantId = props.TenantID.String()
+		}
+		d.Set("tenant_id", tenantId)
+	}
+
+	aadOnly, err := aadOnlyClient.Get(ctx, id.ResourceGroup, id.ManagedInstanceName)
+	if err != nil && !utils.ResponseWasNotFound(aadOnly.Response) {
+		return fmt.Errorf("retrieving Azure AD Only Authentication for %s: %+v", *id, err)
+	}
+
+	aadOnlyEnabled := false
+	if props := aadOnly.ManagedInstanceAzureADOnlyAuthProperties; props != nil && props.AzureADOnlyAuthentication != nil {
+		aadOnlyEnabled = *props.AzureADOnlyAuthentication
+	}
+	d.Set("azuread_authentication_only", aadOnlyEnabled)
+
+	return nil
+}
+
+func resourceMsSqlManagedInstanceActiveDirectoryAdministratorDelete(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).MSSQL.ManagedInstanceAdministratorsClient
+	aadOnlyClient := meta.(*clients.Client).MSSQL.ManagedInstanceAzureADOnlyAuthenticationsClient
+	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.ManagedInstanceAzureActiveDirectoryAdministratorID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	// the administrator can't be removed whilst only Azure AD authentication is allowed
+	if err := setMsSqlManagedInstanceAzureADOnlyAuthentication(ctx, aadOnlyClient, *id, false); err != nil {
+		return err
+	}
+
+	future, err := client.Delete(ctx, id.ResourceGroup, id.ManagedInstanceName)
+	if err != nil {
+		return fmt.Errorf("deleting %s: %+v", *id, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for deletion of %s: %+v", *id, err)
+	}
+
+	return nil
+}
+
+func setMsSqlManagedInstanceAzureADOnlyAuthentication(ctx context.Context, client *sql.ManagedInstanceAzureADOnlyAuthenticationsClient, id parse.ManagedInstanceAzureActiveDirectoryAdministratorId, enabled bool) error {
+	parameters := sql.ManagedInstanceAzureADOnlyAuthentication{
+		ManagedInstanceAzureADOnlyAuthProperties: &sql.ManagedInstanceAzureADOnlyAuthProperties{
+			AzureADOnlyAuthentication: utils.Bool(enabled),
+		},
+	}
+
+	future, err := client.CreateOrUpdate(ctx, id.ResourceGroup, id.ManagedInstanceName, parameters)
+	if err != nil {
+		return fmt.Errorf("setting Azure AD Only Authentication for %s: %+v", id, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for Azure AD Only Authentication to be set for %s: %+v", id, err)
+	}
+
+	return nil
+}
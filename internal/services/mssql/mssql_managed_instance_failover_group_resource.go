@@ -0,0 +1,365 @@
+package mssql
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/sql/mgmt/v3.0/sql"
+	"github.com/hashicorp/terraform-provider-azurerm/helpers/azure"
+	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/location"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/mssql/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/mssql/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+func resourceMsSqlManagedInstanceFailoverGroup() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Create: resourceMsSqlManagedInstanceFailoverGroupCreateUpdate,
+		Read:   resourceMsSqlManagedInstanceFailoverGroupRead,
+		Update: resourceMsSqlManagedInstanceFailoverGroupCreateUpdate,
+		Delete: resourceMsSqlManagedInstanceFailoverGroupDelete,
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			_, err := parse.InstanceFailoverGroupID(id)
+			return err
+		}),
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(60 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(60 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(60 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.ValidateMsSqlFailoverGroupName,
+			},
+
+			"resource_group_name": azure.SchemaResourceGroupName(),
+
+			// the location of the primary Managed Instance, which the Failover Group is scoped to
+			"location": azure.SchemaLocation(),
+
+			"managed_instance_id": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.ManagedInstanceID,
+			},
+
+			"partner_managed_instance_id": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.ManagedInstanceID,
+			},
+
+			"readonly_endpoint_failover_policy": {
+				Type:     pluginsdk.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"mode": {
+							Type:     pluginsdk.TypeString,
+							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								string(sql.ReadOnlyEndpointFailoverPolicyDisabled),
+								string(sql.ReadOnlyEndpointFailoverPolicyEnabled),
+							}, false),
+						},
+					},
+				},
+			},
+
+			"read_write_endpoint_failover_policy": {
+				Type:     pluginsdk.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"mode": {
+							Type:     pluginsdk.TypeString,
+							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								string(sql.Automatic),
+								string(sql.Manual),
+							}, false),
+						},
+						"grace_minutes": {
+							Type:         pluginsdk.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(60),
+						},
+					},
+				},
+			},
+
+			"partner_region": {
+				Type:     pluginsdk.TypeList,
+				Computed: true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"location": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"role": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"role": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceMsSqlManagedInstanceFailoverGroupCreateUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).MSSQL.InstanceFailoverGroupsClient
+	instancesClient := meta.(*clients.Client).MSSQL.ManagedInstancesClient
+	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
+	ctx, cancel := timeouts.ForCreateUpdate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id := parse.NewInstanceFailoverGroupID(subscriptionId, d.Get("resource_group_name").(string), location.Normalize(d.Get("location").(string)), d.Get("name").(string))
+
+	if d.IsNewResource() {
+		existing, err := client.Get(ctx, id.ResourceGroup, id.LocationName, id.Name)
+		if err != nil {
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+			}
+		}
+
+		if existing.ID != nil && *existing.ID != "" {
+			return tf.ImportAsExistsError("azurerm_mssql_managed_instance_failover_group", id.ID())
+		}
+	}
+
+	primaryId := d.Get("managed_instance_id").(string)
+	partnerId, err := parse.ManagedInstanceID(d.Get("partner_managed_instance_id").(string))
+	if err != nil {
+		return err
+	}
+
+	// the API requires the location of the partner, rather than its ID, to be specified for the partner region
+	partner, err := instancesClient.Get(ctx, partnerId.ResourceGroup, partnerId.Name)
+	if err != nil {
+		return fmt.Errorf("retrieving partner %s: %+v", *partnerId, err)
+	}
+	if partner.Location == nil {
+		return fmt.Errorf("retrieving partner %s: `location` was nil", *partnerId)
+	}
+
+	parameters := sql.InstanceFailoverGroup{
+		InstanceFailoverGroupProperties: &sql.InstanceFailoverGroupProperties{
+			ReadOnlyEndpoint:  expandMsSqlManagedInstanceFailoverGroupReadOnlyPolicy(d.Get("readonly_endpoint_failover_policy").([]interface{})),
+			ReadWriteEndpoint: expandMsSqlManagedInstanceFailoverGroupReadWritePolicy(d.Get("read_write_endpoint_failover_policy").([]interface{})),
+			PartnerRegions: &[]sql.PartnerRegionInfo{
+				{
+					Location: partner.Location,
+				},
+			},
+			ManagedInstancePairs: &[]sql.ManagedInstancePairInfo{
+				{
+					PrimaryManagedInstanceID: utils.String(primaryId),
+					PartnerManagedInstanceID: utils.String(partnerId.ID()),
+				},
+			},
+		},
+	}
+
+	future, err := client.CreateOrUpdate(ctx, id.ResourceGroup, id.LocationName, id.Name, parameters)
+	if err != nil {
+		return fmt.Errorf("creating/updating %s: %+v", id, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for creation/update of %s: %+v", id, err)
+	}
+
+	d.SetId(id.ID())
+
+	return resourceMsSqlManagedInstanceFailoverGroupRead(d, meta)
+}
+
+func resourceMsSqlManagedInstanceFailoverGroupRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).MSSQL.InstanceFailoverGroupsClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.InstanceFailoverGroupID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Get(ctx, id.ResourceGroup, id.LocationName, id.Name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[INFO] %s was not found - removing from state", *id)
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	d.Set("name", id.Name)
+	d.Set("resource_group_name", id.ResourceGroup)
+	d.Set("location", location.Normalize(id.LocationName))
+
+	if props := resp.InstanceFailoverGroupProperties; props != nil {
+		if err := d.Set("read_write_endpoint_failover_policy", flattenMsSqlManagedInstanceFailoverGroupReadWritePolicy(props.ReadWriteEndpoint)); err != nil {
+			return fmt.Errorf("setting `read_write_endpoint_failover_policy`: %+v", err)
+		}
+
+		if err := d.Set("readonly_endpoint_failover_policy", flattenMsSqlManagedInstanceFailoverGroupReadOnlyPolicy(props.ReadOnlyEndpoint)); err != nil {
+			return fmt.Errorf("setting `readonly_endpoint_failover_policy`: %+v", err)
+		}
+
+		d.Set("role", string(props.ReplicationRole))
+
+		if err := d.Set("partner_region", flattenMsSqlManagedInstanceFailoverGroupPartnerRegions(props.PartnerRegions)); err != nil {
+			return fmt.Errorf("setting `partner_region`: %+v", err)
+		}
+
+		primaryId := ""
+		partnerId := ""
+		if pairs := props.ManagedInstancePairs; pairs != nil && len(*pairs) > 0 {
+			pair := (*pairs)[0]
+			if pair.PrimaryManagedInstanceID != nil {
+				primaryId = *pair.PrimaryManagedInstanceID
+			}
+			if pair.PartnerManagedInstanceID != nil {
+				partnerId = *pair.PartnerManagedInstanceID
+			}
+		}
+		d.Set("managed_instance_id", primaryId)
+		d.Set("partner_managed_instance_id", partnerId)
+	}
+
+	return nil
+}
+
+func resourceMsSqlManagedInstanceFailoverGroupDelete(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).MSSQL.InstanceFailoverGroupsClient
+	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.InstanceFailoverGroupID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	future, err := client.Delete(ctx, id.ResourceGroup, id.LocationName, id.Name)
+	if err != nil {
+		return fmt.Errorf("deleting %s: %+v", *id, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for deletion of %s: %+v", *id, err)
+	}
+
+	return nil
+}
+
+func expandMsSqlManagedInstanceFailoverGroupReadWritePolicy(input []interface{}) *sql.InstanceFailoverGroupReadWriteEndpoint {
+	if len(input) == 0 || input[0] == nil {
+		return nil
+	}
+
+	v := input[0].(map[string]interface{})
+	mode := sql.ReadWriteEndpointFailoverPolicy(v["mode"].(string))
+
+	policy := &sql.InstanceFailoverGroupReadWriteEndpoint{
+		FailoverPolicy: mode,
+	}
+
+	// a grace period can only be specified for automatic failover
+	if mode != sql.Manual {
+		policy.FailoverWithDataLossGracePeriodMinutes = utils.Int32(int32(v["grace_minutes"].(int)))
+	}
+
+	return policy
+}
+
+func flattenMsSqlManagedInstanceFailoverGroupReadWritePolicy(input *sql.InstanceFailoverGroupReadWriteEndpoint) []interface{} {
+	if input == nil {
+		return []interface{}{}
+	}
+
+	graceMinutes := 0
+	if input.FailoverWithDataLossGracePeriodMinutes != nil {
+		graceMinutes = int(*input.FailoverWithDataLossGracePeriodMinutes)
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"mode":          string(input.FailoverPolicy),
+			"grace_minutes": graceMinutes,
+		},
+	}
+}
+
+func expandMsSqlManagedInstanceFailoverGroupReadOnlyPolicy(input []interface{}) *sql.InstanceFailoverGroupReadOnlyEndpoint {
+	if len(input) == 0 || input[0] == nil {
+		return nil
+	}
+
+	v := input[0].(map[string]interface{})
+
+	return &sql.InstanceFailoverGroupReadOnlyEndpoint{
+		FailoverPolicy: sql.ReadOnlyEndpointFailoverPolicy(v["mode"].(string)),
+	}
+}
+
+func flattenMsSqlManagedInstanceFailoverGroupReadOnlyPolicy(input *sql.InstanceFailoverGroupReadOnlyEndpoint) []interface{} {
+	if input == nil {
+		return []interface{}{}
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"mode": string(input.FailoverPolicy),
+		},
+	}
+}
+
+func flattenMsSqlManagedInstanceFailoverGroupPartnerRegions(input *[]sql.PartnerRegionInfo) []interface{} {
+	results := make([]interface{}, 0)
+	if input == nil {
+		return results
+	}
+
+	for _, region := range *input {
+		regionLocation := ""
+		if region.Location != nil {
+			regionLocation = location.Normalize(*region.Location)
+		}
+
+		results = append(results, map[string]interface{}{
+			"location": regionLocation,
+			"role":     string(region.ReplicationRole),
+		})
+	}
+
+	return results
+}
@@ -0,0 +1,393 @@
+package mssql
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/sql/mgmt/v3.0/sql"
+	"github.com/hashicorp/terraform-provider-azurerm/helpers/azure"
+	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/identity"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/mssql/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/mssql/validate"
+	networkValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/network/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+type managedInstanceIdentity = identity.SystemAssigned
+
+func resourceMsSqlManagedInstance() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Create: resourceMsSqlManagedInstanceCreateUpdate,
+		Read:   resourceMsSqlManagedInstanceRead,
+		Update: resourceMsSqlManagedInstanceCreateUpdate,
+		Delete: resourceMsSqlManagedInstanceDelete,
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			_, err := parse.ManagedInstanceID(id)
+			return err
+		}),
+
+		// provisioning a Managed Instance into a new subnet can take several hours
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(24 * time.Hour),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(24 * time.Hour),
+			Delete: pluginsdk.DefaultTimeout(24 * time.Hour),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.ValidateMsSqlServerName,
+			},
+
+			"location": azure.SchemaLocation(),
+
+			"resource_group_name": azure.SchemaResourceGroupName(),
+
+			"sku_name": {
+				Type:     pluginsdk.TypeString,
+				Required: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"GP_Gen4",
+					"GP_Gen5",
+					"GP_G8IM",
+					"GP_G8IH",
+					"BC_Gen4",
+					"BC_Gen5",
+					"BC_G8IM",
+					"BC_G8IH",
+				}, false),
+			},
+
+			"subnet_id": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: networkValidate.SubnetID,
+			},
+
+			"vcores": {
+				Type:     pluginsdk.TypeInt,
+				Required: true,
+				ValidateFunc: validation.IntInSlice([]int{
+					4,
+					8,
+					16,
+					24,
+					32,
+					40,
+					64,
+					80,
+				}),
+			},
+
+			"storage_size_in_gb": {
+				Type:     pluginsdk.TypeInt,
+				Required: true,
+				ValidateFunc: validation.All(
+					validation.IntBetween(32, 16384),
+					validation.IntDivisibleBy(32),
+				),
+			},
+
+			"license_type": {
+				Type:     pluginsdk.TypeString,
+				Required: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(sql.ManagedInstanceLicenseTypeBasePrice),
+					string(sql.ManagedInstanceLicenseTypeLicenseIncluded),
+				}, false),
+			},
+
+			"administrator_login": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"administrator_login_password": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				Sensitive:    true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"collation": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "SQL_Latin1_General_CP1_CI_AS",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"dns_zone_partner_id": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.ManagedInstanceID,
+			},
+
+			"identity": managedInstanceIdentity{}.Schema(),
+
+			"minimum_tls_version": {
+				Type:     pluginsdk.TypeString,
+				Optional: true,
+				Default:  "1.2",
+				ValidateFunc: validation.StringInSlice([]string{
+					"1.0",
+					"1.1",
+					"1.2",
+				}, false),
+			},
+
+			"proxy_override": {
+				Type:     pluginsdk.TypeString,
+				Optional: true,
+				Default:  string(sql.ManagedInstanceProxyOverrideDefault),
+				ValidateFunc: validation.StringInSlice([]string{
+					string(sql.ManagedInstanceProxyOverrideDefault),
+					string(sql.ManagedInstanceProxyOverrideProxy),
+					string(sql.ManagedInstanceProxyOverrideRedirect),
+				}, false),
+			},
+
+			"public_data_endpoint_enabled": {
+				Type:     pluginsdk.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"storage_account_type": {
+				Type:     pluginsdk.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  string(sql.GRS),
+				ValidateFunc: validation.StringInSlice([]string{
+					string(sql.GRS),
+					string(sql.LRS),
+					string(sql.ZRS),
+				}, false),
+			},
+
+			"timezone_id": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "UTC",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"fqdn": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"tags": tags.Schema(),
+		},
+	}
+}
+
+func resourceMsSqlManagedInstanceCreateUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).MSSQL.ManagedInstancesClient
+	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
+	ctx, cancel := timeouts.ForCreateUpdate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id := parse.NewManagedInstanceID(subscriptionId, d.Get("resource_group_name").(string), d.Get("name").(string))
+
+	if d.IsNewResource() {
+		existing, err := client.Get(ctx, id.ResourceGroup, id.Name)
+		if err != nil {
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+			}
+		}
+
+		if existing.ID != nil && *existing.ID != "" {
+			return tf.ImportAsExistsError("azurerm_mssql_managed_instance", id.ID())
+		}
+	}
+
+	parameters := sql.ManagedInstance{
+		Location: utils.String(azure.NormalizeLocation(d.Get("location").(string))),
+		Sku: &sql.Sku{
+			Name: utils.String(d.Get("sku_name").(string)),
+		},
+		ManagedInstanceProperties: &sql.ManagedInstanceProperties{
+			AdministratorLogin:        utils.String(d.Get("administrator_login").(string)),
+			Collation:                 utils.String(d.Get("collation").(string)),
+			LicenseType:               sql.ManagedInstanceLicenseType(d.Get("license_type").(string)),
+			MinimalTLSVersion:         utils.String(d.Get("minimum_tls_version").(string)),
+			ProxyOverride:             sql.ManagedInstanceProxyOverride(d.Get("proxy_override").(string)),
+			PublicDataEndpointEnabled: utils.Bool(d.Get("public_data_endpoint_enabled").(bool)),
+			StorageAccountType:        sql.StorageAccountType(d.Get("storage_account_type").(string)),
+			StorageSizeInGB:           utils.Int32(int32(d.Get("storage_size_in_gb").(int))),
+			SubnetID:                  utils.String(d.Get("subnet_id").(string)),
+			TimezoneID:                utils.String(d.Get("timezone_id").(string)),
+			VCores:                    utils.Int32(int32(d.Get("vcores").(int))),
+		},
+		Tags: tags.Expand(d.Get("tags").(map[string]interface{})),
+	}
+
+	if _, ok := d.GetOk("identity"); ok {
+		managedIdentity, err := expandManagedInstanceIdentity(d.Get("identity").([]interface{}))
+		if err != nil {
+			return fmt.Errorf("expanding `identity`: %+v", err)
+		}
+		parameters.Identity = managedIdentity
+	}
+
+	if d.IsNewResource() || d.HasChange("administrator_login_password") {
+		parameters.ManagedInstanceProperties.AdministratorLoginPassword = utils.String(d.Get("administrator_login_password").(string))
+	}
+
+	if v := d.Get("dns_zone_partner_id").(string); v != "" {
+		parameters.ManagedInstanceProperties.DNSZonePartner = utils.String(v)
+	}
+
+	future, err := client.CreateOrUpdate(ctx, id.ResourceGroup, id.Name, parameters)
+	if err != nil {
+		return fmt.Errorf("creating/updating %s: %+v", id, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for creation/update of %s: %+v", id, err)
+	}
+
+	d.SetId(id.ID())
+
+	return resourceMsSqlManagedInstanceRead(d, meta)
+}
+
+func resourceMsSqlManagedInstanceRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).MSSQL.ManagedInstancesClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.ManagedInstanceID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Get(ctx, id.ResourceGroup, id.Name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[INFO] %s was not found - removing from state", *id)
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	d.Set("name", id.Name)
+	d.Set("resource_group_name", id.ResourceGroup)
+	if location := resp.Location; location != nil {
+		d.Set("location", azure.NormalizeLocation(*location))
+	}
+
+	skuName := ""
+	if resp.Sku != nil && resp.Sku.Name != nil {
+		skuName = *resp.Sku.Name
+	}
+	d.Set("sku_name", skuName)
+
+	if err := d.Set("identity", flattenManagedInstanceIdentity(resp.Identity)); err != nil {
+		return fmt.Errorf("setting `identity`: %+v", err)
+	}
+
+	if props := resp.ManagedInstanceProperties; props != nil {
+		d.Set("administrator_login", props.AdministratorLogin)
+		d.Set("collation", props.Collation)
+		d.Set("fqdn", props.FullyQualifiedDomainName)
+		d.Set("license_type", string(props.LicenseType))
+		d.Set("minimum_tls_version", props.MinimalTLSVersion)
+		d.Set("proxy_override", string(props.ProxyOverride))
+		d.Set("public_data_endpoint_enabled", props.PublicDataEndpointEnabled)
+		d.Set("storage_account_type", string(props.StorageAccountType))
+		d.Set("subnet_id", props.SubnetID)
+		d.Set("timezone_id", props.TimezoneID)
+
+		storageSizeInGb := 0
+		if props.StorageSizeInGB != nil {
+			storageSizeInGb = int(*props.StorageSizeInGB)
+		}
+		d.Set("storage_size_in_gb", storageSizeInGb)
+
+		vCores := 0
+		if props.VCores != nil {
+			vCores = int(*props.VCores)
+		}
+		d.Set("vcores", vCores)
+	}
+
+	// `dns_zone_partner_id` isn't returned by the API (only the resulting DNS zone is) so is taken from the config
+
+	return tags.FlattenAndSet(d, resp.Tags)
+}
+
+func resourceMsSqlManagedInstanceDelete(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).MSSQL.ManagedInstancesClient
+	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.ManagedInstanceID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	future, err := client.Delete(ctx, id.ResourceGroup, id.Name)
+	if err != nil {
+		return fmt.Errorf("deleting %s: %+v", *id, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for deletion of %s: %+v", *id, err)
+	}
+
+	return nil
+}
+
+func expandManagedInstanceIdentity(input []interface{}) (*sql.ResourceIdentity, error) {
+	config, err := managedInstanceIdentity{}.Expand(input)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sql.ResourceIdentity{
+		Type: sql.IdentityType(config.Type),
+	}, nil
+}
+
+func flattenManagedInstanceIdentity(input *sql.ResourceIdentity) []interface{} {
+	var config *identity.ExpandedConfig
+	if input != nil && input.Type != "" {
+		principalId := ""
+		if input.PrincipalID != nil {
+			principalId = input.PrincipalID.String()
+		}
+
+		tenantId := ""
+		if input.TenantID != nil {
+			tenantId = input.TenantID.String()
+		}
+
+		config = &identity.ExpandedConfig{
+			Type:        identity.Type(input.Type),
+			PrincipalId: principalId,
+			TenantId:    tenantId,
+		}
+	}
+	return managedInstanceIdentity{}.Flatten(config)
+}
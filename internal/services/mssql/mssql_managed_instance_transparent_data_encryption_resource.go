@@ -0,0 +1,203 @@
+package mssql
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/sql/mgmt/v3.0/sql"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	keyVaultParser "github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/parse"
+	keyVaultValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/mssql/parse"
+	mssqlValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/mssql/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+func resourceMsSqlManagedInstanceTransparentDataEncryption() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Create: resourceMsSqlManagedInstanceTransparentDataEncryptionCreateUpdate,
+		Read:   resourceMsSqlManagedInstanceTransparentDataEncryptionRead,
+		Update: resourceMsSqlManagedInstanceTransparentDataEncryptionCreateUpdate,
+		Delete: resourceMsSqlManagedInstanceTransparentDataEncryptionDelete,
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			_, err := parse.ManagedInstanceEncryptionProtectorID(id)
+			return err
+		}),
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"managed_instance_id": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: mssqlValidate.ManagedInstanceID,
+			},
+
+			"key_vault_key_id": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: keyVaultValidate.NestedItemId,
+			},
+		},
+	}
+}
+
+func resourceMsSqlManagedInstanceTransparentDataEncryptionCreateUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
+	encryptionProtectorClient := meta.(*clients.Client).MSSQL.ManagedInstanceEncryptionProtectorsClient
+	keysClient := meta.(*clients.Client).MSSQL.ManagedInstanceKeysClient
+	ctx, cancel := timeouts.ForCreateUpdate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	instanceId, err := parse.ManagedInstanceID(d.Get("managed_instance_id").(string))
+	if err != nil {
+		return err
+	}
+
+	// As with SQL Servers, a Managed Instance always has an encryption protector (which is service managed
+	// by default) - so this resource always overwrites it rather than checking whether it exists
+
+	// Encryption protector always uses "current" for the name
+	id := parse.NewManagedInstanceEncryptionProtectorID(instanceId.SubscriptionId, instanceId.ResourceGroup, instanceId.Name, "current")
+
+	keyName := "ServiceManaged"
+	keyType := sql.ServiceManaged
+
+	if keyVaultKeyId := strings.TrimSpace(d.Get("key_vault_key_id").(string)); keyVaultKeyId != "" {
+		keyType = sql.AzureKeyVault
+
+		keyName, err = managedInstanceKeyNameFromKeyVaultKeyId(keyVaultKeyId)
+		if err != nil {
+			return err
+		}
+
+		key := sql.ManagedInstanceKey{
+			ManagedInstanceKeyProperties: &sql.ManagedInstanceKeyProperties{
+				ServerKeyType: keyType,
+				URI:           utils.String(keyVaultKeyId),
+			},
+		}
+
+		keyFuture, err := keysClient.CreateOrUpdate(ctx, instanceId.ResourceGroup, instanceId.Name, keyName, key)
+		if err != nil {
+			return fmt.Errorf("creating/updating key %q for %s: %+v", keyName, instanceId, err)
+		}
+
+		if err = keyFuture.WaitForCompletionRef(ctx, keysClient.Client); err != nil {
+			return fmt.Errorf("waiting for creation/update of key %q for %s: %+v", keyName, instanceId, err)
+		}
+	}
+
+	parameters := sql.ManagedInstanceEncryptionProtector{
+		ManagedInstanceEncryptionProtectorProperties: &sql.ManagedInstanceEncryptionProtectorProperties{
+			ServerKeyType: keyType,
+			ServerKeyName: utils.String(keyName),
+		},
+	}
+
+	future, err := encryptionProtectorClient.CreateOrUpdate(ctx, id.ResourceGroup, id.ManagedInstanceName, parameters)
+	if err != nil {
+		return fmt.Errorf("creating/updating %s: %+v", id, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, encryptionProtectorClient.Client); err != nil {
+		return fmt.Errorf("waiting for creation/update of %s: %+v", id, err)
+	}
+
+	d.SetId(id.ID())
+
+	return resourceMsSqlManagedInstanceTransparentDataEncryptionRead(d, meta)
+}
+
+func resourceMsSqlManagedInstanceTransparentDataEncryptionRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).MSSQL.ManagedInstanceEncryptionProtectorsClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.ManagedInstanceEncryptionProtectorID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Get(ctx, id.ResourceGroup, id.ManagedInstanceName)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	d.Set("managed_instance_id", parse.NewManagedInstanceID(id.SubscriptionId, id.ResourceGroup, id.ManagedInstanceName).ID())
+
+	// the Key Vault Key ID is only set when the protector is customer managed
+	keyVaultKeyId := ""
+	if props := resp.ManagedInstanceEncryptionProtectorProperties; props != nil && props.ServerKeyType == sql.AzureKeyVault && props.URI != nil {
+		keyVaultKeyId = *props.URI
+	}
+	d.Set("key_vault_key_id", keyVaultKeyId)
+
+	return nil
+}
+
+func resourceMsSqlManagedInstanceTransparentDataEncryptionDelete(d *pluginsdk.ResourceData, meta interface{}) error {
+	// The encryption protector can't be deleted, only switched between AzureKeyVault and ServiceManaged - so
+	// when this resource is deleted it's reset to service managed, to avoid a lockout should the key be removed
+	client := meta.(*clients.Client).MSSQL.ManagedInstanceEncryptionProtectorsClient
+	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.ManagedInstanceEncryptionProtectorID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	parameters := sql.ManagedInstanceEncryptionProtector{
+		ManagedInstanceEncryptionProtectorProperties: &sql.ManagedInstanceEncryptionProtectorProperties{
+			ServerKeyType: sql.ServiceManaged,
+			ServerKeyName: utils.String("ServiceManaged"),
+		},
+	}
+
+	future, err := client.CreateOrUpdate(ctx, id.ResourceGroup, id.ManagedInstanceName, parameters)
+	if err != nil {
+		return fmt.Errorf("resetting %s to Service Managed: %+v", *id, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for %s to be reset to Service Managed: %+v", *id, err)
+	}
+
+	return nil
+}
+
+// managedInstanceKeyNameFromKeyVaultKeyId returns the name the API expects for a Key Vault Key,
+// which is in the format {vaultName}_{keyName}_{keyVersion}
+func managedInstanceKeyNameFromKeyVaultKeyId(input string) (string, error) {
+	keyId, err := keyVaultParser.ParseNestedItemID(input)
+	if err != nil {
+		return "", fmt.Errorf("parsing Key Vault Key ID %q: %+v", input, err)
+	}
+
+	if keyId.NestedItemType != "keys" {
+		return "", fmt.Errorf("`key_vault_key_id` must be a reference to a key, but got: %s", keyId.NestedItemType)
+	}
+
+	idURL, err := url.ParseRequestURI(keyId.KeyVaultBaseUrl)
+	if err != nil {
+		return "", fmt.Errorf("parsing Key Vault hostname %q: %+v", keyId.KeyVaultBaseUrl, err)
+	}
+	vaultName := strings.Split(idURL.Host, ".")[0]
+
+	return fmt.Sprintf("%s_%s_%s", vaultName, keyId.Name, keyId.Version), nil
+}
@@ -73,3 +73,59 @@ func DatabaseID(input string) (*DatabaseId, error) {
 
 	return &resourceId, nil
 }
+
+// DatabaseIDInsensitively parses an Database ID into an DatabaseId struct, insensitively
+// This should only be used to parse an ID for rewriting, the DatabaseID
+// method should be used instead for validation etc.
+//
+// Whilst this may seem strange, this enables Terraform have consistent casing
+// which works around issues in Core, whilst handling broken API responses.
+func DatabaseIDInsensitively(input string) (*DatabaseId, error) {
+	id, err := azure.ParseAzureResourceID(input)
+	if err != nil {
+		return nil, err
+	}
+
+	resourceId := DatabaseId{
+		SubscriptionId: id.SubscriptionID,
+		ResourceGroup:  id.ResourceGroup,
+	}
+
+	if resourceId.SubscriptionId == "" {
+		return nil, fmt.Errorf("ID was missing the 'subscriptions' element")
+	}
+
+	if resourceId.ResourceGroup == "" {
+		return nil, fmt.Errorf("ID was missing the 'resourceGroups' element")
+	}
+
+	// find the correct casing for the 'servers' segment
+	serversKey := "servers"
+	for key := range id.Path {
+		if strings.EqualFold(key, serversKey) {
+			serversKey = key
+			break
+		}
+	}
+	if resourceId.ServerName, err = id.PopSegment(serversKey); err != nil {
+		return nil, err
+	}
+
+	// find the correct casing for the 'databases' segment
+	databasesKey := "databases"
+	for key := range id.Path {
+		if strings.EqualFold(key, databasesKey) {
+			databasesKey = key
+			break
+		}
+	}
+	if resourceId.Name, err = id.PopSegment(databasesKey); err != nil {
+		return nil, err
+	}
+
+	if err := id.ValidateNoEmptySegments(input); err != nil {
+		return nil, err
+	}
+
+	return &resourceId, nil
+}
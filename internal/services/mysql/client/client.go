@@ -2,6 +2,7 @@ package client
 
 import (
 	"github.com/Azure/azure-sdk-for-go/services/mysql/mgmt/2020-01-01/mysql"
+	"github.com/Azure/azure-sdk-for-go/services/preview/mysql/mgmt/2021-05-01-preview/mysqlflexibleservers"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
 )
 
@@ -9,6 +10,10 @@ type Client struct {
 	ConfigurationsClient              *mysql.ConfigurationsClient
 	DatabasesClient                   *mysql.DatabasesClient
 	FirewallRulesClient               *mysql.FirewallRulesClient
+	FlexibleServerClient              *mysqlflexibleservers.ServersClient
+	FlexibleServerConfigurationClient *mysqlflexibleservers.ConfigurationsClient
+	FlexibleServerDatabaseClient      *mysqlflexibleservers.DatabasesClient
+	FlexibleServerFirewallRuleClient  *mysqlflexibleservers.FirewallRulesClient
 	ServersClient                     *mysql.ServersClient
 	ServerKeysClient                  *mysql.ServerKeysClient
 	ServerSecurityAlertPoliciesClient *mysql.ServerSecurityAlertPoliciesClient
@@ -41,10 +46,26 @@ func NewClient(o *common.ClientOptions) *Client {
 	serverAdministratorsClient := mysql.NewServerAdministratorsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
 	o.ConfigureClient(&serverAdministratorsClient.Client, o.ResourceManagerAuthorizer)
 
+	flexibleServerClient := mysqlflexibleservers.NewServersClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	o.ConfigureClient(&flexibleServerClient.Client, o.ResourceManagerAuthorizer)
+
+	flexibleServerConfigurationClient := mysqlflexibleservers.NewConfigurationsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	o.ConfigureClient(&flexibleServerConfigurationClient.Client, o.ResourceManagerAuthorizer)
+
+	flexibleServerDatabaseClient := mysqlflexibleservers.NewDatabasesClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	o.ConfigureClient(&flexibleServerDatabaseClient.Client, o.ResourceManagerAuthorizer)
+
+	flexibleServerFirewallRuleClient := mysqlflexibleservers.NewFirewallRulesClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	o.ConfigureClient(&flexibleServerFirewallRuleClient.Client, o.ResourceManagerAuthorizer)
+
 	return &Client{
 		ConfigurationsClient:              &ConfigurationsClient,
 		DatabasesClient:                   &DatabasesClient,
 		FirewallRulesClient:               &FirewallRulesClient,
+		FlexibleServerClient:              &flexibleServerClient,
+		FlexibleServerConfigurationClient: &flexibleServerConfigurationClient,
+		FlexibleServerDatabaseClient:      &flexibleServerDatabaseClient,
+		FlexibleServerFirewallRuleClient:  &flexibleServerFirewallRuleClient,
 		ServersClient:                     &ServersClient,
 		ServerKeysClient:                  &ServerKeysClient,
 		ServerSecurityAlertPoliciesClient: &serverSecurityAlertPoliciesClient,
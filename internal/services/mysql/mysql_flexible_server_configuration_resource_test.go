@@ -0,0 +1,118 @@
+package mysql_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/mysql/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+type MySqlFlexibleServerConfigurationResource struct {
+}
+
+func TestAccMySqlFlexibleServerConfiguration_characterSetServer(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_mysql_flexible_server_configuration", "test")
+	r := MySqlFlexibleServerConfigurationResource{}
+	name := "character_set_server"
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data, name, "utf8mb4"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("name").HasValue(name),
+				check.That(data.ResourceName).Key("value").HasValue("utf8mb4"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.template(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				data.CheckWithClientForResource(r.checkReset(name), "azurerm_mysql_flexible_server.test"),
+			),
+		},
+	})
+}
+
+func TestAccMySqlFlexibleServerConfiguration_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_mysql_flexible_server_configuration", "test")
+	r := MySqlFlexibleServerConfigurationResource{}
+	name := "interactive_timeout"
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data, name, "30"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("value").HasValue("30"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data, name, "60"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("value").HasValue("60"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (r MySqlFlexibleServerConfigurationResource) checkReset(configurationName string) acceptance.ClientCheckFunc {
+	return func(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) error {
+		id, err := parse.FlexibleServerID(state.ID)
+		if err != nil {
+			return err
+		}
+
+		resp, err := clients.MySQL.FlexibleServerConfigurationClient.Get(ctx, id.ResourceGroup, id.Name, configurationName)
+		if err != nil {
+			return fmt.Errorf("retrieving Configuration %q for %s: %+v", configurationName, *id, err)
+		}
+
+		if resp.ConfigurationProperties == nil || resp.Value == nil || resp.DefaultValue == nil {
+			return fmt.Errorf("retrieving Configuration %q for %s: `properties` was nil", configurationName, *id)
+		}
+
+		if *resp.DefaultValue != *resp.Value {
+			return fmt.Errorf("Configuration %q for %s wasn't reset to the default value. Expected %q - got %q", configurationName, *id, *resp.DefaultValue, *resp.Value)
+		}
+
+		return nil
+	}
+}
+
+func (MySqlFlexibleServerConfigurationResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := parse.FlexibleServerConfigurationID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := clients.MySQL.FlexibleServerConfigurationClient.Get(ctx, id.ResourceGroup, id.FlexibleServerName, id.ConfigurationName)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	return utils.Bool(resp.ID != nil), nil
+}
+
+func (MySqlFlexibleServerConfigurationResource) template(data acceptance.TestData) string {
+	return MySqlFlexibleServerResource{}.basic(data)
+}
+
+func (r MySqlFlexibleServerConfigurationResource) basic(data acceptance.TestData, name, value string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_mysql_flexible_server_configuration" "test" {
+  name      = "%s"
+  server_id = azurerm_mysql_flexible_server.test.id
+  value     = "%s"
+}
+`, r.template(data), name, value)
+}
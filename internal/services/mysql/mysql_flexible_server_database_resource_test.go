@@ -0,0 +1,90 @@
+package mysql_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/mysql/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+type MySqlFlexibleServerDatabaseResource struct {
+}
+
+func TestAccMySqlFlexibleServerDatabase_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_mysql_flexible_server_database", "test")
+	r := MySqlFlexibleServerDatabaseResource{}
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("charset").HasValue("utf8"),
+				check.That(data.ResourceName).Key("collation").HasValue("utf8_unicode_ci"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccMySqlFlexibleServerDatabase_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_mysql_flexible_server_database", "test")
+	r := MySqlFlexibleServerDatabaseResource{}
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport),
+	})
+}
+
+func (MySqlFlexibleServerDatabaseResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := parse.FlexibleServerDatabaseID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := clients.MySQL.FlexibleServerDatabaseClient.Get(ctx, id.ResourceGroup, id.FlexibleServerName, id.DatabaseName)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return utils.Bool(false), nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	return utils.Bool(resp.DatabaseProperties != nil), nil
+}
+
+func (MySqlFlexibleServerDatabaseResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_mysql_flexible_server_database" "test" {
+  name      = "acctest-fsd-%d"
+  server_id = azurerm_mysql_flexible_server.test.id
+  charset   = "utf8"
+  collation = "utf8_unicode_ci"
+}
+`, MySqlFlexibleServerResource{}.basic(data), data.RandomInteger)
+}
+
+func (r MySqlFlexibleServerDatabaseResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_mysql_flexible_server_database" "import" {
+  name      = azurerm_mysql_flexible_server_database.test.name
+  server_id = azurerm_mysql_flexible_server_database.test.server_id
+  charset   = azurerm_mysql_flexible_server_database.test.charset
+  collation = azurerm_mysql_flexible_server_database.test.collation
+}
+`, r.basic(data))
+}
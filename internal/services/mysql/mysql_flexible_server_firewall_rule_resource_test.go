@@ -0,0 +1,111 @@
+package mysql_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/mysql/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+type MySqlFlexibleServerFirewallRuleResource struct {
+}
+
+func TestAccMySqlFlexibleServerFirewallRule_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_mysql_flexible_server_firewall_rule", "test")
+	r := MySqlFlexibleServerFirewallRuleResource{}
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data, "122.122.0.0", "122.122.0.0"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccMySqlFlexibleServerFirewallRule_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_mysql_flexible_server_firewall_rule", "test")
+	r := MySqlFlexibleServerFirewallRuleResource{}
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data, "122.122.0.0", "122.122.0.0"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport),
+	})
+}
+
+func TestAccMySqlFlexibleServerFirewallRule_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_mysql_flexible_server_firewall_rule", "test")
+	r := MySqlFlexibleServerFirewallRuleResource{}
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data, "122.122.0.0", "122.122.0.0"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data, "123.123.0.0", "123.123.0.255"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("start_ip_address").HasValue("123.123.0.0"),
+				check.That(data.ResourceName).Key("end_ip_address").HasValue("123.123.0.255"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (MySqlFlexibleServerFirewallRuleResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := parse.FlexibleServerFirewallRuleID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := clients.MySQL.FlexibleServerFirewallRuleClient.Get(ctx, id.ResourceGroup, id.FlexibleServerName, id.FirewallRuleName)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return utils.Bool(false), nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	return utils.Bool(resp.FirewallRuleProperties != nil), nil
+}
+
+func (MySqlFlexibleServerFirewallRuleResource) basic(data acceptance.TestData, startIP, endIP string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_mysql_flexible_server_firewall_rule" "test" {
+  name             = "acctest-FSFR-%d"
+  server_id        = azurerm_mysql_flexible_server.test.id
+  start_ip_address = "%s"
+  end_ip_address   = "%s"
+}
+`, MySqlFlexibleServerResource{}.basic(data), data.RandomInteger, startIP, endIP)
+}
+
+func (r MySqlFlexibleServerFirewallRuleResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_mysql_flexible_server_firewall_rule" "import" {
+  name             = azurerm_mysql_flexible_server_firewall_rule.test.name
+  server_id        = azurerm_mysql_flexible_server_firewall_rule.test.server_id
+  start_ip_address = azurerm_mysql_flexible_server_firewall_rule.test.start_ip_address
+  end_ip_address   = azurerm_mysql_flexible_server_firewall_rule.test.end_ip_address
+}
+`, r.basic(data, "122.122.0.0", "122.122.0.0"))
+}
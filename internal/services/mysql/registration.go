@@ -18,6 +18,13 @@ func (r Registration) WebsiteCategories() []string {
 	}
 }
 
+// ResourceProviders returns a list of the Resource Providers used by this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"Microsoft.DBforMySQL",
+	}
+}
+
 // SupportedDataSources returns the supported Data Sources supported by this Service
 func (r Registration) SupportedDataSources() map[string]*pluginsdk.Resource {
 	return map[string]*pluginsdk.Resource{
@@ -31,6 +38,10 @@ func (r Registration) SupportedResources() map[string]*pluginsdk.Resource {
 		"azurerm_mysql_configuration":                  resourceMySQLConfiguration(),
 		"azurerm_mysql_database":                       resourceMySqlDatabase(),
 		"azurerm_mysql_firewall_rule":                  resourceMySqlFirewallRule(),
+		"azurerm_mysql_flexible_server":                resourceMysqlFlexibleServer(),
+		"azurerm_mysql_flexible_server_configuration":  resourceMysqlFlexibleServerConfiguration(),
+		"azurerm_mysql_flexible_server_database":       resourceMysqlFlexibleServerDatabase(),
+		"azurerm_mysql_flexible_server_firewall_rule":  resourceMysqlFlexibleServerFirewallRule(),
 		"azurerm_mysql_server":                         resourceMySqlServer(),
 		"azurerm_mysql_server_key":                     resourceMySQLServerKey(),
 		"azurerm_mysql_virtual_network_rule":           resourceMySQLVirtualNetworkRule(),
@@ -0,0 +1,60 @@
+package validate
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFlexibleServerDatabaseName(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		valid bool
+	}{
+		{
+			name:  "Empty",
+			input: "",
+			valid: false,
+		},
+		{
+			name:  "Invalid Characters",
+			input: "flexdb%",
+			valid: false,
+		},
+		{
+			name:  "Start with integer",
+			input: "1flexdb",
+			valid: true,
+		},
+		{
+			name:  "Name to long",
+			input: strings.Repeat("a", 65),
+			valid: false,
+		},
+		{
+			name:  "Max length",
+			input: strings.Repeat("a", 64),
+			valid: true,
+		},
+		{
+			name:  "One character",
+			input: "a",
+			valid: true,
+		},
+		{
+			name:  "Valid",
+			input: "flexdb-1_test",
+			valid: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := FlexibleServerDatabaseName(tt.input, "name")
+			valid := err == nil
+			if valid != tt.valid {
+				t.Errorf("Expected valid status %t but got %t for input %s", tt.valid, valid, tt.input)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package validate
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFlexibleServerName(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		valid bool
+	}{
+		{
+			name:  "Upper Case",
+			input: "Flexible",
+			valid: false,
+		},
+		{
+			name:  "Invalid Characters",
+			input: "flexible_test",
+			valid: false,
+		},
+		{
+			name:  "Too Short",
+			input: "ab",
+			valid: false,
+		},
+		{
+			name:  "Three characters",
+			input: "abc",
+			valid: true,
+		},
+		{
+			name:  "Empty",
+			input: "",
+			valid: false,
+		},
+		{
+			name:  "Start with `-`",
+			input: "-test",
+			valid: false,
+		},
+		{
+			name:  "End with `-`",
+			input: "test-",
+			valid: false,
+		},
+		{
+			name:  "Valid",
+			input: "flexible-server-1",
+			valid: true,
+		},
+		{
+			name:  "Too Long",
+			input: strings.Repeat("a", 64),
+			valid: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := FlexibleServerName(tt.input, "name")
+			valid := err == nil
+			if valid != tt.valid {
+				t.Errorf("Expected valid status %t but got %t for input %s", tt.valid, valid, tt.input)
+			}
+		})
+	}
+}
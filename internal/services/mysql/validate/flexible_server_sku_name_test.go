@@ -0,0 +1,66 @@
+package validate
+
+import "testing"
+
+func TestFlexibleServerSkuName(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		valid bool
+	}{
+		{
+			name:  "GP_Standard_D2ds_v4",
+			input: "GP_Standard_D2ds_v4",
+			valid: true,
+		},
+		{
+			name:  "Standard",
+			input: "Standard",
+			valid: false,
+		},
+		{
+			name:  "Empty",
+			input: "",
+			valid: false,
+		},
+		{
+			name:  "B_Standard_B1s",
+			input: "B_Standard_B1s",
+			valid: true,
+		},
+		{
+			name:  "B_Standard_B2ms",
+			input: "B_Standard_B2ms",
+			valid: false,
+		},
+		{
+			name:  "MO_Standard_E64ds_v4",
+			input: "MO_Standard_E64ds_v4",
+			valid: true,
+		},
+		{
+			name:  "MO_Standard_E30ds_v4",
+			input: "MO_Standard_E30ds_v4",
+			valid: false,
+		},
+		{
+			name:  "GP_Standard_E2ds_v4",
+			input: "GP_Standard_E2ds_v4",
+			valid: false,
+		},
+		{
+			name:  "B_Standard_D2ds_v4",
+			input: "B_Standard_D2ds_v4",
+			valid: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := FlexibleServerSkuName(tt.input, "name")
+			valid := err == nil
+			if valid != tt.valid {
+				t.Errorf("Expected valid status %t but got %t for input %s", tt.valid, valid, tt.input)
+			}
+		})
+	}
+}
@@ -67,7 +67,9 @@ func resourceExpressRouteCircuitAuthorizationCreate(d *pluginsdk.ResourceData, m
 	resourceGroup := d.Get("resource_group_name").(string)
 	circuitName := d.Get("express_route_circuit_name").(string)
 
-	locks.ByName(circuitName, expressRouteCircuitResourceName)
+	if err := locks.ByNameWithContext(ctx, circuitName, expressRouteCircuitResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(circuitName, expressRouteCircuitResourceName)
 
 	if d.IsNewResource() {
@@ -155,7 +157,9 @@ func resourceExpressRouteCircuitAuthorizationDelete(d *pluginsdk.ResourceData, m
 	circuitName := id.Path["expressRouteCircuits"]
 	name := id.Path["authorizations"]
 
-	locks.ByName(circuitName, expressRouteCircuitResourceName)
+	if err := locks.ByNameWithContext(ctx, circuitName, expressRouteCircuitResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(circuitName, expressRouteCircuitResourceName)
 
 	future, err := client.Delete(ctx, resourceGroup, circuitName, name)
@@ -161,7 +161,9 @@ func resourceExpressRouteCircuitCreateUpdate(d *pluginsdk.ResourceData, meta int
 	name := d.Get("name").(string)
 	resGroup := d.Get("resource_group_name").(string)
 
-	locks.ByName(name, expressRouteCircuitResourceName)
+	if err := locks.ByNameWithContext(ctx, name, expressRouteCircuitResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(name, expressRouteCircuitResourceName)
 
 	if d.IsNewResource() {
@@ -346,7 +348,9 @@ func resourceExpressRouteCircuitDelete(d *pluginsdk.ResourceData, meta interface
 	resourceGroup := id.ResourceGroup
 	name := id.Path["expressRouteCircuits"]
 
-	locks.ByName(name, expressRouteCircuitResourceName)
+	if err := locks.ByNameWithContext(ctx, name, expressRouteCircuitResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(name, expressRouteCircuitResourceName)
 
 	future, err := client.Delete(ctx, resourceGroup, name)
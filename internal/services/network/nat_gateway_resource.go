@@ -110,7 +110,9 @@ func resourceNatGatewayCreate(d *pluginsdk.ResourceData, meta interface{}) error
 	name := d.Get("name").(string)
 	resourceGroup := d.Get("resource_group_name").(string)
 
-	locks.ByName(name, natGatewayResourceName)
+	if err := locks.ByNameWithContext(ctx, name, natGatewayResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(name, natGatewayResourceName)
 
 	resp, err := client.Get(ctx, resourceGroup, name, "")
@@ -175,7 +177,9 @@ func resourceNatGatewayUpdate(d *pluginsdk.ResourceData, meta interface{}) error
 		return err
 	}
 
-	locks.ByName(id.Name, natGatewayResourceName)
+	if err := locks.ByNameWithContext(ctx, id.Name, natGatewayResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.Name, natGatewayResourceName)
 
 	existing, err := client.Get(ctx, id.ResourceGroup, id.Name, "")
@@ -226,7 +230,7 @@ func resourceNatGatewayUpdate(d *pluginsdk.ResourceData, meta interface{}) error
 		parameters.NatGatewayPropertiesFormat.PublicIPPrefixes = expandNetworkSubResourceID(publicIpPrefixIds)
 	}
 
-	if d.HasChange("tags") {
+	if d.HasChanges("tags", "tags_all") {
 		t := d.Get("tags").(map[string]interface{})
 		parameters.Tags = tags.Expand(t)
 	}
@@ -303,7 +307,9 @@ func resourceNatGatewayDelete(d *pluginsdk.ResourceData, meta interface{}) error
 		return err
 	}
 
-	locks.ByName(id.Name, natGatewayResourceName)
+	if err := locks.ByNameWithContext(ctx, id.Name, natGatewayResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.Name, natGatewayResourceName)
 
 	future, err := client.Delete(ctx, id.ResourceGroup, id.Name)
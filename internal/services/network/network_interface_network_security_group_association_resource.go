@@ -68,7 +68,9 @@ func resourceNetworkInterfaceSecurityGroupAssociationCreate(d *pluginsdk.Resourc
 	networkInterfaceName := nicId.Path["networkInterfaces"]
 	resourceGroup := nicId.ResourceGroup
 
-	locks.ByName(networkInterfaceName, networkInterfaceResourceName)
+	if err := locks.ByNameWithContext(ctx, networkInterfaceName, networkInterfaceResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(networkInterfaceName, networkInterfaceResourceName)
 
 	nsgId, err := azure.ParseAzureResourceID(networkSecurityGroupId)
@@ -77,7 +79,9 @@ func resourceNetworkInterfaceSecurityGroupAssociationCreate(d *pluginsdk.Resourc
 	}
 	nsgName := nsgId.Path["networkSecurityGroups"]
 
-	locks.ByName(nsgName, networkSecurityGroupResourceName)
+	if err := locks.ByNameWithContext(ctx, nsgName, networkSecurityGroupResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(nsgName, networkSecurityGroupResourceName)
 
 	read, err := client.Get(ctx, resourceGroup, networkInterfaceName, "")
@@ -184,7 +188,9 @@ func resourceNetworkInterfaceSecurityGroupAssociationDelete(d *pluginsdk.Resourc
 	name := nicID.Path["networkInterfaces"]
 	resourceGroup := nicID.ResourceGroup
 
-	locks.ByName(name, networkInterfaceResourceName)
+	if err := locks.ByNameWithContext(ctx, name, networkInterfaceResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(name, networkInterfaceResourceName)
 
 	read, err := client.Get(ctx, resourceGroup, name, "")
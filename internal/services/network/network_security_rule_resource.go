@@ -202,7 +202,9 @@ func resourceNetworkSecurityRuleCreateUpdate(d *pluginsdk.ResourceData, meta int
 	protocol := d.Get("protocol").(string)
 
 	if !meta.(*clients.Client).Features.Network.RelaxedLocking {
-		locks.ByName(nsgName, networkSecurityGroupResourceName)
+		if err := locks.ByNameWithContext(ctx, nsgName, networkSecurityGroupResourceName); err != nil {
+			return err
+		}
 		defer locks.UnlockByName(nsgName, networkSecurityGroupResourceName)
 	}
 
@@ -376,7 +378,9 @@ func resourceNetworkSecurityRuleDelete(d *pluginsdk.ResourceData, meta interface
 	sgRuleName := id.Path["securityRules"]
 
 	if !meta.(*clients.Client).Features.Network.RelaxedLocking {
-		locks.ByName(nsgName, networkSecurityGroupResourceName)
+		if err := locks.ByNameWithContext(ctx, nsgName, networkSecurityGroupResourceName); err != nil {
+			return err
+		}
 		defer locks.UnlockByName(nsgName, networkSecurityGroupResourceName)
 	}
 
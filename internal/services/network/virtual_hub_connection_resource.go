@@ -172,7 +172,9 @@ func resourceVirtualHubConnectionCreateOrUpdate(d *pluginsdk.ResourceData, meta
 		return err
 	}
 
-	locks.ByName(id.Name, virtualHubResourceName)
+	if err := locks.ByNameWithContext(ctx, id.Name, virtualHubResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.Name, virtualHubResourceName)
 
 	remoteVirtualNetworkId, err := parse.VirtualNetworkID(d.Get("remote_virtual_network_id").(string))
@@ -180,7 +182,9 @@ func resourceVirtualHubConnectionCreateOrUpdate(d *pluginsdk.ResourceData, meta
 		return err
 	}
 
-	locks.ByName(remoteVirtualNetworkId.Name, VirtualNetworkResourceName)
+	if err := locks.ByNameWithContext(ctx, remoteVirtualNetworkId.Name, VirtualNetworkResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(remoteVirtualNetworkId.Name, VirtualNetworkResourceName)
 
 	name := d.Get("name").(string)
@@ -287,7 +291,9 @@ func resourceVirtualHubConnectionDelete(d *pluginsdk.ResourceData, meta interfac
 		return err
 	}
 
-	locks.ByName(id.VirtualHubName, virtualHubResourceName)
+	if err := locks.ByNameWithContext(ctx, id.VirtualHubName, virtualHubResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.VirtualHubName, virtualHubResourceName)
 
 	future, err := client.Delete(ctx, id.ResourceGroup, id.VirtualHubName, id.Name)
@@ -116,7 +116,9 @@ func resourceVirtualHubCreateUpdate(d *pluginsdk.ResourceData, meta interface{})
 	name := d.Get("name").(string)
 	resourceGroup := d.Get("resource_group_name").(string)
 
-	locks.ByName(name, virtualHubResourceName)
+	if err := locks.ByNameWithContext(ctx, name, virtualHubResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(name, virtualHubResourceName)
 
 	if d.IsNewResource() {
@@ -247,7 +249,9 @@ func resourceVirtualHubDelete(d *pluginsdk.ResourceData, meta interface{}) error
 		return err
 	}
 
-	locks.ByName(id.Name, virtualHubResourceName)
+	if err := locks.ByNameWithContext(ctx, id.Name, virtualHubResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.Name, virtualHubResourceName)
 
 	future, err := client.Delete(ctx, id.ResourceGroup, id.Name)
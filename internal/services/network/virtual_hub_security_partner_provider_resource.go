@@ -175,7 +175,7 @@ func resourceVirtualHubSecurityPartnerProviderUpdate(d *pluginsdk.ResourceData,
 
 	parameters := network.TagsObject{}
 
-	if d.HasChange("tags") {
+	if d.HasChanges("tags", "tags_all") {
 		parameters.Tags = tags.Expand(d.Get("tags").(map[string]interface{}))
 	}
 
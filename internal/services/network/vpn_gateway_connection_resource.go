@@ -299,7 +299,9 @@ func resourceVpnGatewayConnectionResourceCreateUpdate(d *pluginsdk.ResourceData,
 		}
 	}
 
-	locks.ByName(gatewayId.Name, VPNGatewayResourceName)
+	if err := locks.ByNameWithContext(ctx, gatewayId.Name, VPNGatewayResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(gatewayId.Name, VPNGatewayResourceName)
 
 	param := network.VpnConnection{
@@ -407,7 +409,9 @@ func resourceVpnGatewayConnectionResourceDelete(d *pluginsdk.ResourceData, meta
 		return err
 	}
 
-	locks.ByName(id.VpnGatewayName, VPNGatewayResourceName)
+	if err := locks.ByNameWithContext(ctx, id.VpnGatewayName, VPNGatewayResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.VpnGatewayName, VPNGatewayResourceName)
 
 	future, err := client.Delete(ctx, id.ResourceGroup, id.VpnGatewayName, id.Name)
@@ -267,7 +267,9 @@ func resourceVPNGatewayUpdate(d *pluginsdk.ResourceData, meta interface{}) error
 	name := d.Get("name").(string)
 	resourceGroup := d.Get("resource_group_name").(string)
 
-	locks.ByName(name, VPNGatewayResourceName)
+	if err := locks.ByNameWithContext(ctx, name, VPNGatewayResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(name, VPNGatewayResourceName)
 
 	existing, err := client.Get(ctx, resourceGroup, name)
@@ -278,7 +280,7 @@ func resourceVPNGatewayUpdate(d *pluginsdk.ResourceData, meta interface{}) error
 	if d.HasChange("scale_unit") {
 		existing.VpnGatewayScaleUnit = utils.Int32(int32(d.Get("scale_unit").(int)))
 	}
-	if d.HasChange("tags") {
+	if d.HasChanges("tags", "tags_all") {
 		existing.Tags = tags.Expand(d.Get("tags").(map[string]interface{}))
 	}
 
@@ -4,13 +4,16 @@ import (
 	"github.com/Azure/azure-sdk-for-go/services/postgresql/mgmt/2020-01-01/postgresql"
 	"github.com/Azure/azure-sdk-for-go/services/postgresql/mgmt/2021-06-01/postgresqlflexibleservers"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/postgres/sdk/2022-12-01/administrators"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/postgres/sdk/2022-12-01/servers"
 )
 
 type Client struct {
 	ConfigurationsClient                *postgresql.ConfigurationsClient
 	DatabasesClient                     *postgresql.DatabasesClient
 	FirewallRulesClient                 *postgresql.FirewallRulesClient
-	FlexibleServersClient               *postgresqlflexibleservers.ServersClient
+	FlexibleServersClient               *servers.ServersClient
+	FlexibleServerAdministratorsClient  *administrators.AdministratorsClient
 	FlexibleServersConfigurationsClient *postgresqlflexibleservers.ConfigurationsClient
 	FlexibleServerFirewallRuleClient    *postgresqlflexibleservers.FirewallRulesClient
 	FlexibleServerDatabaseClient        *postgresqlflexibleservers.DatabasesClient
@@ -50,9 +53,12 @@ func NewClient(o *common.ClientOptions) *Client {
 	replicasClient := postgresql.NewReplicasClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
 	o.ConfigureClient(&replicasClient.Client, o.ResourceManagerAuthorizer)
 
-	flexibleServersClient := postgresqlflexibleservers.NewServersClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	flexibleServersClient := servers.NewServersClientWithBaseURI(o.ResourceManagerEndpoint)
 	o.ConfigureClient(&flexibleServersClient.Client, o.ResourceManagerAuthorizer)
 
+	flexibleServerAdministratorsClient := administrators.NewAdministratorsClientWithBaseURI(o.ResourceManagerEndpoint)
+	o.ConfigureClient(&flexibleServerAdministratorsClient.Client, o.ResourceManagerAuthorizer)
+
 	flexibleServerFirewallRuleClient := postgresqlflexibleservers.NewFirewallRulesClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
 	o.ConfigureClient(&flexibleServerFirewallRuleClient.Client, o.ResourceManagerAuthorizer)
 
@@ -68,6 +74,7 @@ func NewClient(o *common.ClientOptions) *Client {
 		FirewallRulesClient:                 &firewallRulesClient,
 		FlexibleServersConfigurationsClient: &flexibleServerConfigurationsClient,
 		FlexibleServersClient:               &flexibleServersClient,
+		FlexibleServerAdministratorsClient:  &flexibleServerAdministratorsClient,
 		FlexibleServerFirewallRuleClient:    &flexibleServerFirewallRuleClient,
 		FlexibleServerDatabaseClient:        &flexibleServerDatabaseClient,
 		ServersClient:                       &serversClient,
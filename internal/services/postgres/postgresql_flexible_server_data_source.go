@@ -4,15 +4,14 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/Azure/azure-sdk-for-go/services/postgresql/mgmt/2021-06-01/postgresqlflexibleservers"
+	"github.com/hashicorp/go-azure-helpers/response"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/azure"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/location"
-	"github.com/hashicorp/terraform-provider-azurerm/internal/services/postgres/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/postgres/sdk/2022-12-01/servers"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
-	"github.com/hashicorp/terraform-provider-azurerm/utils"
 )
 
 func dataSourcePostgresqlFlexibleServer() *pluginsdk.Resource {
@@ -90,52 +89,61 @@ func dataSourceArmPostgresqlFlexibleServerRead(d *pluginsdk.ResourceData, meta i
 	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
 	defer cancel()
 
-	name := d.Get("name").(string)
-	resourceGroup := d.Get("resource_group_name").(string)
+	id := servers.NewFlexibleServerID(subscriptionId, d.Get("resource_group_name").(string), d.Get("name").(string))
 
-	id := parse.NewFlexibleServerID(subscriptionId, resourceGroup, name)
-
-	resp, err := client.Get(ctx, id.ResourceGroup, id.Name)
+	resp, err := client.Get(ctx, id)
 	if err != nil {
-		if utils.ResponseWasNotFound(resp.Response) {
-			return fmt.Errorf("Postgresqlflexibleservers Server %q does not exist", id.Name)
+		if response.WasNotFound(resp.HttpResponse) {
+			return fmt.Errorf("%s was not found", id)
 		}
-		return fmt.Errorf("retrieving Postgresqlflexibleservers Server %q (Resource Group %q): %+v", id.Name, id.ResourceGroup, err)
+		return fmt.Errorf("retrieving %s: %+v", id, err)
 	}
 
 	d.SetId(id.ID())
 	d.Set("name", id.Name)
 	d.Set("resource_group_name", id.ResourceGroup)
-	d.Set("location", location.NormalizeNilable(resp.Location))
 
 	// `cmk_enabled` has been removed from API since 2021-06-01
 	// and should be removed in version 3.0 of the provider.
 	d.Set("cmk_enabled", "")
 
-	if props := resp.ServerProperties; props != nil {
-		d.Set("administrator_login", props.AdministratorLogin)
-		d.Set("version", props.Version)
-		d.Set("fqdn", props.FullyQualifiedDomainName)
+	if model := resp.Model; model != nil {
+		d.Set("location", location.Normalize(model.Location))
 
-		if storage := props.Storage; storage != nil && storage.StorageSizeGB != nil {
-			d.Set("storage_mb", (*props.Storage.StorageSizeGB * 1024))
-		}
+		if props := model.Properties; props != nil {
+			d.Set("administrator_login", props.AdministratorLogin)
+			d.Set("fqdn", props.FullyQualifiedDomainName)
+
+			version := ""
+			if props.Version != nil {
+				version = string(*props.Version)
+			}
+			d.Set("version", version)
+
+			if storage := props.Storage; storage != nil && storage.StorageSizeGB != nil {
+				d.Set("storage_mb", (*storage.StorageSizeGB * 1024))
+			}
 
-		if backup := props.Backup; backup != nil {
-			d.Set("backup_retention_days", props.Backup.BackupRetentionDays)
+			if backup := props.Backup; backup != nil {
+				d.Set("backup_retention_days", backup.BackupRetentionDays)
+			}
+
+			if network := props.Network; network != nil {
+				d.Set("delegated_subnet_id", network.DelegatedSubnetResourceId)
+				d.Set("public_network_access_enabled", network.PublicNetworkAccess != nil && *network.PublicNetworkAccess == servers.ServerPublicNetworkAccessStateEnabled)
+			}
 		}
 
-		if network := props.Network; network != nil {
-			d.Set("delegated_subnet_id", network.DelegatedSubnetResourceID)
-			d.Set("public_network_access_enabled", network.PublicNetworkAccess == postgresqlflexibleservers.ServerPublicNetworkAccessStateEnabled)
+		sku, err := flattenFlexibleServerSku(model.Sku)
+		if err != nil {
+			return fmt.Errorf("flattening `sku_name` for %s: %v", id, err)
 		}
-	}
+		d.Set("sku_name", sku)
 
-	sku, err := flattenFlexibleServerSku(resp.Sku)
-	if err != nil {
-		return fmt.Errorf("flattening `sku_name` for PostgreSQL Flexible Server %s (Resource Group %q): %v", id.Name, id.ResourceGroup, err)
+		if err := tags.FlattenAndSet(d, flattenTags(model.Tags)); err != nil {
+			return fmt.Errorf("setting `tags`: %+v", err)
+		}
 	}
 
-	d.Set("sku_name", sku)
-	return tags.FlattenAndSet(d, resp.Tags)
+	return nil
 }
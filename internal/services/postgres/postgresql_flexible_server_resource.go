@@ -6,14 +6,16 @@ import (
 	"strings"
 	"time"
 
-	"github.com/Azure/azure-sdk-for-go/services/postgresql/mgmt/2021-06-01/postgresqlflexibleservers"
-	"github.com/Azure/go-autorest/autorest/date"
+	"github.com/hashicorp/go-azure-helpers/response"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/azure"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/identity"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/location"
+	keyVaultValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/validate"
+	msiValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/msi/validate"
 	networkValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/network/validate"
-	"github.com/hashicorp/terraform-provider-azurerm/internal/services/postgres/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/postgres/sdk/2022-12-01/servers"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/postgres/validate"
 	privateDnsValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/privatedns/validate"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
@@ -28,6 +30,8 @@ const (
 	ServerMaintenanceWindowDisabled = "Disabled"
 )
 
+type postgresqlFlexibleServerIdentityType = identity.UserAssigned
+
 func resourcePostgresqlFlexibleServer() *pluginsdk.Resource {
 	return &pluginsdk.Resource{
 		Create: resourcePostgresqlFlexibleServerCreate,
@@ -43,7 +47,7 @@ func resourcePostgresqlFlexibleServer() *pluginsdk.Resource {
 		},
 
 		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
-			_, err := parse.FlexibleServerID(id)
+			_, err := servers.ParseFlexibleServerID(id)
 			return err
 		}),
 
@@ -74,6 +78,35 @@ func resourcePostgresqlFlexibleServer() *pluginsdk.Resource {
 				ValidateFunc: validation.StringIsNotEmpty,
 			},
 
+			"authentication": {
+				Type:     pluginsdk.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"active_directory_auth_enabled": {
+							Type:     pluginsdk.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+
+						"password_auth_enabled": {
+							Type:     pluginsdk.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+
+						"tenant_id": {
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validation.IsUUID,
+						},
+					},
+				},
+			},
+
 			"sku_name": {
 				Type:         pluginsdk.TypeString,
 				Optional:     true,
@@ -94,9 +127,10 @@ func resourcePostgresqlFlexibleServer() *pluginsdk.Resource {
 				Computed: true,
 				ForceNew: true,
 				ValidateFunc: validation.StringInSlice([]string{
-					string(postgresqlflexibleservers.ServerVersionOneOne),
-					string(postgresqlflexibleservers.ServerVersionOneTwo),
-					string(postgresqlflexibleservers.ServerVersionOneThree),
+					string(servers.ServerVersionOneOne),
+					string(servers.ServerVersionOneTwo),
+					string(servers.ServerVersionOneThree),
+					string(servers.ServerVersionOneFour),
 				}, false),
 			},
 
@@ -107,8 +141,10 @@ func resourcePostgresqlFlexibleServer() *pluginsdk.Resource {
 				Optional: true,
 				ForceNew: true,
 				ValidateFunc: validation.StringInSlice([]string{
-					string(postgresqlflexibleservers.CreateModeDefault),
-					string(postgresqlflexibleservers.CreateModePointInTimeRestore),
+					string(servers.CreateModeDefault),
+					string(servers.CreateModeGeoRestore),
+					string(servers.CreateModePointInTimeRestore),
+					string(servers.CreateModeReplica),
 				}, false),
 			},
 
@@ -181,6 +217,13 @@ func resourcePostgresqlFlexibleServer() *pluginsdk.Resource {
 				ValidateFunc: validation.IntBetween(7, 35),
 			},
 
+			"geo_redundant_backup_enabled": {
+				Type:     pluginsdk.TypeBool,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
 			"high_availability": {
 				Type:     pluginsdk.TypeList,
 				Optional: true,
@@ -191,7 +234,8 @@ func resourcePostgresqlFlexibleServer() *pluginsdk.Resource {
 							Type:     pluginsdk.TypeString,
 							Required: true,
 							ValidateFunc: validation.StringInSlice([]string{
-								string(postgresqlflexibleservers.HighAvailabilityModeZoneRedundant),
+								string(servers.HighAvailabilityModeSameZone),
+								string(servers.HighAvailabilityModeZoneRedundant),
 							}, false),
 						},
 						"standby_availability_zone": azure.SchemaZoneComputed(),
@@ -199,6 +243,60 @@ func resourcePostgresqlFlexibleServer() *pluginsdk.Resource {
 				},
 			},
 
+			"identity": postgresqlFlexibleServerIdentityType{}.Schema(),
+
+			"customer_managed_key": {
+				Type:         pluginsdk.TypeList,
+				Optional:     true,
+				ForceNew:     true,
+				MaxItems:     1,
+				RequiredWith: []string{"identity"},
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"key_vault_key_id": {
+							Type:         pluginsdk.TypeString,
+							Required:     true,
+							ValidateFunc: keyVaultValidate.NestedItemId,
+						},
+
+						"primary_user_assigned_identity_id": {
+							Type:         pluginsdk.TypeString,
+							Required:     true,
+							ValidateFunc: msiValidate.UserAssignedIdentityID,
+						},
+
+						"geo_backup_key_vault_key_id": {
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							ValidateFunc: keyVaultValidate.NestedItemId,
+							RequiredWith: []string{"customer_managed_key.0.geo_backup_user_assigned_identity_id"},
+						},
+
+						"geo_backup_user_assigned_identity_id": {
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							ValidateFunc: msiValidate.UserAssignedIdentityID,
+							RequiredWith: []string{"customer_managed_key.0.geo_backup_key_vault_key_id"},
+						},
+					},
+				},
+			},
+
+			"replication_role": {
+				Type:     pluginsdk.TypeString,
+				Optional: true,
+				Computed: true,
+				// a replica can only be promoted to a standalone server, which is done by setting this to `None`
+				ValidateFunc: validation.StringInSlice([]string{
+					string(servers.ReplicationRoleNone),
+				}, false),
+			},
+
+			"replica_capacity": {
+				Type:     pluginsdk.TypeInt,
+				Computed: true,
+			},
+
 			"cmk_enabled": {
 				Type:       pluginsdk.TypeString,
 				Computed:   true,
@@ -219,44 +317,51 @@ func resourcePostgresqlFlexibleServer() *pluginsdk.Resource {
 		},
 	}
 }
+
 func resourcePostgresqlFlexibleServerCreate(d *pluginsdk.ResourceData, meta interface{}) error {
 	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
 	client := meta.(*clients.Client).Postgres.FlexibleServersClient
 	ctx, cancel := timeouts.ForCreate(meta.(*clients.Client).StopContext, d)
 	defer cancel()
 
-	name := d.Get("name").(string)
-	resourceGroup := d.Get("resource_group_name").(string)
-
-	id := parse.NewFlexibleServerID(subscriptionId, resourceGroup, name)
+	id := servers.NewFlexibleServerID(subscriptionId, d.Get("resource_group_name").(string), d.Get("name").(string))
 
-	existing, err := client.Get(ctx, id.ResourceGroup, id.Name)
+	existing, err := client.Get(ctx, id)
 	if err != nil {
-		if !utils.ResponseWasNotFound(existing.Response) {
-			return fmt.Errorf("checking for present of existing Postgresql Flexible Server %q (Resource Group %q): %+v", id.Name, id.ResourceGroup, err)
+		if !response.WasNotFound(existing.HttpResponse) {
+			return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
 		}
 	}
-	if !utils.ResponseWasNotFound(existing.Response) {
+	if existing.Model != nil {
 		return tf.ImportAsExistsError("azurerm_postgresql_flexible_server", id.ID())
 	}
 
-	createMode := d.Get("create_mode").(string)
+	createMode := servers.CreateMode(d.Get("create_mode").(string))
+	authConfig := expandFlexibleServerAuthConfig(d.Get("authentication").([]interface{}))
 
-	if postgresqlflexibleservers.CreateMode(createMode) == postgresqlflexibleservers.CreateModePointInTimeRestore {
+	switch createMode {
+	case servers.CreateModePointInTimeRestore:
 		if _, ok := d.GetOk("source_server_id"); !ok {
 			return fmt.Errorf("`source_server_id` is required when `create_mode` is `PointInTimeRestore`")
 		}
 		if _, ok := d.GetOk("point_in_time_restore_time_in_utc"); !ok {
 			return fmt.Errorf("`point_in_time_restore_time_in_utc` is required when `create_mode` is `PointInTimeRestore`")
 		}
-	}
 
-	if createMode == "" || postgresqlflexibleservers.CreateMode(createMode) == postgresqlflexibleservers.CreateModeDefault {
-		if _, ok := d.GetOk("administrator_login"); !ok {
-			return fmt.Errorf("`administrator_login` is required when `create_mode` is `Default`")
+	case servers.CreateModeGeoRestore, servers.CreateModeReplica:
+		if _, ok := d.GetOk("source_server_id"); !ok {
+			return fmt.Errorf("`source_server_id` is required when `create_mode` is `%s`", string(createMode))
 		}
-		if _, ok := d.GetOk("administrator_password"); !ok {
-			return fmt.Errorf("`administrator_password` is required when `create_mode` is `Default`")
+
+	case "", servers.CreateModeDefault:
+		// the administrator credentials are only needed when password authentication is enabled
+		if *authConfig.PasswordAuth == servers.PasswordAuthEnumEnabled {
+			if _, ok := d.GetOk("administrator_login"); !ok {
+				return fmt.Errorf("`administrator_login` is required when `create_mode` is `Default` and password authentication is enabled")
+			}
+			if _, ok := d.GetOk("administrator_password"); !ok {
+				return fmt.Errorf("`administrator_password` is required when `create_mode` is `Default` and password authentication is enabled")
+			}
 		}
 		if _, ok := d.GetOk("sku_name"); !ok {
 			return fmt.Errorf("`sku_name` is required when `create_mode` is `Default`")
@@ -271,37 +376,58 @@ func resourcePostgresqlFlexibleServerCreate(d *pluginsdk.ResourceData, meta inte
 
 	sku, err := expandFlexibleServerSku(d.Get("sku_name").(string))
 	if err != nil {
-		return fmt.Errorf("expanding `sku_name` for PostgreSQL Flexible Server %s (Resource Group %q): %v", id.Name, id.ResourceGroup, err)
+		return fmt.Errorf("expanding `sku_name` for %s: %v", id, err)
+	}
+
+	expandedIdentity, err := expandFlexibleServerIdentity(d.Get("identity").([]interface{}))
+	if err != nil {
+		return fmt.Errorf("expanding `identity`: %+v", err)
+	}
+
+	backup := expandArmServerBackup(d)
+	if d.Get("geo_redundant_backup_enabled").(bool) {
+		geoRedundantBackup := servers.GeoRedundantBackupEnumEnabled
+		backup.GeoRedundantBackup = &geoRedundantBackup
 	}
 
-	parameters := postgresqlflexibleservers.Server{
-		Location: utils.String(location.Normalize(d.Get("location").(string))),
-		ServerProperties: &postgresqlflexibleservers.ServerProperties{
-			CreateMode:       postgresqlflexibleservers.CreateMode(d.Get("create_mode").(string)),
+	parameters := servers.Server{
+		Location: location.Normalize(d.Get("location").(string)),
+		Identity: expandedIdentity,
+		Properties: &servers.ServerProperties{
+			AuthConfig:       authConfig,
 			Network:          expandArmServerNetwork(d),
-			Version:          postgresqlflexibleservers.ServerVersion(d.Get("version").(string)),
 			Storage:          expandArmServerStorage(d),
 			HighAvailability: expandFlexibleServerHighAvailability(d.Get("high_availability").([]interface{})),
-			Backup:           expandArmServerBackup(d),
+			Backup:           backup,
+			DataEncryption:   expandFlexibleServerDataEncryption(d.Get("customer_managed_key").([]interface{})),
 		},
 		Sku:  sku,
-		Tags: tags.Expand(d.Get("tags").(map[string]interface{})),
+		Tags: expandTags(d.Get("tags").(map[string]interface{})),
+	}
+
+	if createMode != "" {
+		parameters.Properties.CreateMode = &createMode
+	}
+
+	if v, ok := d.GetOk("version"); ok && v.(string) != "" {
+		version := servers.ServerVersion(v.(string))
+		parameters.Properties.Version = &version
 	}
 
 	if v, ok := d.GetOk("administrator_login"); ok && v.(string) != "" {
-		parameters.ServerProperties.AdministratorLogin = utils.String(v.(string))
+		parameters.Properties.AdministratorLogin = utils.String(v.(string))
 	}
 
 	if v, ok := d.GetOk("administrator_password"); ok && v.(string) != "" {
-		parameters.ServerProperties.AdministratorLoginPassword = utils.String(v.(string))
+		parameters.Properties.AdministratorLoginPassword = utils.String(v.(string))
 	}
 
 	if v, ok := d.GetOk("zone"); ok && v.(string) != "" {
-		parameters.ServerProperties.AvailabilityZone = utils.String(v.(string))
+		parameters.Properties.AvailabilityZone = utils.String(v.(string))
 	}
 
 	if v, ok := d.GetOk("source_server_id"); ok && v.(string) != "" {
-		parameters.SourceServerResourceID = utils.String(v.(string))
+		parameters.Properties.SourceServerResourceId = utils.String(v.(string))
 	}
 
 	pointInTimeUTC := d.Get("point_in_time_restore_time_in_utc").(string)
@@ -310,32 +436,22 @@ func resourcePostgresqlFlexibleServerCreate(d *pluginsdk.ResourceData, meta inte
 		if err != nil {
 			return fmt.Errorf("unable to parse `point_in_time_restore_time_in_utc` value")
 		}
-		parameters.ServerProperties.PointInTimeUTC = &date.Time{Time: v}
-	}
-
-	future, err := client.Create(ctx, id.ResourceGroup, id.Name, parameters)
-	if err != nil {
-		return fmt.Errorf("creating Postgresql Flexible Server %q (Resource Group %q): %+v", id.Name, id.ResourceGroup, err)
+		parameters.Properties.PointInTimeUTC = utils.String(v.Format(time.RFC3339))
 	}
 
-	if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
-		return fmt.Errorf("waiting for creation of the Postgresql Flexible Server %q (Resource Group %q): %+v", id.Name, id.ResourceGroup, err)
+	if err := client.CreateThenPoll(ctx, id, parameters); err != nil {
+		return fmt.Errorf("creating %s: %+v", id, err)
 	}
 
 	// `maintenance_window` could only be updated with, could not be created with
 	if v, ok := d.GetOk("maintenance_window"); ok {
-		mwParams := postgresqlflexibleservers.ServerForUpdate{
-			ServerPropertiesForUpdate: &postgresqlflexibleservers.ServerPropertiesForUpdate{
+		mwParams := servers.ServerForUpdate{
+			Properties: &servers.ServerPropertiesForUpdate{
 				MaintenanceWindow: expandArmServerMaintenanceWindow(v.([]interface{})),
 			},
 		}
-		mwFuture, err := client.Update(ctx, id.ResourceGroup, id.Name, mwParams)
-		if err != nil {
-			return fmt.Errorf("updating Postgresql Flexible Server %q maintenance window (Resource Group %q): %+v", id.Name, id.ResourceGroup, err)
-		}
-
-		if err := mwFuture.WaitForCompletionRef(ctx, client.Client); err != nil {
-			return fmt.Errorf("waiting for the update of the Postgresql Flexible Server %q maintenance window (Resource Group %q): %+v", id.Name, id.ResourceGroup, err)
+		if err := client.UpdateThenPoll(ctx, id, mwParams); err != nil {
+			return fmt.Errorf("updating the maintenance window for %s: %+v", id, err)
 		}
 	}
 
@@ -349,66 +465,98 @@ func resourcePostgresqlFlexibleServerRead(d *pluginsdk.ResourceData, meta interf
 	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
 	defer cancel()
 
-	id, err := parse.FlexibleServerID(d.Id())
+	id, err := servers.ParseFlexibleServerID(d.Id())
 	if err != nil {
 		return err
 	}
 
-	resp, err := client.Get(ctx, id.ResourceGroup, id.Name)
+	resp, err := client.Get(ctx, *id)
 	if err != nil {
-		if utils.ResponseWasNotFound(resp.Response) {
-			log.Printf("[INFO] Postgresql Flexibleserver %q does not exist - removing from state", d.Id())
+		if response.WasNotFound(resp.HttpResponse) {
+			log.Printf("[INFO] %s does not exist - removing from state", *id)
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("retrieving Postgresql Flexible Server %q (Resource Group %q): %+v", id.Name, id.ResourceGroup, err)
+		return fmt.Errorf("retrieving %s: %+v", *id, err)
 	}
 
 	d.Set("name", id.Name)
 	d.Set("resource_group_name", id.ResourceGroup)
-	d.Set("location", location.NormalizeNilable(resp.Location))
 
 	// `cmk_enabled` has been removed from API since 2021-06-01
 	// and should be removed in version 3.0 of the provider.
 	d.Set("cmk_enabled", "")
 
-	if props := resp.ServerProperties; props != nil {
-		d.Set("administrator_login", props.AdministratorLogin)
-		d.Set("zone", props.AvailabilityZone)
-		d.Set("version", props.Version)
-		d.Set("fqdn", props.FullyQualifiedDomainName)
-
-		if network := props.Network; network != nil {
-			d.Set("public_network_access_enabled", network.PublicNetworkAccess == postgresqlflexibleservers.ServerPublicNetworkAccessStateEnabled)
-			d.Set("delegated_subnet_id", network.DelegatedSubnetResourceID)
-			d.Set("private_dns_zone_id", network.PrivateDNSZoneArmResourceID)
-		}
-
-		if err := d.Set("maintenance_window", flattenArmServerMaintenanceWindow(props.MaintenanceWindow)); err != nil {
-			return fmt.Errorf("setting `maintenance_window`: %+v", err)
+	if model := resp.Model; model != nil {
+		d.Set("location", location.Normalize(model.Location))
+
+		if props := model.Properties; props != nil {
+			d.Set("administrator_login", props.AdministratorLogin)
+			d.Set("zone", props.AvailabilityZone)
+			d.Set("fqdn", props.FullyQualifiedDomainName)
+			d.Set("replica_capacity", props.ReplicaCapacity)
+
+			version := ""
+			if props.Version != nil {
+				version = string(*props.Version)
+			}
+			d.Set("version", version)
+
+			replicationRole := ""
+			if props.ReplicationRole != nil {
+				replicationRole = string(*props.ReplicationRole)
+			}
+			d.Set("replication_role", replicationRole)
+
+			if network := props.Network; network != nil {
+				publicNetworkAccess := network.PublicNetworkAccess != nil && *network.PublicNetworkAccess == servers.ServerPublicNetworkAccessStateEnabled
+				d.Set("public_network_access_enabled", publicNetworkAccess)
+				d.Set("delegated_subnet_id", network.DelegatedSubnetResourceId)
+				d.Set("private_dns_zone_id", network.PrivateDnsZoneArmResourceId)
+			}
+
+			if err := d.Set("authentication", flattenFlexibleServerAuthConfig(props.AuthConfig)); err != nil {
+				return fmt.Errorf("setting `authentication`: %+v", err)
+			}
+
+			if err := d.Set("maintenance_window", flattenArmServerMaintenanceWindow(props.MaintenanceWindow)); err != nil {
+				return fmt.Errorf("setting `maintenance_window`: %+v", err)
+			}
+
+			if storage := props.Storage; storage != nil && storage.StorageSizeGB != nil {
+				d.Set("storage_mb", (*storage.StorageSizeGB * 1024))
+			}
+
+			if backup := props.Backup; backup != nil {
+				d.Set("backup_retention_days", backup.BackupRetentionDays)
+				d.Set("geo_redundant_backup_enabled", backup.GeoRedundantBackup != nil && *backup.GeoRedundantBackup == servers.GeoRedundantBackupEnumEnabled)
+			}
+
+			if err := d.Set("high_availability", flattenFlexibleServerHighAvailability(props.HighAvailability)); err != nil {
+				return fmt.Errorf("setting `high_availability`: %+v", err)
+			}
+
+			if err := d.Set("customer_managed_key", flattenFlexibleServerDataEncryption(props.DataEncryption)); err != nil {
+				return fmt.Errorf("setting `customer_managed_key`: %+v", err)
+			}
 		}
 
-		if storage := props.Storage; storage != nil && storage.StorageSizeGB != nil {
-			d.Set("storage_mb", (*storage.StorageSizeGB * 1024))
+		if err := d.Set("identity", flattenFlexibleServerIdentity(model.Identity)); err != nil {
+			return fmt.Errorf("setting `identity`: %+v", err)
 		}
 
-		if backup := props.Backup; backup != nil {
-			d.Set("backup_retention_days", backup.BackupRetentionDays)
+		sku, err := flattenFlexibleServerSku(model.Sku)
+		if err != nil {
+			return fmt.Errorf("flattening `sku_name` for %s: %v", *id, err)
 		}
+		d.Set("sku_name", sku)
 
-		if err := d.Set("high_availability", flattenFlexibleServerHighAvailability(props.HighAvailability)); err != nil {
-			return fmt.Errorf("setting `high_availability`: %+v", err)
+		if err := tags.FlattenAndSet(d, flattenTags(model.Tags)); err != nil {
+			return fmt.Errorf("setting `tags`: %+v", err)
 		}
 	}
 
-	sku, err := flattenFlexibleServerSku(resp.Sku)
-	if err != nil {
-		return fmt.Errorf("flattening `sku_name` for PostgreSQL Flexible Server %s (Resource Group %q): %v", id.Name, id.ResourceGroup, err)
-	}
-
-	d.Set("sku_name", sku)
-
-	return tags.FlattenAndSet(d, resp.Tags)
+	return nil
 }
 
 func resourcePostgresqlFlexibleServerUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
@@ -416,56 +564,78 @@ func resourcePostgresqlFlexibleServerUpdate(d *pluginsdk.ResourceData, meta inte
 	ctx, cancel := timeouts.ForUpdate(meta.(*clients.Client).StopContext, d)
 	defer cancel()
 
-	id, err := parse.FlexibleServerID(d.Id())
+	id, err := servers.ParseFlexibleServerID(d.Id())
 	if err != nil {
 		return err
 	}
 
-	parameters := postgresqlflexibleservers.ServerForUpdate{
-		Location:                  utils.String(location.Normalize(d.Get("location").(string))),
-		ServerPropertiesForUpdate: &postgresqlflexibleservers.ServerPropertiesForUpdate{},
+	// promoting a replica has to be done on its own, before anything else is changed
+	if d.HasChange("replication_role") {
+		if role := servers.ReplicationRole(d.Get("replication_role").(string)); role == servers.ReplicationRoleNone {
+			parameters := servers.ServerForUpdate{
+				Properties: &servers.ServerPropertiesForUpdate{
+					ReplicationRole: &role,
+				},
+			}
+
+			if err := client.UpdateThenPoll(ctx, *id, parameters); err != nil {
+				return fmt.Errorf("promoting %s: %+v", *id, err)
+			}
+		}
+	}
+
+	parameters := servers.ServerForUpdate{
+		Properties: &servers.ServerPropertiesForUpdate{},
 	}
 
 	if d.HasChange("administrator_password") {
-		parameters.ServerPropertiesForUpdate.AdministratorLoginPassword = utils.String(d.Get("administrator_password").(string))
+		parameters.Properties.AdministratorLoginPassword = utils.String(d.Get("administrator_password").(string))
+	}
+
+	if d.HasChange("authentication") {
+		parameters.Properties.AuthConfig = expandFlexibleServerAuthConfig(d.Get("authentication").([]interface{}))
 	}
 
 	if d.HasChange("storage_mb") {
-		parameters.ServerPropertiesForUpdate.Storage = expandArmServerStorage(d)
+		parameters.Properties.Storage = expandArmServerStorage(d)
 	}
 
 	if d.HasChange("backup_retention_days") {
-		parameters.ServerPropertiesForUpdate.Backup = expandArmServerBackup(d)
+		parameters.Properties.Backup = expandArmServerBackup(d)
 	}
 
 	if d.HasChange("maintenance_window") {
-		parameters.ServerPropertiesForUpdate.MaintenanceWindow = expandArmServerMaintenanceWindow(d.Get("maintenance_window").([]interface{}))
+		parameters.Properties.MaintenanceWindow = expandArmServerMaintenanceWindow(d.Get("maintenance_window").([]interface{}))
 	}
 
 	if d.HasChange("sku_name") {
 		sku, err := expandFlexibleServerSku(d.Get("sku_name").(string))
 		if err != nil {
-			return fmt.Errorf("expanding `sku_name` for PostgreSQL Flexible Server %s (Resource Group %q): %v", id.Name, id.ResourceGroup, err)
+			return fmt.Errorf("expanding `sku_name` for %s: %v", *id, err)
 		}
 		parameters.Sku = sku
 	}
 
-	if d.HasChange("tags") {
-		parameters.Tags = tags.Expand(d.Get("tags").(map[string]interface{}))
+	if d.HasChange("identity") {
+		expandedIdentity, err := expandFlexibleServerIdentity(d.Get("identity").([]interface{}))
+		if err != nil {
+			return fmt.Errorf("expanding `identity`: %+v", err)
+		}
+		parameters.Identity = expandedIdentity
 	}
 
-	if d.HasChange("high_availability") {
-		parameters.HighAvailability = expandFlexibleServerHighAvailability(d.Get("high_availability").([]interface{}))
+	if d.HasChanges("tags", "tags_all") {
+		parameters.Tags = expandTags(d.Get("tags").(map[string]interface{}))
 	}
 
-	future, err := client.Update(ctx, id.ResourceGroup, id.Name, parameters)
-	if err != nil {
-		return fmt.Errorf("updating Postgresql Flexible Server %q (Resource Group %q): %+v", id.Name, id.ResourceGroup, err)
+	if d.HasChange("high_availability") {
+		parameters.Properties.HighAvailability = expandFlexibleServerHighAvailability(d.Get("high_availability").([]interface{}))
 	}
 
-	if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
-		return fmt.Errorf("waiting for the update of the Postgresql Flexible Server %q (Resource Group %q): %+v", id.Name, id.ResourceGroup, err)
+	if err := client.UpdateThenPoll(ctx, *id, parameters); err != nil {
+		return fmt.Errorf("updating %s: %+v", *id, err)
 	}
+
 	return resourcePostgresqlFlexibleServerRead(d, meta)
 }
 
@@ -474,133 +644,128 @@ func resourcePostgresqlFlexibleServerDelete(d *pluginsdk.ResourceData, meta inte
 	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
 	defer cancel()
 
-	id, err := parse.FlexibleServerID(d.Id())
+	id, err := servers.ParseFlexibleServerID(d.Id())
 	if err != nil {
 		return err
 	}
 
-	future, err := client.Delete(ctx, id.ResourceGroup, id.Name)
-	if err != nil {
-		return fmt.Errorf("deleting Postgresql Flexible Server %q (Resource Group %q): %+v", id.Name, id.ResourceGroup, err)
-	}
-
-	if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
-		return fmt.Errorf("waiting for the deletion of the Postgresql Flexible Server %q (Resource Group %q): %+v", id.Name, id.ResourceGroup, err)
+	if err := client.DeleteThenPoll(ctx, *id); err != nil {
+		return fmt.Errorf("deleting %s: %+v", *id, err)
 	}
 
 	return nil
 }
 
-func expandArmServerNetwork(d *pluginsdk.ResourceData) *postgresqlflexibleservers.Network {
-	network := postgresqlflexibleservers.Network{}
+func expandArmServerNetwork(d *pluginsdk.ResourceData) *servers.Network {
+	network := servers.Network{}
 
 	if v, ok := d.GetOk("delegated_subnet_id"); ok {
-		network.DelegatedSubnetResourceID = utils.String(v.(string))
+		network.DelegatedSubnetResourceId = utils.String(v.(string))
 	}
 
 	if v, ok := d.GetOk("private_dns_zone_id"); ok {
-		network.PrivateDNSZoneArmResourceID = utils.String(v.(string))
+		network.PrivateDnsZoneArmResourceId = utils.String(v.(string))
 	}
 
 	return &network
 }
 
-func expandArmServerMaintenanceWindow(input []interface{}) *postgresqlflexibleservers.MaintenanceWindow {
+func expandArmServerMaintenanceWindow(input []interface{}) *servers.MaintenanceWindow {
 	if len(input) == 0 {
-		return &postgresqlflexibleservers.MaintenanceWindow{
+		return &servers.MaintenanceWindow{
 			CustomWindow: utils.String(ServerMaintenanceWindowDisabled),
 		}
 	}
 	v := input[0].(map[string]interface{})
 
-	maintenanceWindow := postgresqlflexibleservers.MaintenanceWindow{
+	maintenanceWindow := servers.MaintenanceWindow{
 		CustomWindow: utils.String(ServerMaintenanceWindowEnabled),
-		StartHour:    utils.Int32(int32(v["start_hour"].(int))),
-		StartMinute:  utils.Int32(int32(v["start_minute"].(int))),
-		DayOfWeek:    utils.Int32(int32(v["day_of_week"].(int))),
+		StartHour:    utils.Int64(int64(v["start_hour"].(int))),
+		StartMinute:  utils.Int64(int64(v["start_minute"].(int))),
+		DayOfWeek:    utils.Int64(int64(v["day_of_week"].(int))),
 	}
 
 	return &maintenanceWindow
 }
 
-func expandArmServerStorage(d *pluginsdk.ResourceData) *postgresqlflexibleservers.Storage {
-	storage := postgresqlflexibleservers.Storage{}
+func expandArmServerStorage(d *pluginsdk.ResourceData) *servers.Storage {
+	storage := servers.Storage{}
 
 	if v, ok := d.GetOk("storage_mb"); ok {
-		storage.StorageSizeGB = utils.Int32(int32(v.(int) / 1024))
+		storage.StorageSizeGB = utils.Int64(int64(v.(int) / 1024))
 	}
 
 	return &storage
 }
 
-func expandArmServerBackup(d *pluginsdk.ResourceData) *postgresqlflexibleservers.Backup {
-	backup := postgresqlflexibleservers.Backup{}
+func expandArmServerBackup(d *pluginsdk.ResourceData) *servers.Backup {
+	backup := servers.Backup{}
 
 	if v, ok := d.GetOk("backup_retention_days"); ok {
-		backup.BackupRetentionDays = utils.Int32(int32(v.(int)))
+		backup.BackupRetentionDays = utils.Int64(int64(v.(int)))
 	}
 
 	return &backup
 }
 
-func expandFlexibleServerSku(name string) (*postgresqlflexibleservers.Sku, error) {
+func expandFlexibleServerSku(name string) (*servers.Sku, error) {
 	if name == "" {
 		return nil, nil
 	}
 	parts := strings.SplitAfterN(name, "_", 2)
 
-	var tier postgresqlflexibleservers.SkuTier
+	var tier servers.SkuTier
 	switch strings.TrimSuffix(parts[0], "_") {
 	case "B":
-		tier = postgresqlflexibleservers.SkuTierBurstable
+		tier = servers.SkuTierBurstable
 	case "GP":
-		tier = postgresqlflexibleservers.SkuTierGeneralPurpose
+		tier = servers.SkuTierGeneralPurpose
 	case "MO":
-		tier = postgresqlflexibleservers.SkuTierMemoryOptimized
+		tier = servers.SkuTierMemoryOptimized
 	default:
 		return nil, fmt.Errorf("sku_name %s has unknown sku tier %s", name, parts[0])
 	}
 
-	return &postgresqlflexibleservers.Sku{
-		Name: utils.String(parts[1]),
+	return &servers.Sku{
+		Name: parts[1],
 		Tier: tier,
 	}, nil
 }
 
-func flattenFlexibleServerSku(sku *postgresqlflexibleservers.Sku) (string, error) {
-	if sku == nil || sku.Name == nil || sku.Tier == "" {
+func flattenFlexibleServerSku(sku *servers.Sku) (string, error) {
+	if sku == nil || sku.Name == "" || sku.Tier == "" {
 		return "", nil
 	}
 
 	var tier string
 	switch sku.Tier {
-	case postgresqlflexibleservers.SkuTierBurstable:
+	case servers.SkuTierBurstable:
 		tier = "B"
-	case postgresqlflexibleservers.SkuTierGeneralPurpose:
+	case servers.SkuTierGeneralPurpose:
 		tier = "GP"
-	case postgresqlflexibleservers.SkuTierMemoryOptimized:
+	case servers.SkuTierMemoryOptimized:
 		tier = "MO"
 	default:
 		return "", fmt.Errorf("sku_name has unknown sku tier %s", sku.Tier)
 	}
 
-	return strings.Join([]string{tier, *sku.Name}, "_"), nil
+	return strings.Join([]string{tier, sku.Name}, "_"), nil
 }
 
-func flattenArmServerMaintenanceWindow(input *postgresqlflexibleservers.MaintenanceWindow) []interface{} {
+func flattenArmServerMaintenanceWindow(input *servers.MaintenanceWindow) []interface{} {
 	if input == nil || input.CustomWindow == nil || *input.CustomWindow == string(ServerMaintenanceWindowDisabled) {
 		return make([]interface{}, 0)
 	}
 
-	var dayOfWeek int32
+	var dayOfWeek int64
 	if input.DayOfWeek != nil {
 		dayOfWeek = *input.DayOfWeek
 	}
-	var startHour int32
+	var startHour int64
 	if input.StartHour != nil {
 		startHour = *input.StartHour
 	}
-	var startMinute int32
+	var startMinute int64
 	if input.StartMinute != nil {
 		startMinute = *input.StartMinute
 	}
@@ -613,17 +778,19 @@ func flattenArmServerMaintenanceWindow(input *postgresqlflexibleservers.Maintena
 	}
 }
 
-func expandFlexibleServerHighAvailability(inputs []interface{}) *postgresqlflexibleservers.HighAvailability {
+func expandFlexibleServerHighAvailability(inputs []interface{}) *servers.HighAvailability {
 	if len(inputs) == 0 || inputs[0] == nil {
-		return &postgresqlflexibleservers.HighAvailability{
-			Mode: postgresqlflexibleservers.HighAvailabilityModeDisabled,
+		mode := servers.HighAvailabilityModeDisabled
+		return &servers.HighAvailability{
+			Mode: &mode,
 		}
 	}
 
 	input := inputs[0].(map[string]interface{})
 
-	result := postgresqlflexibleservers.HighAvailability{
-		Mode: postgresqlflexibleservers.HighAvailabilityMode(input["mode"].(string)),
+	mode := servers.HighAvailabilityMode(input["mode"].(string))
+	result := servers.HighAvailability{
+		Mode: &mode,
 	}
 
 	if v, ok := input["standby_availability_zone"]; ok && v.(string) != "" {
@@ -633,8 +800,8 @@ func expandFlexibleServerHighAvailability(inputs []interface{}) *postgresqlflexi
 	return &result
 }
 
-func flattenFlexibleServerHighAvailability(ha *postgresqlflexibleservers.HighAvailability) []interface{} {
-	if ha == nil || ha.Mode == postgresqlflexibleservers.HighAvailabilityModeDisabled {
+func flattenFlexibleServerHighAvailability(ha *servers.HighAvailability) []interface{} {
+	if ha == nil || ha.Mode == nil || *ha.Mode == servers.HighAvailabilityModeDisabled {
 		return []interface{}{}
 	}
 
@@ -645,8 +812,142 @@ func flattenFlexibleServerHighAvailability(ha *postgresqlflexibleservers.HighAva
 
 	return []interface{}{
 		map[string]interface{}{
-			"mode":                      string(ha.Mode),
+			"mode":                      string(*ha.Mode),
 			"standby_availability_zone": zone,
 		},
 	}
 }
+
+func expandFlexibleServerAuthConfig(inputs []interface{}) *servers.AuthConfig {
+	activeDirectoryAuth := servers.ActiveDirectoryAuthEnumDisabled
+	passwordAuth := servers.PasswordAuthEnumEnabled
+	result := servers.AuthConfig{
+		ActiveDirectoryAuth: &activeDirectoryAuth,
+		PasswordAuth:        &passwordAuth,
+	}
+
+	if len(inputs) == 0 || inputs[0] == nil {
+		return &result
+	}
+
+	input := inputs[0].(map[string]interface{})
+
+	if input["active_directory_auth_enabled"].(bool) {
+		activeDirectoryAuth = servers.ActiveDirectoryAuthEnumEnabled
+	}
+
+	if !input["password_auth_enabled"].(bool) {
+		passwordAuth = servers.PasswordAuthEnumDisabled
+	}
+
+	if v := input["tenant_id"].(string); v != "" {
+		result.TenantId = utils.String(v)
+	}
+
+	return &result
+}
+
+func flattenFlexibleServerAuthConfig(input *servers.AuthConfig) []interface{} {
+	if input == nil {
+		return []interface{}{}
+	}
+
+	// password authentication is enabled unless it has explicitly been disabled
+	passwordAuthEnabled := true
+	if input.PasswordAuth != nil {
+		passwordAuthEnabled = *input.PasswordAuth == servers.PasswordAuthEnumEnabled
+	}
+
+	tenantId := ""
+	if input.TenantId != nil {
+		tenantId = *input.TenantId
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"active_directory_auth_enabled": input.ActiveDirectoryAuth != nil && *input.ActiveDirectoryAuth == servers.ActiveDirectoryAuthEnumEnabled,
+			"password_auth_enabled":         passwordAuthEnabled,
+			"tenant_id":                     tenantId,
+		},
+	}
+}
+
+func expandFlexibleServerDataEncryption(inputs []interface{}) *servers.DataEncryption {
+	if len(inputs) == 0 || inputs[0] == nil {
+		return nil
+	}
+
+	input := inputs[0].(map[string]interface{})
+
+	keyType := servers.ArmServerKeyTypeAzureKeyVault
+	result := servers.DataEncryption{
+		Type:                          &keyType,
+		PrimaryKeyURI:                 utils.String(input["key_vault_key_id"].(string)),
+		PrimaryUserAssignedIdentityId: utils.String(input["primary_user_assigned_identity_id"].(string)),
+	}
+
+	if v := input["geo_backup_key_vault_key_id"].(string); v != "" {
+		result.GeoBackupKeyURI = utils.String(v)
+	}
+
+	if v := input["geo_backup_user_assigned_identity_id"].(string); v != "" {
+		result.GeoBackupUserAssignedIdentityId = utils.String(v)
+	}
+
+	return &result
+}
+
+func flattenFlexibleServerDataEncryption(input *servers.DataEncryption) []interface{} {
+	if input == nil || input.Type == nil || *input.Type != servers.ArmServerKeyTypeAzureKeyVault {
+		return []interface{}{}
+	}
+
+	keyVaultKeyId := ""
+	if input.PrimaryKeyURI != nil {
+		keyVaultKeyId = *input.PrimaryKeyURI
+	}
+
+	primaryUserAssignedIdentityId := ""
+	if input.PrimaryUserAssignedIdentityId != nil {
+		primaryUserAssignedIdentityId = *input.PrimaryUserAssignedIdentityId
+	}
+
+	geoBackupKeyVaultKeyId := ""
+	if input.GeoBackupKeyURI != nil {
+		geoBackupKeyVaultKeyId = *input.GeoBackupKeyURI
+	}
+
+	geoBackupUserAssignedIdentityId := ""
+	if input.GeoBackupUserAssignedIdentityId != nil {
+		geoBackupUserAssignedIdentityId = *input.GeoBackupUserAssignedIdentityId
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"key_vault_key_id":                     keyVaultKeyId,
+			"primary_user_assigned_identity_id":    primaryUserAssignedIdentityId,
+			"geo_backup_key_vault_key_id":          geoBackupKeyVaultKeyId,
+			"geo_backup_user_assigned_identity_id": geoBackupUserAssignedIdentityId,
+		},
+	}
+}
+
+func expandFlexibleServerIdentity(input []interface{}) (*identity.UserAssignedIdentityMap, error) {
+	expanded, err := postgresqlFlexibleServerIdentityType{}.Expand(input)
+	if err != nil {
+		return nil, err
+	}
+
+	result := identity.UserAssignedIdentityMap{}
+	result.FromExpandedConfig(*expanded)
+	return &result, nil
+}
+
+func flattenFlexibleServerIdentity(input *identity.UserAssignedIdentityMap) []interface{} {
+	if input == nil {
+		return []interface{}{}
+	}
+
+	config := input.ToExpandedConfig()
+	return postgresqlFlexibleServerIdentityType{}.Flatten(&config)
+}
@@ -0,0 +1,5 @@
+package administrators
+
+type ActiveDirectoryAdministratorAdd struct {
+	Properties *AdministratorPropertiesForAdd `json:"properties,omitempty"`
+}
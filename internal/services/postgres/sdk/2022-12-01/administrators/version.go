@@ -0,0 +1,9 @@
+package administrators
+
+import "fmt"
+
+const defaultApiVersion = "2022-12-01"
+
+func userAgent() string {
+	return fmt.Sprintf("pandora/administrators/%s", defaultApiVersion)
+}
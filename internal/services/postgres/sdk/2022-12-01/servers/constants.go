@@ -0,0 +1,120 @@
+package servers
+
+type ActiveDirectoryAuthEnum string
+
+const (
+	ActiveDirectoryAuthEnumDisabled ActiveDirectoryAuthEnum = "Disabled"
+	ActiveDirectoryAuthEnumEnabled  ActiveDirectoryAuthEnum = "Enabled"
+)
+
+type ArmServerKeyType string
+
+const (
+	ArmServerKeyTypeAzureKeyVault ArmServerKeyType = "AzureKeyVault"
+	ArmServerKeyTypeSystemManaged ArmServerKeyType = "SystemManaged"
+)
+
+type CreateMode string
+
+const (
+	CreateModeCreate             CreateMode = "Create"
+	CreateModeDefault            CreateMode = "Default"
+	CreateModeGeoRestore         CreateMode = "GeoRestore"
+	CreateModePointInTimeRestore CreateMode = "PointInTimeRestore"
+	CreateModeReplica            CreateMode = "Replica"
+	CreateModeUpdate             CreateMode = "Update"
+)
+
+type CreateModeForUpdate string
+
+const (
+	CreateModeForUpdateDefault CreateModeForUpdate = "Default"
+	CreateModeForUpdateUpdate  CreateModeForUpdate = "Update"
+)
+
+type CreatedByType string
+
+const (
+	CreatedByTypeApplication     CreatedByType = "Application"
+	CreatedByTypeKey             CreatedByType = "Key"
+	CreatedByTypeManagedIdentity CreatedByType = "ManagedIdentity"
+	CreatedByTypeUser            CreatedByType = "User"
+)
+
+type GeoRedundantBackupEnum string
+
+const (
+	GeoRedundantBackupEnumDisabled GeoRedundantBackupEnum = "Disabled"
+	GeoRedundantBackupEnumEnabled  GeoRedundantBackupEnum = "Enabled"
+)
+
+type HighAvailabilityMode string
+
+const (
+	HighAvailabilityModeDisabled      HighAvailabilityMode = "Disabled"
+	HighAvailabilityModeSameZone      HighAvailabilityMode = "SameZone"
+	HighAvailabilityModeZoneRedundant HighAvailabilityMode = "ZoneRedundant"
+)
+
+type PasswordAuthEnum string
+
+const (
+	PasswordAuthEnumDisabled PasswordAuthEnum = "Disabled"
+	PasswordAuthEnumEnabled  PasswordAuthEnum = "Enabled"
+)
+
+type ReplicationRole string
+
+const (
+	ReplicationRoleAsyncReplica    ReplicationRole = "AsyncReplica"
+	ReplicationRoleGeoAsyncReplica ReplicationRole = "GeoAsyncReplica"
+	ReplicationRoleNone            ReplicationRole = "None"
+	ReplicationRolePrimary         ReplicationRole = "Primary"
+)
+
+type ServerHAState string
+
+const (
+	ServerHAStateCreatingStandby ServerHAState = "CreatingStandby"
+	ServerHAStateFailingOver     ServerHAState = "FailingOver"
+	ServerHAStateHealthy         ServerHAState = "Healthy"
+	ServerHAStateNotEnabled      ServerHAState = "NotEnabled"
+	ServerHAStateRemovingStandby ServerHAState = "RemovingStandby"
+	ServerHAStateReplicatingData ServerHAState = "ReplicatingData"
+)
+
+type ServerPublicNetworkAccessState string
+
+const (
+	ServerPublicNetworkAccessStateDisabled ServerPublicNetworkAccessState = "Disabled"
+	ServerPublicNetworkAccessStateEnabled  ServerPublicNetworkAccessState = "Enabled"
+)
+
+type ServerState string
+
+const (
+	ServerStateDisabled ServerState = "Disabled"
+	ServerStateDropping ServerState = "Dropping"
+	ServerStateReady    ServerState = "Ready"
+	ServerStateStarting ServerState = "Starting"
+	ServerStateStopped  ServerState = "Stopped"
+	ServerStateStopping ServerState = "Stopping"
+	ServerStateUpdating ServerState = "Updating"
+)
+
+type ServerVersion string
+
+const (
+	ServerVersionOneFour  ServerVersion = "14"
+	ServerVersionOneOne   ServerVersion = "11"
+	ServerVersionOneThree ServerVersion = "13"
+	ServerVersionOneTwo   ServerVersion = "12"
+)
+
+type SkuTier string
+
+const (
+	SkuTierBurstable       SkuTier = "Burstable"
+	SkuTierGeneralPurpose  SkuTier = "GeneralPurpose"
+	SkuTierMemoryOptimized SkuTier = "MemoryOptimized"
+)
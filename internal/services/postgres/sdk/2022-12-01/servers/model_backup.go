@@ -0,0 +1,22 @@
+package servers
+
+import (
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/formatting"
+)
+
+type Backup struct {
+	BackupRetentionDays *int64                  `json:"backupRetentionDays,omitempty"`
+	EarliestRestoreDate *string                 `json:"earliestRestoreDate,omitempty"`
+	GeoRedundantBackup  *GeoRedundantBackupEnum `json:"geoRedundantBackup,omitempty"`
+}
+
+func (o Backup) GetEarliestRestoreDateAsTime() (*time.Time, error) {
+	return formatting.ParseAsDateFormat(o.EarliestRestoreDate, "2006-01-02T15:04:05Z07:00")
+}
+
+func (o Backup) SetEarliestRestoreDateAsTime(input time.Time) {
+	formatted := input.Format("2006-01-02T15:04:05Z07:00")
+	o.EarliestRestoreDate = &formatted
+}
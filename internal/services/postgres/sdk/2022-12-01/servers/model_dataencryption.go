@@ -0,0 +1,9 @@
+package servers
+
+type DataEncryption struct {
+	GeoBackupKeyURI                 *string           `json:"geoBackupKeyURI,omitempty"`
+	GeoBackupUserAssignedIdentityId *string           `json:"geoBackupUserAssignedIdentityId,omitempty"`
+	PrimaryKeyURI                   *string           `json:"primaryKeyURI,omitempty"`
+	PrimaryUserAssignedIdentityId   *string           `json:"primaryUserAssignedIdentityId,omitempty"`
+	Type                            *ArmServerKeyType `json:"type,omitempty"`
+}
@@ -0,0 +1,7 @@
+package servers
+
+type HighAvailability struct {
+	Mode                    *HighAvailabilityMode `json:"mode,omitempty"`
+	StandbyAvailabilityZone *string               `json:"standbyAvailabilityZone,omitempty"`
+	State                   *ServerHAState        `json:"state,omitempty"`
+}
@@ -0,0 +1,7 @@
+package servers
+
+type Network struct {
+	DelegatedSubnetResourceId   *string                         `json:"delegatedSubnetResourceId,omitempty"`
+	PrivateDnsZoneArmResourceId *string                         `json:"privateDnsZoneArmResourceId,omitempty"`
+	PublicNetworkAccess         *ServerPublicNetworkAccessState `json:"publicNetworkAccess,omitempty"`
+}
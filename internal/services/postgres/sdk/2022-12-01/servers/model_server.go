@@ -0,0 +1,17 @@
+package servers
+
+import (
+	"github.com/hashicorp/terraform-provider-azurerm/internal/identity"
+)
+
+type Server struct {
+	Id         *string                           `json:"id,omitempty"`
+	Identity   *identity.UserAssignedIdentityMap `json:"identity,omitempty"`
+	Location   string                            `json:"location"`
+	Name       *string                           `json:"name,omitempty"`
+	Properties *ServerProperties                 `json:"properties,omitempty"`
+	Sku        *Sku                              `json:"sku,omitempty"`
+	SystemData *SystemData                       `json:"systemData,omitempty"`
+	Tags       *map[string]string                `json:"tags,omitempty"`
+	Type       *string                           `json:"type,omitempty"`
+}
@@ -0,0 +1,12 @@
+package servers
+
+import (
+	"github.com/hashicorp/terraform-provider-azurerm/internal/identity"
+)
+
+type ServerForUpdate struct {
+	Identity   *identity.UserAssignedIdentityMap `json:"identity,omitempty"`
+	Properties *ServerPropertiesForUpdate        `json:"properties,omitempty"`
+	Sku        *Sku                              `json:"sku,omitempty"`
+	Tags       *map[string]string                `json:"tags,omitempty"`
+}
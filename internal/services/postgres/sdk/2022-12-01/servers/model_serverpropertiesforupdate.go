@@ -0,0 +1,14 @@
+package servers
+
+type ServerPropertiesForUpdate struct {
+	AdministratorLoginPassword *string              `json:"administratorLoginPassword,omitempty"`
+	AuthConfig                 *AuthConfig          `json:"authConfig,omitempty"`
+	Backup                     *Backup              `json:"backup,omitempty"`
+	CreateMode                 *CreateModeForUpdate `json:"createMode,omitempty"`
+	DataEncryption             *DataEncryption      `json:"dataEncryption,omitempty"`
+	HighAvailability           *HighAvailability    `json:"highAvailability,omitempty"`
+	MaintenanceWindow          *MaintenanceWindow   `json:"maintenanceWindow,omitempty"`
+	ReplicationRole            *ReplicationRole     `json:"replicationRole,omitempty"`
+	Storage                    *Storage             `json:"storage,omitempty"`
+	Version                    *ServerVersion       `json:"version,omitempty"`
+}
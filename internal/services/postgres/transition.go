@@ -0,0 +1,29 @@
+package postgres
+
+import (
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+func expandTags(input map[string]interface{}) *map[string]string {
+	output := make(map[string]string)
+	for k, v := range tags.Expand(input) {
+		if v == nil {
+			continue
+		}
+		output[k] = *v
+	}
+	return &output
+}
+
+func flattenTags(input *map[string]string) map[string]*string {
+	output := make(map[string]*string)
+
+	if input != nil {
+		for k, v := range *input {
+			output[k] = utils.String(v)
+		}
+	}
+
+	return output
+}
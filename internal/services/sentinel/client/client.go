@@ -6,9 +6,13 @@ import (
 )
 
 type Client struct {
-	AlertRulesClient         *securityinsight.AlertRulesClient
-	AlertRuleTemplatesClient *securityinsight.AlertRuleTemplatesClient
-	DataConnectorsClient     *securityinsight.DataConnectorsClient
+	AlertRulesClient                   *securityinsight.AlertRulesClient
+	AlertRuleTemplatesClient           *securityinsight.AlertRuleTemplatesClient
+	AutomationRulesClient              *securityinsight.AutomationRulesClient
+	DataConnectorsClient               *securityinsight.DataConnectorsClient
+	ThreatIntelligenceIndicatorsClient *securityinsight.ThreatIntelligenceIndicatorClient
+	WatchlistsClient                   *securityinsight.WatchlistsClient
+	WatchlistItemsClient               *securityinsight.WatchlistItemsClient
 }
 
 func NewClient(o *common.ClientOptions) *Client {
@@ -18,12 +22,28 @@ func NewClient(o *common.ClientOptions) *Client {
 	alertRuleTemplatesClient := securityinsight.NewAlertRuleTemplatesClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
 	o.ConfigureClient(&alertRuleTemplatesClient.Client, o.ResourceManagerAuthorizer)
 
+	automationRulesClient := securityinsight.NewAutomationRulesClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	o.ConfigureClient(&automationRulesClient.Client, o.ResourceManagerAuthorizer)
+
 	dataConnectorsClient := securityinsight.NewDataConnectorsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
 	o.ConfigureClient(&dataConnectorsClient.Client, o.ResourceManagerAuthorizer)
 
+	threatIntelligenceIndicatorsClient := securityinsight.NewThreatIntelligenceIndicatorClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	o.ConfigureClient(&threatIntelligenceIndicatorsClient.Client, o.ResourceManagerAuthorizer)
+
+	watchlistsClient := securityinsight.NewWatchlistsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	o.ConfigureClient(&watchlistsClient.Client, o.ResourceManagerAuthorizer)
+
+	watchlistItemsClient := securityinsight.NewWatchlistItemsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	o.ConfigureClient(&watchlistItemsClient.Client, o.ResourceManagerAuthorizer)
+
 	return &Client{
-		AlertRulesClient:         &alertRulesClient,
-		AlertRuleTemplatesClient: &alertRuleTemplatesClient,
-		DataConnectorsClient:     &dataConnectorsClient,
+		AlertRulesClient:                   &alertRulesClient,
+		AlertRuleTemplatesClient:           &alertRuleTemplatesClient,
+		AutomationRulesClient:              &automationRulesClient,
+		DataConnectorsClient:               &dataConnectorsClient,
+		ThreatIntelligenceIndicatorsClient: &threatIntelligenceIndicatorsClient,
+		WatchlistsClient:                   &watchlistsClient,
+		WatchlistItemsClient:               &watchlistItemsClient,
 	}
 }
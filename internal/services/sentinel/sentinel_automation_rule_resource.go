@@ -0,0 +1,570 @@
+package sentinel
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/securityinsight/mgmt/2019-01-01-preview/securityinsight"
+	"github.com/Azure/go-autorest/autorest/date"
+	"github.com/gofrs/uuid"
+	"github.com/hashicorp/terraform-provider-azurerm/helpers/azure"
+	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	loganalyticsParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/loganalytics/parse"
+	loganalyticsValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/loganalytics/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/sentinel/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/suppress"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+func resourceSentinelAutomationRule() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Create: resourceSentinelAutomationRuleCreateUpdate,
+		Read:   resourceSentinelAutomationRuleRead,
+		Update: resourceSentinelAutomationRuleCreateUpdate,
+		Delete: resourceSentinelAutomationRuleDelete,
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			_, err := parse.AutomationRuleID(id)
+			return err
+		}),
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+
+			"log_analytics_workspace_id": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: loganalyticsValidate.LogAnalyticsWorkspaceID,
+			},
+
+			"display_name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"order": {
+				Type:         pluginsdk.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntBetween(1, 1000),
+			},
+
+			"enabled": {
+				Type:     pluginsdk.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"expiration": {
+				Type:             pluginsdk.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppress.RFC3339Time,
+				ValidateFunc:     validation.IsRFC3339Time,
+			},
+
+			"condition": {
+				Type:     pluginsdk.TypeList,
+				Optional: true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"property": {
+							Type:         pluginsdk.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice(possibleAutomationRuleConditionProperties(), false),
+						},
+
+						"operator": {
+							Type:     pluginsdk.TypeString,
+							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								string(securityinsight.AutomationRulePropertyConditionSupportedOperatorContains),
+								string(securityinsight.AutomationRulePropertyConditionSupportedOperatorEndsWith),
+								string(securityinsight.AutomationRulePropertyConditionSupportedOperatorEquals),
+								string(securityinsight.AutomationRulePropertyConditionSupportedOperatorNotContains),
+								string(securityinsight.AutomationRulePropertyConditionSupportedOperatorNotEndsWith),
+								string(securityinsight.AutomationRulePropertyConditionSupportedOperatorNotEquals),
+								string(securityinsight.AutomationRulePropertyConditionSupportedOperatorNotStartsWith),
+								string(securityinsight.AutomationRulePropertyConditionSupportedOperatorStartsWith),
+							}, false),
+						},
+
+						"values": {
+							Type:     pluginsdk.TypeList,
+							Required: true,
+							Elem: &pluginsdk.Schema{
+								Type:         pluginsdk.TypeString,
+								ValidateFunc: validation.StringIsNotEmpty,
+							},
+						},
+					},
+				},
+			},
+
+			"action_incident": {
+				Type:         pluginsdk.TypeList,
+				Optional:     true,
+				AtLeastOneOf: []string{"action_incident", "action_playbook"},
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"order": {
+							Type:         pluginsdk.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+
+						"status": {
+							Type:     pluginsdk.TypeString,
+							Optional: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								string(securityinsight.IncidentStatusActive),
+								string(securityinsight.IncidentStatusClosed),
+								string(securityinsight.IncidentStatusNew),
+							}, false),
+						},
+
+						"classification": {
+							Type:     pluginsdk.TypeString,
+							Optional: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								string(securityinsight.IncidentClassificationUndetermined),
+								automationRuleClassification(securityinsight.IncidentClassificationTruePositive, securityinsight.IncidentClassificationReasonSuspiciousActivity),
+								automationRuleClassification(securityinsight.IncidentClassificationBenignPositive, securityinsight.IncidentClassificationReasonSuspiciousButExpected),
+								automationRuleClassification(securityinsight.IncidentClassificationFalsePositive, securityinsight.IncidentClassificationReasonIncorrectAlertLogic),
+								automationRuleClassification(securityinsight.IncidentClassificationFalsePositive, securityinsight.IncidentClassificationReasonInaccurateData),
+							}, false),
+						},
+
+						"classification_comment": {
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+
+						"labels": {
+							Type:     pluginsdk.TypeList,
+							Optional: true,
+							Elem: &pluginsdk.Schema{
+								Type:         pluginsdk.TypeString,
+								ValidateFunc: validation.StringIsNotEmpty,
+							},
+						},
+
+						"owner_id": {
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.IsUUID,
+						},
+
+						"severity": {
+							Type:     pluginsdk.TypeString,
+							Optional: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								string(securityinsight.IncidentSeverityHigh),
+								string(securityinsight.IncidentSeverityInformational),
+								string(securityinsight.IncidentSeverityLow),
+								string(securityinsight.IncidentSeverityMedium),
+							}, false),
+						},
+					},
+				},
+			},
+
+			"action_playbook": {
+				Type:         pluginsdk.TypeList,
+				Optional:     true,
+				AtLeastOneOf: []string{"action_incident", "action_playbook"},
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"order": {
+							Type:         pluginsdk.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+
+						"logic_app_id": {
+							Type:         pluginsdk.TypeString,
+							Required:     true,
+							ValidateFunc: azure.ValidateResourceID,
+						},
+
+						"tenant_id": {
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validation.IsUUID,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceSentinelAutomationRuleCreateUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Sentinel.AutomationRulesClient
+	ctx, cancel := timeouts.ForCreateUpdate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	workspaceId, err := loganalyticsParse.LogAnalyticsWorkspaceID(d.Get("log_analytics_workspace_id").(string))
+	if err != nil {
+		return err
+	}
+	id := parse.NewAutomationRuleID(workspaceId.SubscriptionId, workspaceId.ResourceGroup, workspaceId.WorkspaceName, d.Get("name").(string))
+
+	if d.IsNewResource() {
+		existing, err := client.Get(ctx, id.ResourceGroup, OperationalInsightsResourceProvider, id.WorkspaceName, id.Name)
+		if err != nil {
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return fmt.Errorf("checking for existing %s: %+v", id, err)
+			}
+		}
+		if !utils.ResponseWasNotFound(existing.Response) {
+			return tf.ImportAsExistsError("azurerm_sentinel_automation_rule", id.ID())
+		}
+	}
+
+	actions, err := expandAutomationRuleActions(d, meta.(*clients.Client).Account.TenantId)
+	if err != nil {
+		return err
+	}
+
+	param := securityinsight.AutomationRule{
+		AutomationRuleProperties: &securityinsight.AutomationRuleProperties{
+			DisplayName: utils.String(d.Get("display_name").(string)),
+			Order:       utils.Int32(int32(d.Get("order").(int))),
+			TriggeringLogic: &securityinsight.AutomationRuleTriggeringLogic{
+				IsEnabled:    utils.Bool(d.Get("enabled").(bool)),
+				TriggersOn:   utils.String("Incidents"),
+				TriggersWhen: utils.String("Created"),
+				Conditions:   expandAutomationRuleConditions(d.Get("condition").([]interface{})),
+			},
+			Actions: actions,
+		},
+	}
+
+	if v, ok := d.GetOk("expiration"); ok {
+		expiration, err := date.ParseTime(time.RFC3339, v.(string))
+		if err != nil {
+			return fmt.Errorf("parsing `expiration`: %+v", err)
+		}
+		param.AutomationRuleProperties.TriggeringLogic.ExpirationTimeUtc = &date.Time{Time: expiration}
+	}
+
+	if _, err := client.CreateOrUpdate(ctx, id.ResourceGroup, OperationalInsightsResourceProvider, id.WorkspaceName, id.Name, param); err != nil {
+		return fmt.Errorf("creating/updating %s: %+v", id, err)
+	}
+
+	d.SetId(id.ID())
+
+	return resourceSentinelAutomationRuleRead(d, meta)
+}
+
+func resourceSentinelAutomationRuleRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Sentinel.AutomationRulesClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.AutomationRuleID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Get(ctx, id.ResourceGroup, OperationalInsightsResourceProvider, id.WorkspaceName, id.Name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[DEBUG] %s was not found - removing from state!", id)
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("retrieving %s: %+v", id, err)
+	}
+
+	d.Set("name", id.Name)
+	d.Set("log_analytics_workspace_id", loganalyticsParse.NewLogAnalyticsWorkspaceID(id.SubscriptionId, id.ResourceGroup, id.WorkspaceName).ID())
+
+	if props := resp.AutomationRuleProperties; props != nil {
+		d.Set("display_name", props.DisplayName)
+
+		order := 0
+		if props.Order != nil {
+			order = int(*props.Order)
+		}
+		d.Set("order", order)
+
+		enabled := false
+		expiration := ""
+		var conditions []interface{}
+		if logic := props.TriggeringLogic; logic != nil {
+			if logic.IsEnabled != nil {
+				enabled = *logic.IsEnabled
+			}
+			if logic.ExpirationTimeUtc != nil {
+				expiration = logic.ExpirationTimeUtc.Format(time.RFC3339)
+			}
+			conditions = flattenAutomationRuleConditions(logic.Conditions)
+		}
+		d.Set("enabled", enabled)
+		d.Set("expiration", expiration)
+		if err := d.Set("condition", conditions); err != nil {
+			return fmt.Errorf("setting `condition`: %+v", err)
+		}
+
+		incidentActions, playbookActions := flattenAutomationRuleActions(props.Actions)
+		if err := d.Set("action_incident", incidentActions); err != nil {
+			return fmt.Errorf("setting `action_incident`: %+v", err)
+		}
+		if err := d.Set("action_playbook", playbookActions); err != nil {
+			return fmt.Errorf("setting `action_playbook`: %+v", err)
+		}
+	}
+
+	return nil
+}
+
+func resourceSentinelAutomationRuleDelete(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Sentinel.AutomationRulesClient
+	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.AutomationRuleID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	if _, err := client.Delete(ctx, id.ResourceGroup, OperationalInsightsResourceProvider, id.WorkspaceName, id.Name); err != nil {
+		return fmt.Errorf("deleting %s: %+v", id, err)
+	}
+
+	return nil
+}
+
+func possibleAutomationRuleConditionProperties() []string {
+	output := make([]string, 0)
+	for _, v := range securityinsight.PossibleAutomationRulePropertyConditionSupportedPropertyValues() {
+		output = append(output, string(v))
+	}
+	return output
+}
+
+// automationRuleClassification combines a classification and its reason into the single value exposed in the schema,
+// since the API only accepts certain combinations of the two.
+func automationRuleClassification(classification securityinsight.IncidentClassification, reason securityinsight.IncidentClassificationReason) string {
+	if reason == "" {
+		return string(classification)
+	}
+	return fmt.Sprintf("%s_%s", classification, reason)
+}
+
+func expandAutomationRuleConditions(input []interface{}) *[]securityinsight.BasicAutomationRuleCondition {
+	output := make([]securityinsight.BasicAutomationRuleCondition, 0)
+
+	for _, item := range input {
+		v := item.(map[string]interface{})
+		output = append(output, securityinsight.AutomationRulePropertyValuesCondition{
+			ConditionProperties: &securityinsight.AutomationRulePropertyValuesConditionConditionProperties{
+				PropertyName:   securityinsight.AutomationRulePropertyConditionSupportedProperty(v["property"].(string)),
+				Operator:       securityinsight.AutomationRulePropertyConditionSupportedOperator(v["operator"].(string)),
+				PropertyValues: utils.ExpandStringSlice(v["values"].([]interface{})),
+			},
+		})
+	}
+
+	return &output
+}
+
+func flattenAutomationRuleConditions(input *[]securityinsight.BasicAutomationRuleCondition) []interface{} {
+	if input == nil {
+		return []interface{}{}
+	}
+
+	output := make([]interface{}, 0)
+	for _, item := range *input {
+		condition, ok := item.(securityinsight.AutomationRulePropertyValuesCondition)
+		if !ok || condition.ConditionProperties == nil {
+			continue
+		}
+
+		props := condition.ConditionProperties
+		output = append(output, map[string]interface{}{
+			"property": string(props.PropertyName),
+			"operator": string(props.Operator),
+			"values":   utils.FlattenStringSlice(props.PropertyValues),
+		})
+	}
+
+	return output
+}
+
+func expandAutomationRuleActions(d *pluginsdk.ResourceData, defaultTenantId string) (*[]securityinsight.BasicAutomationRuleAction, error) {
+	output := make([]securityinsight.BasicAutomationRuleAction, 0)
+
+	for _, item := range d.Get("action_incident").([]interface{}) {
+		v := item.(map[string]interface{})
+
+		config := &securityinsight.AutomationRuleModifyPropertiesActionActionConfiguration{
+			Status:   securityinsight.IncidentStatus(v["status"].(string)),
+			Severity: securityinsight.IncidentSeverity(v["severity"].(string)),
+		}
+
+		if classification := v["classification"].(string); classification != "" {
+			// e.g. "TruePositive_SuspiciousActivity"
+			parts := strings.SplitN(classification, "_", 2)
+			config.Classification = securityinsight.IncidentClassification(parts[0])
+			if len(parts) == 2 {
+				config.ClassificationReason = securityinsight.IncidentClassificationReason(parts[1])
+			}
+		}
+		if config.Status == securityinsight.IncidentStatusClosed && config.Classification == "" {
+			return nil, fmt.Errorf("`classification` must be specified when `status` is set to %q", securityinsight.IncidentStatusClosed)
+		}
+
+		if comment := v["classification_comment"].(string); comment != "" {
+			config.ClassificationComment = utils.String(comment)
+		}
+
+		if labelsRaw := v["labels"].([]interface{}); len(labelsRaw) > 0 {
+			labels := make([]securityinsight.IncidentLabel, 0)
+			for _, label := range labelsRaw {
+				labels = append(labels, securityinsight.IncidentLabel{
+					LabelName: utils.String(label.(string)),
+				})
+			}
+			config.Labels = &labels
+		}
+
+		if ownerId := v["owner_id"].(string); ownerId != "" {
+			objectId, err := uuid.FromString(ownerId)
+			if err != nil {
+				return nil, fmt.Errorf("parsing `owner_id`: %+v", err)
+			}
+			config.Owner = &securityinsight.IncidentOwnerInfo{
+				ObjectID: &objectId,
+			}
+		}
+
+		output = append(output, securityinsight.AutomationRuleModifyPropertiesAction{
+			Order:               utils.Int32(int32(v["order"].(int))),
+			ActionConfiguration: config,
+		})
+	}
+
+	for _, item := range d.Get("action_playbook").([]interface{}) {
+		v := item.(map[string]interface{})
+
+		tenantId := v["tenant_id"].(string)
+		if tenantId == "" {
+			tenantId = defaultTenantId
+		}
+
+		output = append(output, securityinsight.AutomationRuleRunPlaybookAction{
+			Order: utils.Int32(int32(v["order"].(int))),
+			ActionConfiguration: &securityinsight.AutomationRuleRunPlaybookActionActionConfiguration{
+				LogicAppResourceID: utils.String(v["logic_app_id"].(string)),
+				TenantID:           utils.String(tenantId),
+			},
+		})
+	}
+
+	return &output, nil
+}
+
+func flattenAutomationRuleActions(input *[]securityinsight.BasicAutomationRuleAction) ([]interface{}, []interface{}) {
+	incidentActions := make([]interface{}, 0)
+	playbookActions := make([]interface{}, 0)
+
+	if input == nil {
+		return incidentActions, playbookActions
+	}
+
+	for _, item := range *input {
+		switch action := item.(type) {
+		case securityinsight.AutomationRuleModifyPropertiesAction:
+			order := 0
+			if action.Order != nil {
+				order = int(*action.Order)
+			}
+
+			status := ""
+			classification := ""
+			classificationComment := ""
+			labels := make([]interface{}, 0)
+			ownerId := ""
+			severity := ""
+			if config := action.ActionConfiguration; config != nil {
+				status = string(config.Status)
+				severity = string(config.Severity)
+				if config.Classification != "" {
+					classification = automationRuleClassification(config.Classification, config.ClassificationReason)
+				}
+				if config.ClassificationComment != nil {
+					classificationComment = *config.ClassificationComment
+				}
+				if config.Labels != nil {
+					for _, label := range *config.Labels {
+						if label.LabelName != nil {
+							labels = append(labels, *label.LabelName)
+						}
+					}
+				}
+				if config.Owner != nil && config.Owner.ObjectID != nil {
+					ownerId = config.Owner.ObjectID.String()
+				}
+			}
+
+			incidentActions = append(incidentActions, map[string]interface{}{
+				"order":                  order,
+				"status":                 status,
+				"classification":         classification,
+				"classification_comment": classificationComment,
+				"labels":                 labels,
+				"owner_id":               ownerId,
+				"severity":               severity,
+			})
+
+		case securityinsight.AutomationRuleRunPlaybookAction:
+			order := 0
+			if action.Order != nil {
+				order = int(*action.Order)
+			}
+
+			logicAppId := ""
+			tenantId := ""
+			if config := action.ActionConfiguration; config != nil {
+				if config.LogicAppResourceID != nil {
+					logicAppId = *config.LogicAppResourceID
+				}
+				if config.TenantID != nil {
+					tenantId = *config.TenantID
+				}
+			}
+
+			playbookActions = append(playbookActions, map[string]interface{}{
+				"order":        order,
+				"logic_app_id": logicAppId,
+				"tenant_id":    tenantId,
+			})
+		}
+	}
+
+	return incidentActions, playbookActions
+}
@@ -0,0 +1,208 @@
+package sentinel_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/sentinel"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/sentinel/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+type SentinelAutomationRuleResource struct{}
+
+func TestAccSentinelAutomationRule_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_sentinel_automation_rule", "test")
+	r := SentinelAutomationRuleResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccSentinelAutomationRule_complete(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_sentinel_automation_rule", "test")
+	r := SentinelAutomationRuleResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.complete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccSentinelAutomationRule_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_sentinel_automation_rule", "test")
+	r := SentinelAutomationRuleResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.complete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccSentinelAutomationRule_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_sentinel_automation_rule", "test")
+	r := SentinelAutomationRuleResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport),
+	})
+}
+
+func (r SentinelAutomationRuleResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	client := clients.Sentinel.AutomationRulesClient
+
+	id, err := parse.AutomationRuleID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp, err := client.Get(ctx, id.ResourceGroup, sentinel.OperationalInsightsResourceProvider, id.WorkspaceName, id.Name); err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return utils.Bool(false), nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", id, err)
+	}
+
+	return utils.Bool(true), nil
+}
+
+func (r SentinelAutomationRuleResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_sentinel_automation_rule" "test" {
+  name                       = "%s"
+  log_analytics_workspace_id = azurerm_log_analytics_solution.test.workspace_resource_id
+  display_name               = "acctest-SentinelAutoRule-%d"
+  order                      = 1
+
+  action_incident {
+    order  = 1
+    status = "Active"
+  }
+}
+`, r.template(data), r.uuid(), data.RandomInteger)
+}
+
+func (r SentinelAutomationRuleResource) complete(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_client_config" "current" {}
+
+resource "azurerm_logic_app_workflow" "test" {
+  name                = "acctestlaw-%[3]d"
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+}
+
+resource "azurerm_sentinel_automation_rule" "test" {
+  name                       = "%[2]s"
+  log_analytics_workspace_id = azurerm_log_analytics_solution.test.workspace_resource_id
+  display_name               = "acctest-SentinelAutoRule-%[3]d-update"
+  order                      = 2
+  enabled                    = false
+  expiration                 = "2030-01-01T00:00:00Z"
+
+  condition {
+    property = "IncidentTitle"
+    operator = "Contains"
+    values   = ["a", "b"]
+  }
+
+  condition {
+    property = "IncidentTitle"
+    operator = "Contains"
+    values   = ["c", "d"]
+  }
+
+  action_incident {
+    order                  = 1
+    status                 = "Closed"
+    classification         = "Undetermined"
+    classification_comment = "whatever reason"
+  }
+
+  action_incident {
+    order    = 3
+    labels   = ["foo", "bar"]
+    severity = "High"
+    owner_id = data.azurerm_client_config.current.object_id
+  }
+
+  action_playbook {
+    order        = 2
+    logic_app_id = azurerm_logic_app_workflow.test.id
+    tenant_id    = data.azurerm_client_config.current.tenant_id
+  }
+}
+`, r.template(data), r.uuid(), data.RandomInteger)
+}
+
+func (r SentinelAutomationRuleResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_sentinel_automation_rule" "import" {
+  name                       = azurerm_sentinel_automation_rule.test.name
+  log_analytics_workspace_id = azurerm_sentinel_automation_rule.test.log_analytics_workspace_id
+  display_name               = azurerm_sentinel_automation_rule.test.display_name
+  order                      = azurerm_sentinel_automation_rule.test.order
+
+  action_incident {
+    order  = 1
+    status = "Active"
+  }
+}
+`, r.basic(data))
+}
+
+func (r SentinelAutomationRuleResource) uuid() string {
+	return "1d1d5d2e-2e6c-4b9c-8b36-2c7f6e3f3c0a"
+}
+
+func (r SentinelAutomationRuleResource) template(data acceptance.TestData) string {
+	return SentinelWatchlistResource{}.template(data)
+}
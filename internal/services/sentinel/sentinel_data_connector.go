@@ -10,6 +10,9 @@ import (
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 )
 
+// NOTE: each kind of Data Connector is exposed as a separate resource - the generic Data Connectors (`GenericUI`
+// and `APIPolling`) aren't available in the 2019-01-01-preview Security Insights API, so can't be supported until
+// the API version used by this package is updated
 func importSentinelDataConnector(expectKind securityinsight.DataConnectorKind) pluginsdk.ImporterFunc {
 	return func(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) (data []*pluginsdk.ResourceData, err error) {
 		id, err := parse.DataConnectorID(d.Id())
@@ -0,0 +1,610 @@
+package sentinel
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/securityinsight/mgmt/2019-01-01-preview/securityinsight"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	loganalyticsParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/loganalytics/parse"
+	loganalyticsValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/loganalytics/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/sentinel/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/suppress"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+// threatIntelligenceName is the name of the only Threat Intelligence container within a Sentinel workspace
+const threatIntelligenceName = "main"
+
+func resourceSentinelThreatIntelligenceIndicator() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Create: resourceSentinelThreatIntelligenceIndicatorCreate,
+		Read:   resourceSentinelThreatIntelligenceIndicatorRead,
+		Update: resourceSentinelThreatIntelligenceIndicatorUpdate,
+		Delete: resourceSentinelThreatIntelligenceIndicatorDelete,
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			_, err := parse.ThreatIntelligenceIndicatorID(id)
+			return err
+		}),
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"log_analytics_workspace_id": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: loganalyticsValidate.LogAnalyticsWorkspaceID,
+			},
+
+			"display_name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"pattern_type": {
+				Type:     pluginsdk.TypeString,
+				Required: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"domain-name",
+					"file",
+					"ipv4-addr",
+					"ipv6-addr",
+					"url",
+				}, false),
+			},
+
+			"pattern": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"source": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"validate_from_utc": {
+				Type:             pluginsdk.TypeString,
+				Required:         true,
+				DiffSuppressFunc: suppress.RFC3339Time,
+				ValidateFunc:     validation.IsRFC3339Time,
+			},
+
+			"validate_until_utc": {
+				Type:             pluginsdk.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppress.RFC3339Time,
+				ValidateFunc:     validation.IsRFC3339Time,
+			},
+
+			"description": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"confidence": {
+				Type:         pluginsdk.TypeInt,
+				Optional:     true,
+				Default:      -1,
+				ValidateFunc: validation.IntBetween(-1, 100),
+			},
+
+			"created_by": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"language": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"pattern_version": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"revoked": {
+				Type:     pluginsdk.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"object_marking_refs": {
+				Type:     pluginsdk.TypeList,
+				Optional: true,
+				Elem: &pluginsdk.Schema{
+					Type:         pluginsdk.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+			},
+
+			"tags": {
+				Type:     pluginsdk.TypeList,
+				Optional: true,
+				Elem: &pluginsdk.Schema{
+					Type:         pluginsdk.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+			},
+
+			"threat_types": {
+				Type:     pluginsdk.TypeList,
+				Optional: true,
+				Elem: &pluginsdk.Schema{
+					Type:         pluginsdk.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+			},
+
+			"external_reference": {
+				Type:     pluginsdk.TypeList,
+				Optional: true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"description": {
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+
+						"hashes": {
+							Type:     pluginsdk.TypeMap,
+							Optional: true,
+							Elem: &pluginsdk.Schema{
+								Type: pluginsdk.TypeString,
+							},
+						},
+
+						"source_name": {
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+
+						"url": {
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+						},
+
+						"id": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"granular_marking": {
+				Type:     pluginsdk.TypeList,
+				Optional: true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"language": {
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+
+						"marking_ref": {
+							Type:     pluginsdk.TypeInt,
+							Optional: true,
+						},
+
+						"selectors": {
+							Type:     pluginsdk.TypeList,
+							Optional: true,
+							Elem: &pluginsdk.Schema{
+								Type:         pluginsdk.TypeString,
+								ValidateFunc: validation.StringIsNotEmpty,
+							},
+						},
+					},
+				},
+			},
+
+			"kill_chain_phase": {
+				Type:     pluginsdk.TypeList,
+				Optional: true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"kill_chain_name": {
+							Type:         pluginsdk.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+
+						"phase_name": {
+							Type:         pluginsdk.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+					},
+				},
+			},
+
+			"created_on": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"defanged": {
+				Type:     pluginsdk.TypeBool,
+				Computed: true,
+			},
+
+			"external_id": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"guid": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"indicator_types": {
+				Type:     pluginsdk.TypeList,
+				Computed: true,
+				Elem: &pluginsdk.Schema{
+					Type: pluginsdk.TypeString,
+				},
+			},
+
+			"last_updated_time_utc": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceSentinelThreatIntelligenceIndicatorCreate(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Sentinel.ThreatIntelligenceIndicatorsClient
+	ctx, cancel := timeouts.ForCreate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	workspaceId, err := loganalyticsParse.LogAnalyticsWorkspaceID(d.Get("log_analytics_workspace_id").(string))
+	if err != nil {
+		return err
+	}
+
+	// the name of an indicator is generated by the service, so there's no existing resource to check for here
+	resp, err := client.CreateIndicator(ctx, workspaceId.ResourceGroup, OperationalInsightsResourceProvider, workspaceId.WorkspaceName, expandThreatIntelligenceIndicator(d))
+	if err != nil {
+		return fmt.Errorf("creating Threat Intelligence Indicator in %s: %+v", workspaceId, err)
+	}
+
+	indicator, ok := resp.Value.(securityinsight.ThreatIntelligenceIndicatorModel)
+	if !ok || indicator.Name == nil || *indicator.Name == "" {
+		return fmt.Errorf("creating Threat Intelligence Indicator in %s: `name` was nil or empty", workspaceId)
+	}
+
+	id := parse.NewThreatIntelligenceIndicatorID(workspaceId.SubscriptionId, workspaceId.ResourceGroup, workspaceId.WorkspaceName, threatIntelligenceName, *indicator.Name)
+	d.SetId(id.ID())
+
+	return resourceSentinelThreatIntelligenceIndicatorRead(d, meta)
+}
+
+func resourceSentinelThreatIntelligenceIndicatorUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Sentinel.ThreatIntelligenceIndicatorsClient
+	ctx, cancel := timeouts.ForUpdate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.ThreatIntelligenceIndicatorID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	if _, err := client.Create(ctx, id.ResourceGroup, OperationalInsightsResourceProvider, id.WorkspaceName, id.IndicatorName, expandThreatIntelligenceIndicator(d)); err != nil {
+		return fmt.Errorf("updating %s: %+v", id, err)
+	}
+
+	return resourceSentinelThreatIntelligenceIndicatorRead(d, meta)
+}
+
+func resourceSentinelThreatIntelligenceIndicatorRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Sentinel.ThreatIntelligenceIndicatorsClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.ThreatIntelligenceIndicatorID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Get(ctx, id.ResourceGroup, OperationalInsightsResourceProvider, id.WorkspaceName, id.IndicatorName)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[DEBUG] %s was not found - removing from state!", id)
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("retrieving %s: %+v", id, err)
+	}
+
+	indicator, ok := resp.Value.(securityinsight.ThreatIntelligenceIndicatorModel)
+	if !ok {
+		return fmt.Errorf("%s was not a Threat Intelligence Indicator", id)
+	}
+
+	d.Set("log_analytics_workspace_id", loganalyticsParse.NewLogAnalyticsWorkspaceID(id.SubscriptionId, id.ResourceGroup, id.WorkspaceName).ID())
+	d.Set("guid", id.IndicatorName)
+
+	if props := indicator.ThreatIntelligenceIndicatorProperties; props != nil {
+		d.Set("display_name", props.DisplayName)
+		d.Set("pattern_type", props.PatternType)
+		d.Set("pattern", props.Pattern)
+		d.Set("source", props.Source)
+		d.Set("validate_from_utc", props.ValidFrom)
+		d.Set("validate_until_utc", props.ValidUntil)
+		d.Set("description", props.Description)
+		d.Set("created_by", props.CreatedByRef)
+		d.Set("language", props.Language)
+		d.Set("pattern_version", props.PatternVersion)
+		d.Set("revoked", props.Revoked)
+		d.Set("created_on", props.Created)
+		d.Set("defanged", props.Defanged)
+		d.Set("external_id", props.ExternalID)
+		d.Set("last_updated_time_utc", props.LastUpdatedTimeUtc)
+
+		confidence := -1
+		if props.Confidence != nil {
+			confidence = int(*props.Confidence)
+		}
+		d.Set("confidence", confidence)
+
+		if err := d.Set("object_marking_refs", utils.FlattenStringSlice(props.ObjectMarkingRefs)); err != nil {
+			return fmt.Errorf("setting `object_marking_refs`: %+v", err)
+		}
+		if err := d.Set("tags", utils.FlattenStringSlice(props.ThreatIntelligenceTags)); err != nil {
+			return fmt.Errorf("setting `tags`: %+v", err)
+		}
+		if err := d.Set("threat_types", utils.FlattenStringSlice(props.ThreatTypes)); err != nil {
+			return fmt.Errorf("setting `threat_types`: %+v", err)
+		}
+		if err := d.Set("indicator_types", utils.FlattenStringSlice(props.IndicatorTypes)); err != nil {
+			return fmt.Errorf("setting `indicator_types`: %+v", err)
+		}
+		if err := d.Set("external_reference", flattenThreatIntelligenceExternalReferences(props.ExternalReferences)); err != nil {
+			return fmt.Errorf("setting `external_reference`: %+v", err)
+		}
+		if err := d.Set("granular_marking", flattenThreatIntelligenceGranularMarkings(props.GranularMarkings)); err != nil {
+			return fmt.Errorf("setting `granular_marking`: %+v", err)
+		}
+		if err := d.Set("kill_chain_phase", flattenThreatIntelligenceKillChainPhases(props.KillChainPhases)); err != nil {
+			return fmt.Errorf("setting `kill_chain_phase`: %+v", err)
+		}
+	}
+
+	return nil
+}
+
+func resourceSentinelThreatIntelligenceIndicatorDelete(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Sentinel.ThreatIntelligenceIndicatorsClient
+	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.ThreatIntelligenceIndicatorID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	if _, err := client.Delete(ctx, id.ResourceGroup, OperationalInsightsResourceProvider, id.WorkspaceName, id.IndicatorName); err != nil {
+		return fmt.Errorf("deleting %s: %+v", id, err)
+	}
+
+	return nil
+}
+
+func expandThreatIntelligenceIndicator(d *pluginsdk.ResourceData) securityinsight.ThreatIntelligenceIndicatorModelForRequestBody {
+	props := &securityinsight.ThreatIntelligenceIndicatorProperties{
+		DisplayName:            utils.String(d.Get("display_name").(string)),
+		PatternType:            utils.String(d.Get("pattern_type").(string)),
+		Pattern:                utils.String(d.Get("pattern").(string)),
+		Source:                 utils.String(d.Get("source").(string)),
+		ValidFrom:              utils.String(d.Get("validate_from_utc").(string)),
+		Revoked:                utils.Bool(d.Get("revoked").(bool)),
+		ObjectMarkingRefs:      utils.ExpandStringSlice(d.Get("object_marking_refs").([]interface{})),
+		ThreatIntelligenceTags: utils.ExpandStringSlice(d.Get("tags").([]interface{})),
+		ThreatTypes:            utils.ExpandStringSlice(d.Get("threat_types").([]interface{})),
+		ExternalReferences:     expandThreatIntelligenceExternalReferences(d.Get("external_reference").([]interface{})),
+		GranularMarkings:       expandThreatIntelligenceGranularMarkings(d.Get("granular_marking").([]interface{})),
+		KillChainPhases:        expandThreatIntelligenceKillChainPhases(d.Get("kill_chain_phase").([]interface{})),
+	}
+
+	if v, ok := d.GetOk("validate_until_utc"); ok {
+		props.ValidUntil = utils.String(v.(string))
+	}
+	if v, ok := d.GetOk("description"); ok {
+		props.Description = utils.String(v.(string))
+	}
+	if v := d.Get("confidence").(int); v != -1 {
+		props.Confidence = utils.Int32(int32(v))
+	}
+	if v, ok := d.GetOk("created_by"); ok {
+		props.CreatedByRef = utils.String(v.(string))
+	}
+	if v, ok := d.GetOk("language"); ok {
+		props.Language = utils.String(v.(string))
+	}
+	if v, ok := d.GetOk("pattern_version"); ok {
+		props.PatternVersion = utils.String(v.(string))
+	}
+
+	return securityinsight.ThreatIntelligenceIndicatorModelForRequestBody{
+		Kind:                                  utils.String(string(securityinsight.ThreatIntelligenceResourceKindIndicator)),
+		ThreatIntelligenceIndicatorProperties: props,
+	}
+}
+
+func expandThreatIntelligenceExternalReferences(input []interface{}) *[]securityinsight.ThreatIntelligenceExternalReference {
+	output := make([]securityinsight.ThreatIntelligenceExternalReference, 0)
+
+	for _, item := range input {
+		v := item.(map[string]interface{})
+
+		reference := securityinsight.ThreatIntelligenceExternalReference{
+			Hashes: utils.ExpandMapStringPtrString(v["hashes"].(map[string]interface{})),
+		}
+		if description := v["description"].(string); description != "" {
+			reference.Description = utils.String(description)
+		}
+		if sourceName := v["source_name"].(string); sourceName != "" {
+			reference.SourceName = utils.String(sourceName)
+		}
+		if url := v["url"].(string); url != "" {
+			reference.URL = utils.String(url)
+		}
+
+		output = append(output, reference)
+	}
+
+	return &output
+}
+
+func flattenThreatIntelligenceExternalReferences(input *[]securityinsight.ThreatIntelligenceExternalReference) []interface{} {
+	if input == nil {
+		return []interface{}{}
+	}
+
+	output := make([]interface{}, 0)
+	for _, item := range *input {
+		description := ""
+		if item.Description != nil {
+			description = *item.Description
+		}
+
+		sourceName := ""
+		if item.SourceName != nil {
+			sourceName = *item.SourceName
+		}
+
+		url := ""
+		if item.URL != nil {
+			url = *item.URL
+		}
+
+		externalId := ""
+		if item.ExternalID != nil {
+			externalId = *item.ExternalID
+		}
+
+		output = append(output, map[string]interface{}{
+			"description": description,
+			"hashes":      utils.FlattenMapStringPtrString(item.Hashes),
+			"source_name": sourceName,
+			"url":         url,
+			"id":          externalId,
+		})
+	}
+
+	return output
+}
+
+func expandThreatIntelligenceGranularMarkings(input []interface{}) *[]securityinsight.ThreatIntelligenceGranularMarkingModel {
+	output := make([]securityinsight.ThreatIntelligenceGranularMarkingModel, 0)
+
+	for _, item := range input {
+		v := item.(map[string]interface{})
+
+		marking := securityinsight.ThreatIntelligenceGranularMarkingModel{
+			MarkingRef: utils.Int32(int32(v["marking_ref"].(int))),
+			Selectors:  utils.ExpandStringSlice(v["selectors"].([]interface{})),
+		}
+		if language := v["language"].(string); language != "" {
+			marking.Language = utils.String(language)
+		}
+
+		output = append(output, marking)
+	}
+
+	return &output
+}
+
+func flattenThreatIntelligenceGranularMarkings(input *[]securityinsight.ThreatIntelligenceGranularMarkingModel) []interface{} {
+	if input == nil {
+		return []interface{}{}
+	}
+
+	output := make([]interface{}, 0)
+	for _, item := range *input {
+		language := ""
+		if item.Language != nil {
+			language = *item.Language
+		}
+
+		markingRef := 0
+		if item.MarkingRef != nil {
+			markingRef = int(*item.MarkingRef)
+		}
+
+		output = append(output, map[string]interface{}{
+			"language":    language,
+			"marking_ref": markingRef,
+			"selectors":   utils.FlattenStringSlice(item.Selectors),
+		})
+	}
+
+	return output
+}
+
+func expandThreatIntelligenceKillChainPhases(input []interface{}) *[]securityinsight.ThreatIntelligenceKillChainPhase {
+	output := make([]securityinsight.ThreatIntelligenceKillChainPhase, 0)
+
+	for _, item := range input {
+		v := item.(map[string]interface{})
+		output = append(output, securityinsight.ThreatIntelligenceKillChainPhase{
+			KillChainName: utils.String(v["kill_chain_name"].(string)),
+			PhaseName:     utils.String(v["phase_name"].(string)),
+		})
+	}
+
+	return &output
+}
+
+func flattenThreatIntelligenceKillChainPhases(input *[]securityinsight.ThreatIntelligenceKillChainPhase) []interface{} {
+	if input == nil {
+		return []interface{}{}
+	}
+
+	output := make([]interface{}, 0)
+	for _, item := range *input {
+		killChainName := ""
+		if item.KillChainName != nil {
+			killChainName = *item.KillChainName
+		}
+
+		phaseName := ""
+		if item.PhaseName != nil {
+			phaseName = *item.PhaseName
+		}
+
+		output = append(output, map[string]interface{}{
+			"kill_chain_name": killChainName,
+			"phase_name":      phaseName,
+		})
+	}
+
+	return output
+}
@@ -0,0 +1,153 @@
+package sentinel_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/sentinel"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/sentinel/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+type SentinelThreatIntelligenceIndicatorResource struct{}
+
+func TestAccSentinelThreatIntelligenceIndicator_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_sentinel_threat_intelligence_indicator", "test")
+	r := SentinelThreatIntelligenceIndicatorResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccSentinelThreatIntelligenceIndicator_complete(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_sentinel_threat_intelligence_indicator", "test")
+	r := SentinelThreatIntelligenceIndicatorResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.complete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccSentinelThreatIntelligenceIndicator_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_sentinel_threat_intelligence_indicator", "test")
+	r := SentinelThreatIntelligenceIndicatorResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.complete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (r SentinelThreatIntelligenceIndicatorResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	client := clients.Sentinel.ThreatIntelligenceIndicatorsClient
+
+	id, err := parse.ThreatIntelligenceIndicatorID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp, err := client.Get(ctx, id.ResourceGroup, sentinel.OperationalInsightsResourceProvider, id.WorkspaceName, id.IndicatorName); err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return utils.Bool(false), nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", id, err)
+	}
+
+	return utils.Bool(true), nil
+}
+
+func (r SentinelThreatIntelligenceIndicatorResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_sentinel_threat_intelligence_indicator" "test" {
+  log_analytics_workspace_id = azurerm_log_analytics_solution.test.workspace_resource_id
+  display_name               = "acctest-TI-%d"
+  pattern_type               = "domain-name"
+  pattern                    = "[domain-name:value = 'http://www.example.com']"
+  source                     = "Microsoft Sentinel"
+  validate_from_utc          = "2022-12-14T16:00:00Z"
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (r SentinelThreatIntelligenceIndicatorResource) complete(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_sentinel_threat_intelligence_indicator" "test" {
+  log_analytics_workspace_id = azurerm_log_analytics_solution.test.workspace_resource_id
+  display_name               = "acctest-TI-%d-update"
+  pattern_type               = "domain-name"
+  pattern                    = "[domain-name:value = 'http://www.example.com']"
+  source                     = "Microsoft Sentinel"
+  validate_from_utc          = "2022-12-14T16:00:00Z"
+  validate_until_utc         = "2032-12-14T16:00:00Z"
+  description                = "test indicator"
+  confidence                 = 20
+  created_by                 = "testcreated@microsoft.com"
+  language                   = "en"
+  revoked                    = true
+  tags                       = ["test-tags"]
+  threat_types               = ["compromised"]
+
+  external_reference {
+    description = "test-external"
+    source_name = "test-sourcename"
+    url         = "https://www.example.com"
+  }
+
+  granular_marking {
+    language    = "en"
+    marking_ref = 1
+    selectors   = ["test"]
+  }
+
+  kill_chain_phase {
+    kill_chain_name = "lockheed-martin-cyber-kill-chain"
+    phase_name      = "reconnaissance"
+  }
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (r SentinelThreatIntelligenceIndicatorResource) template(data acceptance.TestData) string {
+	return SentinelWatchlistResource{}.template(data)
+}
@@ -39,11 +39,15 @@ type Client struct {
 	SyncGroupsClient            *storagesync.SyncGroupsClient
 	SubscriptionId              string
 
+	options                   *common.ClientOptions
 	resourceManagerAuthorizer autorest.Authorizer
 	storageAdAuth             *autorest.Authorizer
 }
 
 func NewClient(options *common.ClientOptions) *Client {
+	// the Storage Account Keys are returned as `keys[].value`
+	common.RegisterRedactedFields("keys.value")
+
 	accountsClient := storage.NewAccountsClientWithBaseURI(options.ResourceManagerEndpoint, options.SubscriptionId)
 	options.ConfigureClient(&accountsClient.Client, options.ResourceManagerAuthorizer)
 
@@ -98,6 +102,7 @@ func NewClient(options *common.ClientOptions) *Client {
 		SyncServiceClient:           &syncServiceClient,
 		SyncGroupsClient:            &syncGroupsClient,
 
+		options:                   options,
 		resourceManagerAuthorizer: options.ResourceManagerAuthorizer,
 	}
 
@@ -111,7 +116,7 @@ func NewClient(options *common.ClientOptions) *Client {
 func (client Client) AccountsDataPlaneClient(ctx context.Context, account accountDetails) (*accounts.Client, error) {
 	if client.storageAdAuth != nil {
 		accountsClient := accounts.NewWithEnvironment(client.Environment)
-		accountsClient.Client.Authorizer = *client.storageAdAuth
+		client.options.ConfigureClient(&accountsClient.Client, *client.storageAdAuth)
 		return &accountsClient, nil
 	}
 
@@ -126,14 +131,14 @@ func (client Client) AccountsDataPlaneClient(ctx context.Context, account accoun
 	}
 
 	accountsClient := accounts.NewWithEnvironment(client.Environment)
-	accountsClient.Client.Authorizer = storageAuth
+	client.options.ConfigureClient(&accountsClient.Client, storageAuth)
 	return &accountsClient, nil
 }
 
 func (client Client) BlobsClient(ctx context.Context, account accountDetails) (*blobs.Client, error) {
 	if client.storageAdAuth != nil {
 		blobsClient := blobs.NewWithEnvironment(client.Environment)
-		blobsClient.Client.Authorizer = *client.storageAdAuth
+		client.options.ConfigureClient(&blobsClient.Client, *client.storageAdAuth)
 		return &blobsClient, nil
 	}
 
@@ -148,14 +153,14 @@ func (client Client) BlobsClient(ctx context.Context, account accountDetails) (*
 	}
 
 	blobsClient := blobs.NewWithEnvironment(client.Environment)
-	blobsClient.Client.Authorizer = storageAuth
+	client.options.ConfigureClient(&blobsClient.Client, storageAuth)
 	return &blobsClient, nil
 }
 
 func (client Client) ContainersClient(ctx context.Context, account accountDetails) (shim.StorageContainerWrapper, error) {
 	if client.storageAdAuth != nil {
 		containersClient := containers.NewWithEnvironment(client.Environment)
-		containersClient.Client.Authorizer = *client.storageAdAuth
+		client.options.ConfigureClient(&containersClient.Client, *client.storageAdAuth)
 		shim := shim.NewDataPlaneStorageContainerWrapper(&containersClient)
 		return shim, nil
 	}
@@ -171,7 +176,7 @@ func (client Client) ContainersClient(ctx context.Context, account accountDetail
 	}
 
 	containersClient := containers.NewWithEnvironment(client.Environment)
-	containersClient.Client.Authorizer = storageAuth
+	client.options.ConfigureClient(&containersClient.Client, storageAuth)
 
 	shim := shim.NewDataPlaneStorageContainerWrapper(&containersClient)
 	return shim, nil
@@ -191,7 +196,7 @@ func (client Client) FileShareDirectoriesClient(ctx context.Context, account acc
 	}
 
 	directoriesClient := directories.NewWithEnvironment(client.Environment)
-	directoriesClient.Client.Authorizer = storageAuth
+	client.options.ConfigureClient(&directoriesClient.Client, storageAuth)
 	return &directoriesClient, nil
 }
 
@@ -209,7 +214,7 @@ func (client Client) FileShareFilesClient(ctx context.Context, account accountDe
 	}
 
 	filesClient := files.NewWithEnvironment(client.Environment)
-	filesClient.Client.Authorizer = storageAuth
+	client.options.ConfigureClient(&filesClient.Client, storageAuth)
 	return &filesClient, nil
 }
 
@@ -227,7 +232,7 @@ func (client Client) FileSharesClient(ctx context.Context, account accountDetail
 	}
 
 	sharesClient := shares.NewWithEnvironment(client.Environment)
-	sharesClient.Client.Authorizer = storageAuth
+	client.options.ConfigureClient(&sharesClient.Client, storageAuth)
 	shim := shim.NewDataPlaneStorageShareWrapper(&sharesClient)
 	return shim, nil
 }
@@ -235,7 +240,7 @@ func (client Client) FileSharesClient(ctx context.Context, account accountDetail
 func (client Client) QueuesClient(ctx context.Context, account accountDetails) (shim.StorageQueuesWrapper, error) {
 	if client.storageAdAuth != nil {
 		queueClient := queues.NewWithEnvironment(client.Environment)
-		queueClient.Client.Authorizer = *client.storageAdAuth
+		client.options.ConfigureClient(&queueClient.Client, *client.storageAdAuth)
 		return shim.NewDataPlaneStorageQueueWrapper(&queueClient), nil
 	}
 
@@ -250,7 +255,7 @@ func (client Client) QueuesClient(ctx context.Context, account accountDetails) (
 	}
 
 	queuesClient := queues.NewWithEnvironment(client.Environment)
-	queuesClient.Client.Authorizer = storageAuth
+	client.options.ConfigureClient(&queuesClient.Client, storageAuth)
 	return shim.NewDataPlaneStorageQueueWrapper(&queuesClient), nil
 }
 
@@ -268,7 +273,7 @@ func (client Client) TableEntityClient(ctx context.Context, account accountDetai
 	}
 
 	entitiesClient := entities.NewWithEnvironment(client.Environment)
-	entitiesClient.Client.Authorizer = storageAuth
+	client.options.ConfigureClient(&entitiesClient.Client, storageAuth)
 	return &entitiesClient, nil
 }
 
@@ -286,7 +291,7 @@ func (client Client) TablesClient(ctx context.Context, account accountDetails) (
 	}
 
 	tablesClient := tables.NewWithEnvironment(client.Environment)
-	tablesClient.Client.Authorizer = storageAuth
+	client.options.ConfigureClient(&tablesClient.Client, storageAuth)
 	shim := shim.NewDataPlaneStorageTableWrapper(&tablesClient)
 	return shim, nil
 }
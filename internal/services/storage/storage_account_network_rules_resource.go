@@ -125,7 +125,9 @@ func resourceStorageAccountNetworkRulesCreateUpdate(d *pluginsdk.ResourceData, m
 	storageAccountName := d.Get("storage_account_name").(string)
 	resourceGroup := d.Get("resource_group_name").(string)
 
-	locks.ByName(storageAccountName, storageAccountResourceName)
+	if err := locks.ByNameWithContext(ctx, storageAccountName, storageAccountResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(storageAccountName, storageAccountResourceName)
 
 	storageAccount, err := client.GetProperties(ctx, resourceGroup, storageAccountName, "")
@@ -226,7 +228,9 @@ func resourceStorageAccountNetworkRulesDelete(d *pluginsdk.ResourceData, meta in
 	resourceGroup := parsedStorageAccountNetworkRuleId.ResourceGroup
 	storageAccountName := parsedStorageAccountNetworkRuleId.Path["storageAccounts"]
 
-	locks.ByName(storageAccountName, storageAccountResourceName)
+	if err := locks.ByNameWithContext(ctx, storageAccountName, storageAccountResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(storageAccountName, storageAccountResourceName)
 
 	storageAccount, err := client.GetProperties(ctx, resourceGroup, storageAccountName, "")
@@ -16,6 +16,7 @@ import (
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/azure"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/location"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/locks"
 	msiparse "github.com/hashicorp/terraform-provider-azurerm/internal/services/msi/parse"
 	msiValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/msi/validate"
@@ -874,6 +875,7 @@ func resourceStorageAccount() *pluginsdk.Resource {
 				}
 				return false
 			}),
+			location.ZoneRedundantSkuSupportedCustomizeDiff("location", "account_replication_type", "ZRS", "GZRS", "RAGZRS"),
 		),
 	}
 }
@@ -888,7 +890,9 @@ func resourceStorageAccountCreate(d *pluginsdk.ResourceData, meta interface{}) e
 	storageAccountName := d.Get("name").(string)
 	resourceGroupName := d.Get("resource_group_name").(string)
 
-	locks.ByName(storageAccountName, storageAccountResourceName)
+	if err := locks.ByNameWithContext(ctx, storageAccountName, storageAccountResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(storageAccountName, storageAccountResourceName)
 
 	existing, err := client.GetProperties(ctx, resourceGroupName, storageAccountName, "")
@@ -1151,7 +1155,9 @@ func resourceStorageAccountUpdate(d *pluginsdk.ResourceData, meta interface{}) e
 	storageAccountName := id.Path["storageAccounts"]
 	resourceGroupName := id.ResourceGroup
 
-	locks.ByName(storageAccountName, storageAccountResourceName)
+	if err := locks.ByNameWithContext(ctx, storageAccountName, storageAccountResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(storageAccountName, storageAccountResourceName)
 
 	accountTier := d.Get("account_tier").(string)
@@ -1233,7 +1239,7 @@ func resourceStorageAccountUpdate(d *pluginsdk.ResourceData, meta interface{}) e
 		}
 	}
 
-	if d.HasChange("tags") {
+	if d.HasChanges("tags", "tags_all") {
 		t := d.Get("tags").(map[string]interface{})
 
 		opts := storage.AccountUpdateParameters{
@@ -1775,7 +1781,9 @@ func resourceStorageAccountDelete(d *pluginsdk.ResourceData, meta interface{}) e
 	name := id.Path["storageAccounts"]
 	resourceGroup := id.ResourceGroup
 
-	locks.ByName(name, storageAccountResourceName)
+	if err := locks.ByNameWithContext(ctx, name, storageAccountResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(name, storageAccountResourceName)
 
 	read, err := client.GetProperties(ctx, resourceGroup, name, "")
@@ -1814,7 +1822,9 @@ func resourceStorageAccountDelete(d *pluginsdk.ResourceData, meta interface{}) e
 		}
 	}
 
-	locks.MultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
+	if err := locks.MultipleByNameWithContext(ctx, &virtualNetworkNames, network.VirtualNetworkResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockMultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
 
 	resp, err := client.Delete(ctx, resourceGroup, name)
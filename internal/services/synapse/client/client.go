@@ -6,7 +6,6 @@ import (
 	"github.com/Azure/azure-sdk-for-go/services/preview/synapse/2019-06-01-preview/managedvirtualnetwork"
 	"github.com/Azure/azure-sdk-for-go/services/preview/synapse/2020-08-01-preview/accesscontrol"
 	"github.com/Azure/azure-sdk-for-go/services/synapse/mgmt/2021-03-01/synapse"
-	"github.com/Azure/go-autorest/autorest"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
 )
 
@@ -20,7 +19,7 @@ type Client struct {
 	WorkspaceAadAdminsClient                         *synapse.WorkspaceAadAdminsClient
 	WorkspaceManagedIdentitySQLControlSettingsClient *synapse.WorkspaceManagedIdentitySQLControlSettingsClient
 
-	synapseAuthorizer autorest.Authorizer
+	options *common.ClientOptions
 }
 
 func NewClient(o *common.ClientOptions) *Client {
@@ -59,37 +58,37 @@ func NewClient(o *common.ClientOptions) *Client {
 		WorkspaceAadAdminsClient:                         &workspaceAadAdminsClient,
 		WorkspaceManagedIdentitySQLControlSettingsClient: &workspaceManagedIdentitySQLControlSettingsClient,
 
-		synapseAuthorizer: o.SynapseAuthorizer,
+		options: o,
 	}
 }
 
 func (client Client) RoleDefinitionsClient(workspaceName, synapseEndpointSuffix string) (*accesscontrol.RoleDefinitionsClient, error) {
-	if client.synapseAuthorizer == nil {
+	if client.options.SynapseAuthorizer == nil {
 		return nil, fmt.Errorf("Synapse is not supported in this Azure Environment")
 	}
 	endpoint := buildEndpoint(workspaceName, synapseEndpointSuffix)
 	roleDefinitionsClient := accesscontrol.NewRoleDefinitionsClient(endpoint)
-	roleDefinitionsClient.Client.Authorizer = client.synapseAuthorizer
+	client.options.ConfigureClient(&roleDefinitionsClient.Client, client.options.SynapseAuthorizer)
 	return &roleDefinitionsClient, nil
 }
 
 func (client Client) RoleAssignmentsClient(workspaceName, synapseEndpointSuffix string) (*accesscontrol.RoleAssignmentsClient, error) {
-	if client.synapseAuthorizer == nil {
+	if client.options.SynapseAuthorizer == nil {
 		return nil, fmt.Errorf("Synapse is not supported in this Azure Environment")
 	}
 	endpoint := buildEndpoint(workspaceName, synapseEndpointSuffix)
 	roleAssignmentsClient := accesscontrol.NewRoleAssignmentsClient(endpoint)
-	roleAssignmentsClient.Client.Authorizer = client.synapseAuthorizer
+	client.options.ConfigureClient(&roleAssignmentsClient.Client, client.options.SynapseAuthorizer)
 	return &roleAssignmentsClient, nil
 }
 
 func (client Client) ManagedPrivateEndpointsClient(workspaceName, synapseEndpointSuffix string) (*managedvirtualnetwork.ManagedPrivateEndpointsClient, error) {
-	if client.synapseAuthorizer == nil {
+	if client.options.SynapseAuthorizer == nil {
 		return nil, fmt.Errorf("Synapse is not supported in this Azure Environment")
 	}
 	endpoint := buildEndpoint(workspaceName, synapseEndpointSuffix)
 	managedPrivateEndpointsClient := managedvirtualnetwork.NewManagedPrivateEndpointsClient(endpoint)
-	managedPrivateEndpointsClient.Client.Authorizer = client.synapseAuthorizer
+	client.options.ConfigureClient(&managedPrivateEndpointsClient.Client, client.options.SynapseAuthorizer)
 	return &managedPrivateEndpointsClient, nil
 }
 
@@ -0,0 +1,130 @@
+package tags
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+// Config is the tags configuration from the Provider block, which is stored on the Client
+// (rather than globally) since multiple instances of the Provider can be configured in a single process
+type Config struct {
+	// defaultTags are the tags from the `default_tags` block, which are merged into the tags of every resource
+	defaultTags map[string]string
+
+	// ignoredKeys and ignoredKeyPrefixes are the (lower-cased) tag keys from the `ignore_tags` block,
+	// which are managed outside of Terraform (for example by Azure Policy) - see ignore.go
+	ignoredKeys        map[string]struct{}
+	ignoredKeyPrefixes []string
+}
+
+// NewConfig returns the tags configuration for the specified default tags and ignored tag keys (and key prefixes)
+func NewConfig(defaultTags map[string]string, ignoredKeys []string, ignoredKeyPrefixes []string) *Config {
+	config := Config{
+		defaultTags:        make(map[string]string, len(defaultTags)),
+		ignoredKeys:        make(map[string]struct{}, len(ignoredKeys)),
+		ignoredKeyPrefixes: make([]string, 0, len(ignoredKeyPrefixes)),
+	}
+
+	for k, v := range defaultTags {
+		config.defaultTags[k] = v
+	}
+	for _, key := range ignoredKeys {
+		if key == "" {
+			continue
+		}
+		config.ignoredKeys[strings.ToLower(key)] = struct{}{}
+	}
+	for _, prefix := range ignoredKeyPrefixes {
+		if prefix == "" {
+			continue
+		}
+		config.ignoredKeyPrefixes = append(config.ignoredKeyPrefixes, strings.ToLower(prefix))
+	}
+
+	return &config
+}
+
+// WithDefaults returns the configured tags merged with the default tags (where the configured tags take precedence)
+func (c *Config) WithDefaults(configured map[string]interface{}) map[string]interface{} {
+	output := make(map[string]interface{}, len(configured))
+	for k, v := range configured {
+		output[k] = v
+	}
+	if c == nil {
+		return output
+	}
+
+	for k, v := range c.defaultTags {
+		if _, exists := output[k]; exists {
+			continue
+		}
+
+		output[k] = v
+	}
+
+	return output
+}
+
+// WithoutDefaults returns the tags excluding any ignored tags and any default tags (with the default value)
+// unless these are also specified in the configured tags - such that these don't show as a diff in the `tags` field
+func (c *Config) WithoutDefaults(input map[string]interface{}, configured map[string]interface{}) map[string]interface{} {
+	output := c.WithoutIgnored(input)
+	if c == nil {
+		return output
+	}
+
+	for k, v := range c.defaultTags {
+		if value, ok := output[k]; !ok || value != v {
+			continue
+		}
+		if _, ok := configured[k]; ok {
+			continue
+		}
+
+		delete(output, k)
+	}
+
+	return output
+}
+
+// CustomizeDiffTagsAll plans the value of the `tags_all` field when either the resource's `tags` or the
+// default tags have changed, so that changes to the default tags are applied to the resource
+func (c *Config) CustomizeDiffTagsAll(_ context.Context, diff *pluginsdk.ResourceDiff) error {
+	// the tags can't be determined until apply-time
+	if !diff.NewValueKnown("tags") {
+		return diff.SetNewComputed("tags_all")
+	}
+
+	configured := diff.Get("tags").(map[string]interface{})
+	expected := c.WithoutIgnored(c.WithDefaults(configured))
+	existing, _ := diff.Get("tags_all").(map[string]interface{})
+
+	// tags added outside of Terraform (for example by Azure Policy) are ignored, since these aren't managed
+	changed := diff.HasChange("tags")
+	for k, v := range expected {
+		if existing[k] != v {
+			changed = true
+			break
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	// ignored tags are retained by the resource when the tags are updated
+	return diff.SetNew("tags_all", c.PreserveIgnored(expected, existing))
+}
+
+// SchemaTagsAll returns the Schema used for the `tags_all` field, which contains all of the tags assigned
+// to the resource - including those inherited from the `default_tags` block in the Provider
+func SchemaTagsAll() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeMap,
+		Computed: true,
+		Elem: &pluginsdk.Schema{
+			Type: pluginsdk.TypeString,
+		},
+	}
+}
@@ -0,0 +1,122 @@
+package tags
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConfigWithDefaults(t *testing.T) {
+	config := NewConfig(map[string]string{
+		"environment": "Production",
+		"owner":       "platform",
+	}, nil, nil)
+
+	actual := config.WithDefaults(map[string]interface{}{
+		"owner": "team1",
+		"name":  "example",
+	})
+	expected := map[string]interface{}{
+		"environment": "Production",
+		"owner":       "team1",
+		"name":        "example",
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %+v but got %+v", expected, actual)
+	}
+
+	var unconfigured *Config
+	actual = unconfigured.WithDefaults(map[string]interface{}{
+		"name": "example",
+	})
+	expected = map[string]interface{}{
+		"name": "example",
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %+v but got %+v", expected, actual)
+	}
+}
+
+func TestConfigWithoutDefaults(t *testing.T) {
+	config := NewConfig(map[string]string{
+		"environment": "Production",
+		"owner":       "platform",
+	}, []string{"ms-resource-usage"}, nil)
+
+	testData := []struct {
+		Name       string
+		Input      map[string]interface{}
+		Configured map[string]interface{}
+		Expected   map[string]interface{}
+	}{
+		{
+			Name: "Default Tags Excluded",
+			Input: map[string]interface{}{
+				"environment": "Production",
+				"owner":       "platform",
+				"name":        "example",
+			},
+			Configured: map[string]interface{}{
+				"name": "example",
+			},
+			Expected: map[string]interface{}{
+				"name": "example",
+			},
+		},
+		{
+			Name: "Default Tag Overridden",
+			Input: map[string]interface{}{
+				"environment": "Production",
+				"owner":       "team1",
+			},
+			Configured: map[string]interface{}{
+				"owner": "team1",
+			},
+			Expected: map[string]interface{}{
+				"owner": "team1",
+			},
+		},
+		{
+			Name: "Default Tag Configured With The Same Value",
+			Input: map[string]interface{}{
+				"environment": "Production",
+				"owner":       "platform",
+			},
+			Configured: map[string]interface{}{
+				"owner": "platform",
+			},
+			Expected: map[string]interface{}{
+				"owner": "platform",
+			},
+		},
+		{
+			Name: "Default Tag Changed Outside Of Terraform",
+			Input: map[string]interface{}{
+				"environment": "Development",
+			},
+			Configured: map[string]interface{}{},
+			Expected: map[string]interface{}{
+				"environment": "Development",
+			},
+		},
+		{
+			Name: "Ignored Tags Excluded",
+			Input: map[string]interface{}{
+				"name":              "example",
+				"ms-resource-usage": "azure-cloud-shell",
+			},
+			Configured: map[string]interface{}{},
+			Expected: map[string]interface{}{
+				"name": "example",
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test %q", v.Name)
+
+		actual := config.WithoutDefaults(v.Input, v.Configured)
+		if !reflect.DeepEqual(actual, v.Expected) {
+			t.Fatalf("expected %+v but got %+v", v.Expected, actual)
+		}
+	}
+}
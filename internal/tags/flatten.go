@@ -6,6 +6,8 @@ import (
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 )
 
+// Flatten flattens all of the tags - tags ignored via the `ignore_tags` block in the Provider are excluded
+// from the `tags` field by the Provider (using the Config from the Client) rather than here
 func Flatten(tagMap map[string]*string) map[string]interface{} {
 	// If tagsMap is nil, len(tagsMap) will be 0.
 	output := make(map[string]interface{}, len(tagMap))
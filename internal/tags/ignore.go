@@ -0,0 +1,70 @@
+package tags
+
+import (
+	"strings"
+)
+
+// HasIgnoredTags returns whether any tag keys (or key prefixes) are ignored
+func (c *Config) HasIgnoredTags() bool {
+	if c == nil {
+		return false
+	}
+
+	return len(c.ignoredKeys) > 0 || len(c.ignoredKeyPrefixes) > 0
+}
+
+// IsIgnored returns whether the specified tag key is ignored - tag keys are compared case-insensitively
+// since Azure treats these as case-insensitive
+func (c *Config) IsIgnored(key string) bool {
+	if c == nil {
+		return false
+	}
+
+	key = strings.ToLower(key)
+	if _, ok := c.ignoredKeys[key]; ok {
+		return true
+	}
+	for _, prefix := range c.ignoredKeyPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// WithoutIgnored returns the tags excluding any tags which are ignored
+func (c *Config) WithoutIgnored(input map[string]interface{}) map[string]interface{} {
+	output := make(map[string]interface{}, len(input))
+	for k, v := range input {
+		if c.IsIgnored(k) {
+			continue
+		}
+
+		output[k] = v
+	}
+
+	return output
+}
+
+// PreserveIgnored returns the configured tags along with any ignored tags from the existing tags which aren't
+// configured, such that updating the tags of a resource doesn't remove the tags managed outside of Terraform
+func (c *Config) PreserveIgnored(configured map[string]interface{}, existing map[string]interface{}) map[string]interface{} {
+	output := make(map[string]interface{}, len(configured))
+	for k, v := range configured {
+		output[k] = v
+	}
+
+	for k, v := range existing {
+		if !c.IsIgnored(k) {
+			continue
+		}
+		if _, ok := output[k]; ok {
+			continue
+		}
+
+		output[k] = v
+	}
+
+	return output
+}
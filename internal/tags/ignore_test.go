@@ -0,0 +1,114 @@
+package tags
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConfigIsIgnored(t *testing.T) {
+	config := NewConfig(nil, []string{"ms-resource-usage"}, []string{"hidden-link:"})
+
+	testData := []struct {
+		Key      string
+		Expected bool
+	}{
+		{
+			Key:      "environment",
+			Expected: false,
+		},
+		{
+			Key:      "ms-resource-usage",
+			Expected: true,
+		},
+		{
+			Key:      "MS-Resource-Usage",
+			Expected: true,
+		},
+		{
+			Key:      "ms-resource-usage-2",
+			Expected: false,
+		},
+		{
+			Key:      "hidden-link:/app-insights-resource-id",
+			Expected: true,
+		},
+		{
+			Key:      "hidden-link",
+			Expected: false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.Key)
+
+		if actual := config.IsIgnored(v.Key); actual != v.Expected {
+			t.Fatalf("expected %t but got %t", v.Expected, actual)
+		}
+	}
+
+	var unconfigured *Config
+	if unconfigured.HasIgnoredTags() || unconfigured.IsIgnored("ms-resource-usage") {
+		t.Fatalf("expected no tags to be ignored when the Config is nil")
+	}
+}
+
+func TestConfigPreserveIgnored(t *testing.T) {
+	config := NewConfig(nil, []string{"ms-resource-usage"}, []string{"hidden-link:"})
+
+	testData := []struct {
+		Name       string
+		Configured map[string]interface{}
+		Existing   map[string]interface{}
+		Expected   map[string]interface{}
+	}{
+		{
+			Name: "No Ignored Tags",
+			Configured: map[string]interface{}{
+				"environment": "Production",
+			},
+			Existing: map[string]interface{}{
+				"environment": "Development",
+				"removed":     "value",
+			},
+			Expected: map[string]interface{}{
+				"environment": "Production",
+			},
+		},
+		{
+			Name: "Ignored Tags Preserved",
+			Configured: map[string]interface{}{
+				"environment": "Production",
+			},
+			Existing: map[string]interface{}{
+				"ms-resource-usage":       "azure-cloud-shell",
+				"hidden-link:/some/scope": "Resource",
+			},
+			Expected: map[string]interface{}{
+				"environment":             "Production",
+				"ms-resource-usage":       "azure-cloud-shell",
+				"hidden-link:/some/scope": "Resource",
+			},
+		},
+		{
+			Name: "Configured Value Takes Precedence",
+			Configured: map[string]interface{}{
+				"ms-resource-usage": "terraform",
+			},
+			Existing: map[string]interface{}{
+				"ms-resource-usage": "azure-cloud-shell",
+			},
+			Expected: map[string]interface{}{
+				"ms-resource-usage": "terraform",
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test %q", v.Name)
+
+		actual := config.PreserveIgnored(v.Configured, v.Existing)
+		if !reflect.DeepEqual(actual, v.Expected) {
+			t.Fatalf("expected %+v but got %+v", v.Expected, actual)
+		}
+	}
+}
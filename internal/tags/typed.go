@@ -12,6 +12,8 @@ func FromTypedObject(input map[string]string) map[string]*string {
 	return output
 }
 
+// ToTypedObject converts all of the tags into the typed representation - as with Flatten, ignored tags are
+// excluded from the `tags` field by the Provider rather than here
 func ToTypedObject(input map[string]*string) map[string]string {
 	output := make(map[string]string)
 
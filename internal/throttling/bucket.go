@@ -0,0 +1,117 @@
+package throttling
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+const (
+	// minimumRequestsPerSecond is the lowest rate which an adaptive bucket will back off to
+	// (unless a lower rate has been configured, which is used instead - see minimumRate)
+	minimumRequestsPerSecond = 0.2
+
+	// lowRemainingThreshold is the number of remaining requests (as returned in the
+	// `x-ms-ratelimit-remaining-*` headers) below which the request rate is reduced
+	lowRemainingThreshold = 25
+
+	// recoveryFactor is the proportion of the configured rate which is restored
+	// after each response which reports sufficient remaining quota
+	recoveryFactor = 0.1
+)
+
+// bucket is a token bucket for a single Subscription and Resource Provider
+type bucket struct {
+	sync.Mutex
+
+	maximumRate float64
+	rate        float64
+	burst       float64
+	tokens      float64
+	lastRefill  time.Time
+	pausedUntil time.Time
+}
+
+func newBucket(options Options, now time.Time) *bucket {
+	return &bucket{
+		maximumRate: options.RequestsPerSecond,
+		rate:        options.RequestsPerSecond,
+		burst:       float64(options.Burst),
+		tokens:      float64(options.Burst),
+		lastRefill:  now,
+	}
+}
+
+// reserve takes a token from the bucket, returning how long the caller must wait before sending the request
+func (b *bucket) reserve(now time.Time) time.Duration {
+	b.Lock()
+	defer b.Unlock()
+
+	b.refill(now)
+	b.tokens--
+
+	var wait time.Duration
+	if paused := b.pausedUntil.Sub(now); paused > 0 {
+		wait = paused
+	}
+	if b.tokens < 0 {
+		wait += time.Duration(-b.tokens / b.rate * float64(time.Second))
+	}
+	return wait
+}
+
+// release returns a token which was reserved but not used (e.g. when the context was cancelled)
+func (b *bucket) release() {
+	b.Lock()
+	defer b.Unlock()
+
+	b.tokens = math.Min(b.burst, b.tokens+1)
+}
+
+// throttled pauses the bucket for the duration specified by Resource Manager and halves the request rate
+func (b *bucket) throttled(now time.Time, retryAfter time.Duration) {
+	b.Lock()
+	defer b.Unlock()
+
+	b.refill(now)
+	if until := now.Add(retryAfter); until.After(b.pausedUntil) {
+		b.pausedUntil = until
+	}
+
+	// drain the bucket and only refill it once the pause has expired, so that requests
+	// aren't sent as a burst when the pause ends
+	b.tokens = math.Min(0, b.tokens)
+	b.lastRefill = b.pausedUntil
+	b.rate = math.Max(b.minimumRate(), b.rate/2)
+}
+
+// observeRemaining adjusts the request rate based on the remaining quota returned by Resource Manager
+func (b *bucket) observeRemaining(now time.Time, remaining int64) {
+	b.Lock()
+	defer b.Unlock()
+
+	b.refill(now)
+	if remaining < lowRemainingThreshold {
+		target := b.maximumRate * float64(remaining) / lowRemainingThreshold
+		b.rate = math.Max(b.minimumRate(), math.Min(b.rate, target))
+		return
+	}
+
+	b.rate = math.Min(b.maximumRate, b.rate+b.maximumRate*recoveryFactor)
+}
+
+// minimumRate returns the lowest rate which this bucket will back off to, which mustn't
+// exceed the configured rate - otherwise backing off would increase the request rate
+func (b *bucket) minimumRate() float64 {
+	return math.Min(minimumRequestsPerSecond, b.maximumRate)
+}
+
+func (b *bucket) refill(now time.Time) {
+	elapsed := now.Sub(b.lastRefill).Seconds()
+	if elapsed <= 0 {
+		return
+	}
+
+	b.tokens = math.Min(b.burst, b.tokens+elapsed*b.rate)
+	b.lastRefill = now
+}
@@ -0,0 +1,129 @@
+package throttling
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBucketReserve(t *testing.T) {
+	now := time.Date(2021, 9, 1, 10, 0, 0, 0, time.UTC)
+	b := newBucket(Options{
+		RequestsPerSecond: 2,
+		Burst:             2,
+	}, now)
+
+	// the burst can be sent immediately
+	for i := 0; i < 2; i++ {
+		if wait := b.reserve(now); wait != 0 {
+			t.Fatalf("expected request %d to be sent immediately but got a wait of %s", i, wait)
+		}
+	}
+
+	// subsequent requests are spread out at the configured rate
+	if wait := b.reserve(now); wait != 500*time.Millisecond {
+		t.Fatalf("expected a wait of 500ms but got %s", wait)
+	}
+	if wait := b.reserve(now); wait != time.Second {
+		t.Fatalf("expected a wait of 1s but got %s", wait)
+	}
+
+	// and the bucket refills over time
+	later := now.Add(5 * time.Second)
+	if wait := b.reserve(later); wait != 0 {
+		t.Fatalf("expected no wait after the bucket has refilled but got %s", wait)
+	}
+}
+
+func TestBucketRelease(t *testing.T) {
+	now := time.Date(2021, 9, 1, 10, 0, 0, 0, time.UTC)
+	b := newBucket(Options{
+		RequestsPerSecond: 1,
+		Burst:             1,
+	}, now)
+
+	b.reserve(now)
+	b.release()
+	if wait := b.reserve(now); wait != 0 {
+		t.Fatalf("expected no wait after releasing a token but got %s", wait)
+	}
+}
+
+func TestBucketThrottled(t *testing.T) {
+	now := time.Date(2021, 9, 1, 10, 0, 0, 0, time.UTC)
+	b := newBucket(Options{
+		RequestsPerSecond: 4,
+		Burst:             10,
+	}, now)
+
+	b.throttled(now, 10*time.Second)
+	if b.rate != 2 {
+		t.Fatalf("expected the rate to be halved to 2 but got %f", b.rate)
+	}
+
+	// requests made during the pause wait for the pause to expire and then the rate
+	if wait := b.reserve(now.Add(4 * time.Second)); wait != 6500*time.Millisecond {
+		t.Fatalf("expected a wait of 6.5s but got %s", wait)
+	}
+
+	// the rate never drops below the minimum
+	for i := 0; i < 10; i++ {
+		b.throttled(now, time.Second)
+	}
+	if b.rate != minimumRequestsPerSecond {
+		t.Fatalf("expected the rate to be %f but got %f", minimumRequestsPerSecond, b.rate)
+	}
+}
+
+func TestBucketBackOffBelowMinimumRate(t *testing.T) {
+	now := time.Date(2021, 9, 1, 10, 0, 0, 0, time.UTC)
+	b := newBucket(Options{
+		RequestsPerSecond: 0.1,
+		Burst:             1,
+	}, now)
+
+	// a rate configured below the minimum is never increased when backing off
+	b.throttled(now, time.Second)
+	if b.rate != 0.1 {
+		t.Fatalf("expected the rate to remain at 0.1 but got %f", b.rate)
+	}
+
+	b.observeRemaining(now, 0)
+	if b.rate != 0.1 {
+		t.Fatalf("expected the rate to remain at 0.1 but got %f", b.rate)
+	}
+}
+
+func TestBucketObserveRemaining(t *testing.T) {
+	now := time.Date(2021, 9, 1, 10, 0, 0, 0, time.UTC)
+	b := newBucket(Options{
+		RequestsPerSecond: 10,
+		Burst:             10,
+	}, now)
+
+	b.observeRemaining(now, 1000)
+	if b.rate != 10 {
+		t.Fatalf("expected the rate to remain at 10 but got %f", b.rate)
+	}
+
+	b.observeRemaining(now, 5)
+	if b.rate != 2 {
+		t.Fatalf("expected the rate to be reduced to 2 but got %f", b.rate)
+	}
+
+	b.observeRemaining(now, 0)
+	if b.rate != minimumRequestsPerSecond {
+		t.Fatalf("expected the rate to be reduced to %f but got %f", minimumRequestsPerSecond, b.rate)
+	}
+
+	// once there's sufficient quota the rate gradually recovers
+	b.observeRemaining(now, 1000)
+	if b.rate != minimumRequestsPerSecond+1 {
+		t.Fatalf("expected the rate to recover to %f but got %f", minimumRequestsPerSecond+1, b.rate)
+	}
+	for i := 0; i < 20; i++ {
+		b.observeRemaining(now, 1000)
+	}
+	if b.rate != 10 {
+		t.Fatalf("expected the rate to recover to 10 but got %f", b.rate)
+	}
+}
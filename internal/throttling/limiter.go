@@ -0,0 +1,202 @@
+package throttling
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+const (
+	// defaultRetryAfter is the duration a bucket is paused for when Resource Manager throttles
+	// a request without specifying a `Retry-After` header
+	defaultRetryAfter = 5 * time.Second
+
+	remainingHeaderPrefix = "X-Ms-Ratelimit-Remaining-"
+)
+
+// Limiter rate limits requests sent to Azure Resource Manager using a token bucket per
+// Subscription and Resource Provider - a single Limiter is intended to be shared by
+// every client within the Provider so that the limits apply across all resources.
+type Limiter struct {
+	options Options
+
+	bucketsLock sync.Mutex
+	buckets     map[string]*bucket
+
+	// now is overridden in tests
+	now func() time.Time
+}
+
+func NewLimiter(options Options) *Limiter {
+	return &Limiter{
+		options: options,
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+// WithThrottling returns a Sender which rate limits any requests to Resource Manager before
+// sending them using the specified Sender - requests to other API's (for example data plane
+// API's) are sent without being rate limited.
+func (l *Limiter) WithThrottling(sender autorest.Sender) autorest.Sender {
+	return throttledSender{
+		limiter: l,
+		sender:  sender,
+	}
+}
+
+func (l *Limiter) bucketFor(key string) *bucket {
+	l.bucketsLock.Lock()
+	defer l.bucketsLock.Unlock()
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = newBucket(l.options, l.now())
+		l.buckets[key] = b
+	}
+	return b
+}
+
+func (l *Limiter) wait(ctx context.Context, key string, b *bucket) error {
+	delay := b.reserve(l.now())
+	if delay <= 0 {
+		return nil
+	}
+
+	log.Printf("[DEBUG] Throttling: delaying request for %q by %s", key, delay)
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		b.release()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (l *Limiter) observe(key string, b *bucket, resp *http.Response) {
+	now := l.now()
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		retryAfter := parseRetryAfter(resp.Header.Get("Retry-After"), now)
+		log.Printf("[DEBUG] Throttling: requests for %q were throttled by Resource Manager, pausing for %s", key, retryAfter)
+		b.throttled(now, retryAfter)
+		return
+	}
+
+	if remaining, ok := lowestRemaining(resp.Header); ok {
+		b.observeRemaining(now, remaining)
+	}
+}
+
+type throttledSender struct {
+	limiter *Limiter
+	sender  autorest.Sender
+}
+
+func (s throttledSender) Do(r *http.Request) (*http.Response, error) {
+	key, ok := bucketKey(r.URL)
+	if !ok {
+		return s.sender.Do(r)
+	}
+
+	b := s.limiter.bucketFor(key)
+	if err := s.limiter.wait(r.Context(), key, b); err != nil {
+		return nil, fmt.Errorf("waiting to send a %s request to %q: %+v", r.Method, r.URL.Path, err)
+	}
+
+	resp, err := s.sender.Do(r)
+	if resp != nil && s.limiter.options.Adaptive {
+		s.limiter.observe(key, b, resp)
+	}
+	return resp, err
+}
+
+// bucketKey returns the key of the bucket used for the specified Resource Manager URL,
+// which is comprised of the Subscription ID and Resource Provider - for example
+// `00000000-0000-0000-0000-000000000000/microsoft.network`
+func bucketKey(uri *url.URL) (string, bool) {
+	if uri == nil {
+		return "", false
+	}
+
+	segments := strings.Split(strings.Trim(uri.Path, "/"), "/")
+	if len(segments) < 2 || !strings.EqualFold(segments[0], "subscriptions") || segments[1] == "" {
+		return "", false
+	}
+	subscriptionId := strings.ToLower(segments[1])
+
+	// nested resources (e.g. Diagnostic Settings) are throttled by the innermost Resource Provider
+	resourceProvider := "microsoft.resources"
+	for i := len(segments) - 2; i >= 2; i-- {
+		if strings.EqualFold(segments[i], "providers") && segments[i+1] != "" {
+			resourceProvider = strings.ToLower(segments[i+1])
+			break
+		}
+	}
+
+	return fmt.Sprintf("%s/%s", subscriptionId, resourceProvider), true
+}
+
+// lowestRemaining returns the lowest remaining quota from the `x-ms-ratelimit-remaining-*` headers,
+// which are either a number (e.g. `x-ms-ratelimit-remaining-subscription-reads: 11999`) or a
+// comma separated list of policies (e.g. `x-ms-ratelimit-remaining-resource: Microsoft.Compute/HighCostGet3Min;107`)
+func lowestRemaining(headers http.Header) (int64, bool) {
+	found := false
+	var lowest int64
+	for name, values := range headers {
+		if !strings.HasPrefix(http.CanonicalHeaderKey(name), remainingHeaderPrefix) {
+			continue
+		}
+
+		for _, value := range values {
+			for _, item := range strings.Split(value, ",") {
+				if idx := strings.LastIndex(item, ";"); idx != -1 {
+					item = item[idx+1:]
+				}
+
+				remaining, err := strconv.ParseInt(strings.TrimSpace(item), 10, 64)
+				if err != nil {
+					continue
+				}
+
+				if !found || remaining < lowest {
+					lowest = remaining
+					found = true
+				}
+			}
+		}
+	}
+
+	return lowest, found
+}
+
+// parseRetryAfter parses the `Retry-After` header, which is either a number of seconds or a HTTP Date
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return defaultRetryAfter
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		if retryAfter := date.Sub(now); retryAfter > 0 {
+			return retryAfter
+		}
+		return 0
+	}
+
+	return defaultRetryAfter
+}
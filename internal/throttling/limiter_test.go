@@ -0,0 +1,289 @@
+package throttling
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestBucketKey(t *testing.T) {
+	testData := []struct {
+		path     string
+		expected string
+		ok       bool
+	}{
+		{
+			path: "/",
+			ok:   false,
+		},
+		{
+			// data plane
+			path: "/container1/blob1",
+			ok:   false,
+		},
+		{
+			path: "/subscriptions/",
+			ok:   false,
+		},
+		{
+			path:     "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1",
+			expected: "00000000-0000-0000-0000-000000000000/microsoft.resources",
+			ok:       true,
+		},
+		{
+			path:     "/subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.Compute/virtualMachines",
+			expected: "00000000-0000-0000-0000-000000000000/microsoft.compute",
+			ok:       true,
+		},
+		{
+			path:     "/Subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Network/virtualNetworks/network1",
+			expected: "00000000-0000-0000-0000-000000000000/microsoft.network",
+			ok:       true,
+		},
+		{
+			path:     "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Network/virtualNetworks/network1/providers/Microsoft.Insights/diagnosticSettings/setting1",
+			expected: "00000000-0000-0000-0000-000000000000/microsoft.insights",
+			ok:       true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.path)
+
+		actual, ok := bucketKey(&url.URL{Path: v.path})
+		if ok != v.ok {
+			t.Fatalf("expected ok to be %t but got %t", v.ok, ok)
+		}
+		if actual != v.expected {
+			t.Fatalf("expected %q but got %q", v.expected, actual)
+		}
+	}
+}
+
+func TestLowestRemaining(t *testing.T) {
+	testData := []struct {
+		name     string
+		headers  map[string]string
+		expected int64
+		ok       bool
+	}{
+		{
+			name: "no headers",
+			ok:   false,
+		},
+		{
+			name: "unrelated headers",
+			headers: map[string]string{
+				"Content-Type": "application/json",
+			},
+			ok: false,
+		},
+		{
+			name: "subscription reads",
+			headers: map[string]string{
+				"x-ms-ratelimit-remaining-subscription-reads": "11999",
+			},
+			expected: 11999,
+			ok:       true,
+		},
+		{
+			name: "multiple headers",
+			headers: map[string]string{
+				"x-ms-ratelimit-remaining-subscription-writes": "1199",
+				"x-ms-ratelimit-remaining-tenant-writes":       "1150",
+			},
+			expected: 1150,
+			ok:       true,
+		},
+		{
+			name: "resource policies",
+			headers: map[string]string{
+				"x-ms-ratelimit-remaining-subscription-reads": "11999",
+				"x-ms-ratelimit-remaining-resource":           "Microsoft.Compute/HighCostGet3Min;107,Microsoft.Compute/HighCostGet30Min;23",
+			},
+			expected: 23,
+			ok:       true,
+		},
+		{
+			name: "invalid value",
+			headers: map[string]string{
+				"x-ms-ratelimit-remaining-subscription-reads": "hello",
+			},
+			ok: false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		headers := http.Header{}
+		for k, val := range v.headers {
+			headers.Set(k, val)
+		}
+
+		actual, ok := lowestRemaining(headers)
+		if ok != v.ok {
+			t.Fatalf("expected ok to be %t but got %t", v.ok, ok)
+		}
+		if actual != v.expected {
+			t.Fatalf("expected %d but got %d", v.expected, actual)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2021, 9, 1, 10, 0, 0, 0, time.UTC)
+	testData := []struct {
+		value    string
+		expected time.Duration
+	}{
+		{
+			value:    "",
+			expected: defaultRetryAfter,
+		},
+		{
+			value:    "17",
+			expected: 17 * time.Second,
+		},
+		{
+			value:    "Wed, 01 Sep 2021 10:00:30 GMT",
+			expected: 30 * time.Second,
+		},
+		{
+			value:    "Wed, 01 Sep 2021 09:00:00 GMT",
+			expected: 0,
+		},
+		{
+			value:    "later",
+			expected: defaultRetryAfter,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.value)
+
+		actual := parseRetryAfter(v.value, now)
+		if actual != v.expected {
+			t.Fatalf("expected %s but got %s", v.expected, actual)
+		}
+	}
+}
+
+type testSender struct {
+	requests int
+	response func(r *http.Request) *http.Response
+}
+
+func (s *testSender) Do(r *http.Request) (*http.Response, error) {
+	s.requests++
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{},
+		Request:    r,
+	}
+	if s.response != nil {
+		resp = s.response(r)
+	}
+	return resp, nil
+}
+
+func TestThrottledSenderIgnoresDataPlaneRequests(t *testing.T) {
+	limiter := NewLimiter(Options{
+		RequestsPerSecond: 1,
+		Burst:             1,
+		Adaptive:          true,
+	})
+	sender := &testSender{}
+	throttled := limiter.WithThrottling(sender)
+
+	for i := 0; i < 5; i++ {
+		req, _ := http.NewRequest(http.MethodGet, "https://account1.blob.core.windows.net/container1/blob1", nil)
+		if _, err := throttled.Do(req); err != nil {
+			t.Fatalf("sending request: %+v", err)
+		}
+	}
+
+	if sender.requests != 5 {
+		t.Fatalf("expected 5 requests but got %d", sender.requests)
+	}
+	if len(limiter.buckets) != 0 {
+		t.Fatalf("expected no buckets but got %d", len(limiter.buckets))
+	}
+}
+
+func TestThrottledSenderCancelledContext(t *testing.T) {
+	now := time.Date(2021, 9, 1, 10, 0, 0, 0, time.UTC)
+	limiter := NewLimiter(Options{
+		RequestsPerSecond: 1,
+		Burst:             1,
+		Adaptive:          true,
+	})
+	limiter.now = func() time.Time {
+		return now
+	}
+	sender := &testSender{}
+	throttled := limiter.WithThrottling(sender)
+	uri := "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1"
+
+	// the first request consumes the only token in the bucket
+	req, _ := http.NewRequest(http.MethodGet, uri, nil)
+	if _, err := throttled.Do(req); err != nil {
+		t.Fatalf("sending request: %+v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req, _ = http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if _, err := throttled.Do(req); err == nil {
+		t.Fatalf("expected an error but didn't get one")
+	}
+
+	if sender.requests != 1 {
+		t.Fatalf("expected 1 request but got %d", sender.requests)
+	}
+}
+
+func TestThrottledSenderPausesWhenThrottled(t *testing.T) {
+	now := time.Date(2021, 9, 1, 10, 0, 0, 0, time.UTC)
+	limiter := NewLimiter(Options{
+		RequestsPerSecond: 10,
+		Burst:             10,
+		Adaptive:          true,
+	})
+	limiter.now = func() time.Time {
+		return now
+	}
+	sender := &testSender{
+		response: func(r *http.Request) *http.Response {
+			return &http.Response{
+				StatusCode: http.StatusTooManyRequests,
+				Header: http.Header{
+					"Retry-After": []string{"30"},
+				},
+				Request: r,
+			}
+		},
+	}
+	throttled := limiter.WithThrottling(sender)
+
+	req, _ := http.NewRequest(http.MethodPut, "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Network/virtualNetworks/network1", nil)
+	if _, err := throttled.Do(req); err != nil {
+		t.Fatalf("sending request: %+v", err)
+	}
+
+	b := limiter.bucketFor("00000000-0000-0000-0000-000000000000/microsoft.network")
+	if wait := b.reserve(now); wait < 30*time.Second {
+		t.Fatalf("expected the bucket to be paused for at least 30s but got %s", wait)
+	}
+	if b.rate != 5 {
+		t.Fatalf("expected the rate to be halved to 5 but got %f", b.rate)
+	}
+
+	// other Resource Providers shouldn't be affected
+	other := limiter.bucketFor("00000000-0000-0000-0000-000000000000/microsoft.compute")
+	if wait := other.reserve(now); wait != 0 {
+		t.Fatalf("expected no wait for another Resource Provider but got %s", wait)
+	}
+}
@@ -0,0 +1,27 @@
+package throttling
+
+// Options configures the client-side rate limiting applied to requests sent to Azure Resource Manager
+type Options struct {
+	// RequestsPerSecond is the sustained rate at which requests can be sent for each
+	// combination of Subscription and Resource Provider
+	RequestsPerSecond float64
+
+	// Burst is the number of requests which can be sent at once before the
+	// RequestsPerSecond limit applies
+	Burst int
+
+	// Adaptive specifies whether the request rate should be reduced based on the remaining
+	// quota returned by Resource Manager (in the `x-ms-ratelimit-remaining-*` headers) and
+	// paused when a request is throttled (e.g. a 429 is returned)
+	Adaptive bool
+}
+
+// DefaultOptions returns the Options used when the `throttling` block is specified
+// in the Provider block without any values overridden
+func DefaultOptions() Options {
+	return Options{
+		RequestsPerSecond: 10,
+		Burst:             20,
+		Adaptive:          true,
+	}
+}
@@ -0,0 +1,63 @@
+package timeouts
+
+import (
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+// Defaults are the default timeouts for a Resource Type, specified in the `default_timeouts` block
+// in the Provider - which take precedence over the default timeouts defined by the Resource but
+// can be overridden by the `timeouts` block within the Resource
+type Defaults struct {
+	Create *time.Duration
+	Read   *time.Duration
+	Update *time.Duration
+	Delete *time.Duration
+}
+
+var (
+	defaultsLock sync.Mutex
+
+	// resourceTimeouts are the timeouts originally defined by each Resource, keyed by Resource Type - which
+	// are retained so that the default timeouts can be re-applied when the Provider is configured again
+	resourceTimeouts = map[string]pluginsdk.ResourceTimeout{}
+)
+
+// ApplyDefaults applies the default timeouts for the specified Resource Type to the Resource, such that these are
+// used by ForCreate/ForRead/ForUpdate/ForDelete (and the Typed SDK) when a `timeouts` block isn't specified.
+//
+// Default timeouts are only applied to the operations the Resource supports a timeout for.
+func ApplyDefaults(resourceType string, resource *pluginsdk.Resource, defaults *Defaults) {
+	defaultsLock.Lock()
+	defer defaultsLock.Unlock()
+
+	original, ok := resourceTimeouts[resourceType]
+	if !ok {
+		if resource.Timeouts == nil {
+			return
+		}
+		original = *resource.Timeouts
+		resourceTimeouts[resourceType] = original
+	}
+
+	output := original
+	if defaults != nil {
+		output.Create = overrideTimeout(original.Create, defaults.Create)
+		output.Read = overrideTimeout(original.Read, defaults.Read)
+		output.Update = overrideTimeout(original.Update, defaults.Update)
+		output.Delete = overrideTimeout(original.Delete, defaults.Delete)
+	}
+	resource.Timeouts = &output
+}
+
+func overrideTimeout(original *time.Duration, override *time.Duration) *time.Duration {
+	// a timeout can't be specified for an operation which the Resource doesn't define a timeout for
+	if original == nil || override == nil {
+		return original
+	}
+
+	value := *override
+	return &value
+}
@@ -0,0 +1,61 @@
+package timeouts
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func TestApplyDefaults(t *testing.T) {
+	duration := func(input time.Duration) *time.Duration {
+		return &input
+	}
+	resource := &pluginsdk.Resource{
+		Schema: map[string]*pluginsdk.Schema{},
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: duration(30 * time.Minute),
+			Read:   duration(5 * time.Minute),
+			Delete: duration(30 * time.Minute),
+		},
+	}
+
+	ApplyDefaults("azurerm_example_resource", resource, &Defaults{
+		Create: duration(90 * time.Minute),
+		// the resource doesn't support an Update timeout, so this should be ignored
+		Update: duration(90 * time.Minute),
+	})
+	if *resource.Timeouts.Create != 90*time.Minute {
+		t.Fatalf("expected the Create timeout to be 90m but got %s", *resource.Timeouts.Create)
+	}
+	if *resource.Timeouts.Read != 5*time.Minute {
+		t.Fatalf("expected the Read timeout to be 5m but got %s", *resource.Timeouts.Read)
+	}
+	if resource.Timeouts.Update != nil {
+		t.Fatalf("expected no Update timeout but got %s", *resource.Timeouts.Update)
+	}
+
+	// a `timeouts` block within the resource takes precedence
+	configured := &pluginsdk.ResourceTimeout{}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"timeouts": map[string]interface{}{
+			"create": "10m",
+		},
+	})
+	if err := configured.ConfigDecode(resource, config); err != nil {
+		t.Fatalf("decoding timeouts: %+v", err)
+	}
+	if *configured.Create != 10*time.Minute {
+		t.Fatalf("expected the configured Create timeout to be 10m but got %s", *configured.Create)
+	}
+	if *configured.Delete != 30*time.Minute {
+		t.Fatalf("expected the configured Delete timeout to be 30m but got %s", *configured.Delete)
+	}
+
+	// removing the default timeouts restores the timeouts defined by the resource
+	ApplyDefaults("azurerm_example_resource", resource, nil)
+	if *resource.Timeouts.Create != 30*time.Minute {
+		t.Fatalf("expected the Create timeout to be restored to 30m but got %s", *resource.Timeouts.Create)
+	}
+}
@@ -0,0 +1,499 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// NOTE: since we're using `go run` for these tools all of the code needs to live within the main.go
+
+func main() {
+	f := flag.NewFlagSet("schema-diff", flag.ExitOnError)
+
+	oldCatalogue := f.String("old", "", "The path to the catalogue (exported via the `schema-export` tool) for the old version of the Provider")
+	newCatalogue := f.String("new", "", "The path to the catalogue (exported via the `schema-export` tool) for the new version of the Provider")
+	oldRevision := f.String("old-revision", "", "The git revision of the old version of the Provider, which is exported rather than specifying `-old`")
+	newRevision := f.String("new-revision", "", "The git revision of the new version of the Provider, which is exported rather than specifying `-new`")
+	includeWarnings := f.Bool("include-warnings", true, "Should changes which may be breaking (such as changed validation) be included in the report?")
+	failOnBreakingChanges := f.Bool("fail-on-breaking-changes", false, "Should this exit with a non-zero exit code when breaking changes are detected?")
+
+	_ = f.Parse(os.Args[1:])
+
+	quitWithError := func(message string) {
+		log.Print(message)
+		os.Exit(1)
+	}
+
+	if (*oldCatalogue == "") == (*oldRevision == "") {
+		quitWithError("Either `-old` or `-old-revision` must be specified")
+		return
+	}
+	if (*newCatalogue == "") == (*newRevision == "") {
+		quitWithError("Either `-new` or `-new-revision` must be specified")
+		return
+	}
+
+	oldVersion, err := loadCatalogue(*oldCatalogue, *oldRevision)
+	if err != nil {
+		quitWithError(fmt.Sprintf("loading the old version: %+v", err))
+		return
+	}
+	newVersion, err := loadCatalogue(*newCatalogue, *newRevision)
+	if err != nil {
+		quitWithError(fmt.Sprintf("loading the new version: %+v", err))
+		return
+	}
+
+	changes := compareCatalogues(*oldVersion, *newVersion)
+	if !*includeWarnings {
+		changes = changes.breaking()
+	}
+
+	if err := writeReport(os.Stdout, changes); err != nil {
+		quitWithError(fmt.Sprintf("writing report: %+v", err))
+		return
+	}
+
+	if *failOnBreakingChanges && len(changes.breaking()) > 0 {
+		os.Exit(1)
+	}
+}
+
+// Catalogue is the catalogue exported by the `schema-export` tool, only the fields used to detect
+// breaking changes are defined here
+type Catalogue struct {
+	FormatVersion string                  `json:"format_version"`
+	Provider      map[string]Attribute    `json:"provider"`
+	DataSources   map[string]ResourceInfo `json:"data_sources"`
+	Resources     map[string]ResourceInfo `json:"resources"`
+}
+
+func (c Catalogue) hasValidation() bool {
+	for _, resource := range c.Resources {
+		if schemaHasValidation(resource.Schema) {
+			return true
+		}
+	}
+	return false
+}
+
+func schemaHasValidation(input map[string]Attribute) bool {
+	for _, field := range input {
+		if field.Validation != "" || schemaHasValidation(field.Block) {
+			return true
+		}
+	}
+	return false
+}
+
+type ResourceInfo struct {
+	DeprecationMessage string               `json:"deprecation_message"`
+	Timeouts           map[string]string    `json:"timeouts"`
+	IdFormat           *IdFormat            `json:"id_format"`
+	Schema             map[string]Attribute `json:"schema"`
+}
+
+type IdFormat struct {
+	Name    string `json:"name"`
+	Example string `json:"example"`
+}
+
+type Attribute struct {
+	Type        string               `json:"type"`
+	Required    bool                 `json:"required"`
+	Optional    bool                 `json:"optional"`
+	Computed    bool                 `json:"computed"`
+	ForceNew    bool                 `json:"force_new"`
+	Sensitive   bool                 `json:"sensitive"`
+	Deprecated  string               `json:"deprecated"`
+	Default     interface{}          `json:"default"`
+	MinItems    int                  `json:"min_items"`
+	MaxItems    int                  `json:"max_items"`
+	Validation  string               `json:"validation"`
+	ElementType string               `json:"element_type"`
+	Block       map[string]Attribute `json:"block"`
+
+	// AllowedValues, Minimum and Maximum are the values accepted by the Validation Function
+	// where these could be determined (e.g. for `validation.StringInSlice` and `validation.IntBetween`)
+	AllowedValues []string `json:"allowed_values"`
+	Minimum       *int     `json:"minimum"`
+	Maximum       *int     `json:"maximum"`
+}
+
+func loadCatalogue(path string, revision string) (*Catalogue, error) {
+	if revision != "" {
+		exported, err := exportCatalogueAtRevision(revision)
+		if err != nil {
+			return nil, fmt.Errorf("exporting the catalogue at revision %q: %+v", revision, err)
+		}
+		defer os.Remove(exported)
+		path = exported
+	}
+
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading %q: %+v", path, err)
+	}
+
+	var catalogue Catalogue
+	if err := json.Unmarshal(contents, &catalogue); err != nil {
+		return nil, fmt.Errorf("parsing %q: %+v", path, err)
+	}
+
+	return &catalogue, nil
+}
+
+// exportCatalogueAtRevision checks out the specified revision into a temporary git worktree and runs the current
+// `schema-export` tool against it, returning the path to the exported catalogue
+func exportCatalogueAtRevision(revision string) (string, error) {
+	output, err := exec.Command("git", "rev-parse", "--show-toplevel").Output()
+	if err != nil {
+		return "", fmt.Errorf("determining the root of the repository: %+v", err)
+	}
+	repositoryRoot := strings.TrimSpace(string(output))
+
+	worktree, err := ioutil.TempDir("", "schema-diff")
+	if err != nil {
+		return "", fmt.Errorf("creating temp directory: %+v", err)
+	}
+	defer os.RemoveAll(worktree)
+
+	if err := runCommand(repositoryRoot, "git", "worktree", "add", "--quiet", "--detach", worktree, revision); err != nil {
+		return "", fmt.Errorf("creating a worktree: %+v", err)
+	}
+	defer func() {
+		if err := runCommand(repositoryRoot, "git", "worktree", "remove", "--force", worktree); err != nil {
+			log.Printf("[WARN] removing the worktree %q: %+v", worktree, err)
+		}
+	}()
+
+	// the `schema-export` tool from the current checkout is used (rather than the version at the revision) since
+	// older revisions (for example released versions of the Provider) predate this tool
+	toolSource, err := ioutil.ReadFile(filepath.Join(repositoryRoot, "internal", "tools", "schema-export", "main.go"))
+	if err != nil {
+		return "", fmt.Errorf("reading the `schema-export` tool: %+v", err)
+	}
+	toolPath := filepath.Join(worktree, "internal", "tools", "schema-export")
+	if err := os.MkdirAll(toolPath, 0755); err != nil {
+		return "", fmt.Errorf("creating %q: %+v", toolPath, err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(toolPath, "main.go"), toolSource, 0644); err != nil {
+		return "", fmt.Errorf("copying the `schema-export` tool into the worktree: %+v", err)
+	}
+
+	file, err := ioutil.TempFile("", "schema-diff-*.json")
+	if err != nil {
+		return "", fmt.Errorf("creating temp file: %+v", err)
+	}
+	_ = file.Close()
+
+	if err := runCommand(toolPath, "go", "run", "main.go", "-output", file.Name()); err != nil {
+		os.Remove(file.Name())
+		return "", fmt.Errorf("running the `schema-export` tool: %+v", err)
+	}
+
+	return file.Name(), nil
+}
+
+func runCommand(directory string, name string, args ...string) error {
+	cmd := exec.Command(name, args...)
+	cmd.Dir = directory
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+type Severity string
+
+const (
+	// SeverityBreaking is a change which requires users to change their configuration or would
+	// cause resources to be recreated
+	SeverityBreaking Severity = "Breaking"
+
+	// SeverityWarning is a change which may be breaking depending on the configuration
+	SeverityWarning Severity = "Warning"
+)
+
+type Change struct {
+	Severity Severity
+	Kind     string
+	Name     string
+	Path     string
+	Message  string
+}
+
+type Changes []Change
+
+func (c Changes) breaking() Changes {
+	output := make(Changes, 0)
+	for _, change := range c {
+		if change.Severity == SeverityBreaking {
+			output = append(output, change)
+		}
+	}
+	return output
+}
+
+func compareCatalogues(oldVersion, newVersion Catalogue) Changes {
+	changes := make(Changes, 0)
+
+	// catalogues exported by older versions of the `schema-export` tool don't contain the validation functions
+	c := comparer{
+		compareValidation: oldVersion.hasValidation() && newVersion.hasValidation(),
+	}
+	changes = append(changes, c.compareSchema("Provider", "provider", "", oldVersion.Provider, newVersion.Provider)...)
+	changes = append(changes, c.compareResources("Data Source", oldVersion.DataSources, newVersion.DataSources)...)
+	changes = append(changes, c.compareResources("Resource", oldVersion.Resources, newVersion.Resources)...)
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		if changes[i].Severity != changes[j].Severity {
+			return changes[i].Severity == SeverityBreaking
+		}
+		if changes[i].Kind != changes[j].Kind {
+			return changes[i].Kind < changes[j].Kind
+		}
+		if changes[i].Name != changes[j].Name {
+			return changes[i].Name < changes[j].Name
+		}
+		return changes[i].Path < changes[j].Path
+	})
+
+	return changes
+}
+
+type comparer struct {
+	compareValidation bool
+}
+
+func (c comparer) compareResources(kind string, oldVersion, newVersion map[string]ResourceInfo) Changes {
+	changes := make(Changes, 0)
+
+	for name, oldResource := range oldVersion {
+		newResource, ok := newVersion[name]
+		if !ok {
+			changes = append(changes, Change{
+				Severity: SeverityBreaking,
+				Kind:     kind,
+				Name:     name,
+				Message:  fmt.Sprintf("The %s has been removed.", kind),
+			})
+			continue
+		}
+
+		if oldResource.DeprecationMessage == "" && newResource.DeprecationMessage != "" {
+			changes = append(changes, Change{
+				Severity: SeverityWarning,
+				Kind:     kind,
+				Name:     name,
+				Message:  fmt.Sprintf("The %s has been deprecated: %s", kind, newResource.DeprecationMessage),
+			})
+		}
+
+		if oldResource.IdFormat != nil && newResource.IdFormat != nil && oldResource.IdFormat.Example != newResource.IdFormat.Example {
+			changes = append(changes, Change{
+				Severity: SeverityBreaking,
+				Kind:     kind,
+				Name:     name,
+				Message:  fmt.Sprintf("The format of the Resource ID has changed from `%s` to `%s`.", oldResource.IdFormat.Example, newResource.IdFormat.Example),
+			})
+		}
+
+		for _, operation := range sortedKeys(oldResource.Timeouts) {
+			oldTimeout, oldErr := time.ParseDuration(oldResource.Timeouts[operation])
+			newTimeout, newErr := time.ParseDuration(newResource.Timeouts[operation])
+			if oldErr != nil || newErr != nil || newTimeout >= oldTimeout {
+				continue
+			}
+
+			changes = append(changes, Change{
+				Severity: SeverityWarning,
+				Kind:     kind,
+				Name:     name,
+				Message:  fmt.Sprintf("The default `%s` timeout has been reduced from `%s` to `%s`.", operation, oldTimeout, newTimeout),
+			})
+		}
+
+		changes = append(changes, c.compareSchema(kind, name, "", oldResource.Schema, newResource.Schema)...)
+	}
+
+	return changes
+}
+
+func (c comparer) compareSchema(kind, name, prefix string, oldVersion, newVersion map[string]Attribute) Changes {
+	changes := make(Changes, 0)
+
+	change := func(severity Severity, path, message string, args ...interface{}) {
+		changes = append(changes, Change{
+			Severity: severity,
+			Kind:     kind,
+			Name:     name,
+			Path:     path,
+			Message:  fmt.Sprintf(message, args...),
+		})
+	}
+
+	for _, field := range sortedKeys(oldVersion) {
+		oldField := oldVersion[field]
+		path := field
+		if prefix != "" {
+			path = fmt.Sprintf("%s.%s", prefix, field)
+		}
+
+		newField, ok := newVersion[field]
+		if !ok {
+			change(SeverityBreaking, path, "The field has been removed.")
+			continue
+		}
+
+		if oldField.Type != newField.Type || oldField.ElementType != newField.ElementType {
+			change(SeverityBreaking, path, "The type has changed from `%s` to `%s`.", describeType(oldField), describeType(newField))
+		}
+		if !oldField.Required && newField.Required {
+			change(SeverityBreaking, path, "The field is now Required.")
+		}
+		if !oldField.ForceNew && newField.ForceNew {
+			change(SeverityBreaking, path, "Changing the field now forces a new resource to be created.")
+		}
+		if oldField.Computed && !newField.Computed && (oldField.Optional || oldField.Required) {
+			change(SeverityBreaking, path, "The field is no longer Computed, so a diff will be shown where this isn't specified.")
+		}
+		if (oldField.Optional || oldField.Required) && !newField.Optional && !newField.Required {
+			change(SeverityBreaking, path, "The field can no longer be specified.")
+		}
+		if newField.MaxItems > 0 && (oldField.MaxItems == 0 || newField.MaxItems < oldField.MaxItems) {
+			change(SeverityBreaking, path, "The maximum number of items has been reduced from %s to %d.", describeLimit(oldField.MaxItems), newField.MaxItems)
+		}
+		if newField.MinItems > oldField.MinItems {
+			change(SeverityBreaking, path, "The minimum number of items has been increased from %d to %d.", oldField.MinItems, newField.MinItems)
+		}
+		if c.compareValidation {
+			if oldField.Validation == "" && newField.Validation != "" && (newField.Optional || newField.Required) {
+				change(SeverityBreaking, path, "The field is now validated using `%s`.", newField.Validation)
+			}
+			if oldField.Validation != "" && newField.Validation != "" && oldField.Validation != newField.Validation {
+				change(SeverityWarning, path, "The validation has changed from `%s` to `%s`.", oldField.Validation, newField.Validation)
+			}
+
+			// the values accepted are only known for some validation functions (and are omitted where these
+			// couldn't be determined) - so are only compared when known for both versions
+			if oldField.Validation == newField.Validation {
+				if removed := removedValues(oldField.AllowedValues, newField.AllowedValues); len(removed) > 0 {
+					change(SeverityBreaking, path, "The value(s) `%s` are no longer accepted.", strings.Join(removed, "`, `"))
+				}
+				if oldField.Minimum != nil && newField.Minimum != nil && *newField.Minimum > *oldField.Minimum {
+					change(SeverityBreaking, path, "The minimum value has been increased from %d to %d.", *oldField.Minimum, *newField.Minimum)
+				}
+				if oldField.Maximum != nil && newField.Maximum != nil && *newField.Maximum < *oldField.Maximum {
+					change(SeverityBreaking, path, "The maximum value has been reduced from %d to %d.", *oldField.Maximum, *newField.Maximum)
+				}
+			}
+		}
+		if !reflect.DeepEqual(oldField.Default, newField.Default) {
+			change(SeverityWarning, path, "The default value has changed from `%v` to `%v`.", oldField.Default, newField.Default)
+		}
+		if !oldField.Sensitive && newField.Sensitive {
+			change(SeverityWarning, path, "The field is now Sensitive, so outputs referencing it must be marked as sensitive.")
+		}
+		if oldField.Deprecated == "" && newField.Deprecated != "" {
+			change(SeverityWarning, path, "The field has been deprecated: %s", newField.Deprecated)
+		}
+
+		if oldField.Block != nil && newField.Block != nil {
+			changes = append(changes, c.compareSchema(kind, name, path, oldField.Block, newField.Block)...)
+		}
+	}
+
+	for _, field := range sortedKeys(newVersion) {
+		if _, ok := oldVersion[field]; ok {
+			continue
+		}
+
+		path := field
+		if prefix != "" {
+			path = fmt.Sprintf("%s.%s", prefix, field)
+		}
+		if newVersion[field].Required {
+			change(SeverityBreaking, path, "A new Required field has been added.")
+		}
+	}
+
+	return changes
+}
+
+func describeType(input Attribute) string {
+	if input.ElementType != "" {
+		return fmt.Sprintf("%s(%s)", input.Type, input.ElementType)
+	}
+	return input.Type
+}
+
+// removedValues returns the values in the old version which aren't in the new version, when both are known
+func removedValues(oldValues []string, newValues []string) []string {
+	removed := make([]string, 0)
+	if oldValues == nil || newValues == nil {
+		return removed
+	}
+
+	accepted := make(map[string]struct{}, len(newValues))
+	for _, v := range newValues {
+		accepted[v] = struct{}{}
+	}
+	for _, v := range oldValues {
+		if _, ok := accepted[v]; !ok {
+			removed = append(removed, v)
+		}
+	}
+
+	return removed
+}
+
+func describeLimit(input int) string {
+	if input == 0 {
+		return "unlimited"
+	}
+	return fmt.Sprintf("%d", input)
+}
+
+func sortedKeys(input interface{}) []string {
+	keys := make([]string, 0)
+	for _, key := range reflect.ValueOf(input).MapKeys() {
+		keys = append(keys, key.String())
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func writeReport(w io.Writer, changes Changes) error {
+	breaking := len(changes.breaking())
+	warnings := len(changes) - breaking
+
+	output := []string{
+		"# Schema Changes",
+		"",
+		fmt.Sprintf("Found %d breaking change(s) and %d warning(s).", breaking, warnings),
+	}
+
+	if len(changes) > 0 {
+		output = append(output, "", "| Severity | Type | Name | Field | Change |", "| --- | --- | --- | --- | --- |")
+		for _, change := range changes {
+			path := ""
+			if change.Path != "" {
+				path = fmt.Sprintf("`%s`", change.Path)
+			}
+			output = append(output, fmt.Sprintf("| %s | %s | `%s` | %s | %s |", change.Severity, change.Kind, change.Name, path, strings.ReplaceAll(change.Message, "|", "\\|")))
+		}
+	}
+
+	_, err := fmt.Fprintln(w, strings.Join(output, "\n"))
+	return err
+}
@@ -0,0 +1,279 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestCompareCatalogues(t *testing.T) {
+	oldVersion := Catalogue{
+		Resources: map[string]ResourceInfo{
+			"azurerm_example": {
+				Timeouts: map[string]string{
+					"create": "30m0s",
+				},
+				IdFormat: &IdFormat{
+					Name:    "Example",
+					Example: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Example/examples/example1",
+				},
+				Schema: map[string]Attribute{
+					"name": {
+						Type:       "string",
+						Required:   true,
+						ForceNew:   true,
+						Validation: "validation.StringIsNotEmpty",
+					},
+					"sku": {
+						Type:       "string",
+						Optional:   true,
+						Validation: "validation.StringInSlice",
+					},
+					"size": {
+						Type:     "int",
+						Optional: true,
+						Computed: true,
+					},
+					"removed": {
+						Type:     "string",
+						Optional: true,
+					},
+					"block": {
+						Type:     "list",
+						Optional: true,
+						Block: map[string]Attribute{
+							"enabled": {
+								Type:     "bool",
+								Optional: true,
+								Default:  false,
+							},
+						},
+					},
+				},
+			},
+			"azurerm_removed": {},
+		},
+	}
+	newVersion := Catalogue{
+		Resources: map[string]ResourceInfo{
+			"azurerm_example": {
+				Timeouts: map[string]string{
+					"create": "20m0s",
+				},
+				IdFormat: &IdFormat{
+					Name:    "Example",
+					Example: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Example/examples/example1",
+				},
+				Schema: map[string]Attribute{
+					"name": {
+						Type:       "string",
+						Required:   true,
+						ForceNew:   true,
+						Validation: "validation.StringIsNotEmpty",
+					},
+					"sku": {
+						Type:       "string",
+						Required:   true,
+						ForceNew:   true,
+						Validation: "validate.ExampleSku",
+					},
+					"size": {
+						Type:     "string",
+						Optional: true,
+					},
+					"block": {
+						Type:     "list",
+						Optional: true,
+						MaxItems: 1,
+						Block: map[string]Attribute{
+							"enabled": {
+								Type:     "bool",
+								Optional: true,
+								Default:  true,
+							},
+							"new_required": {
+								Type:     "string",
+								Required: true,
+							},
+						},
+					},
+					"new_optional": {
+						Type:     "string",
+						Optional: true,
+					},
+				},
+			},
+		},
+	}
+
+	expected := []string{
+		"Breaking|azurerm_example|block|The maximum number of items has been reduced from unlimited to 1.",
+		"Breaking|azurerm_example|block.new_required|A new Required field has been added.",
+		"Breaking|azurerm_example|removed|The field has been removed.",
+		"Breaking|azurerm_example|size|The type has changed from `int` to `string`.",
+		"Breaking|azurerm_example|size|The field is no longer Computed, so a diff will be shown where this isn't specified.",
+		"Breaking|azurerm_example|sku|The field is now Required.",
+		"Breaking|azurerm_example|sku|Changing the field now forces a new resource to be created.",
+		"Breaking|azurerm_removed||The Resource has been removed.",
+		"Warning|azurerm_example||The default `create` timeout has been reduced from `30m0s` to `20m0s`.",
+		"Warning|azurerm_example|block.enabled|The default value has changed from `false` to `true`.",
+		"Warning|azurerm_example|sku|The validation has changed from `validation.StringInSlice` to `validate.ExampleSku`.",
+	}
+
+	changes := compareCatalogues(oldVersion, newVersion)
+	actual := make([]string, 0)
+	for _, change := range changes {
+		actual = append(actual, strings.Join([]string{string(change.Severity), change.Name, change.Path, change.Message}, "|"))
+	}
+
+	if len(actual) != len(expected) {
+		t.Fatalf("expected %d changes but got %d:\n%s", len(expected), len(actual), strings.Join(actual, "\n"))
+	}
+	for i := range expected {
+		if actual[i] != expected[i] {
+			t.Fatalf("expected change %d to be %q but got %q", i, expected[i], actual[i])
+		}
+	}
+
+	if breaking := len(changes.breaking()); breaking != 8 {
+		t.Fatalf("expected 8 breaking changes but got %d", breaking)
+	}
+}
+
+func TestCompareCataloguesValidationValues(t *testing.T) {
+	intPointer := func(input int) *int {
+		return &input
+	}
+	catalogue := func(sku []string, minimum, maximum *int) Catalogue {
+		return Catalogue{
+			Resources: map[string]ResourceInfo{
+				"azurerm_example": {
+					Schema: map[string]Attribute{
+						"sku": {
+							Type:          "string",
+							Optional:      true,
+							Validation:    "validation.StringInSlice",
+							AllowedValues: sku,
+						},
+						"retention_in_days": {
+							Type:       "int",
+							Optional:   true,
+							Validation: "validation.IntBetween",
+							Minimum:    minimum,
+							Maximum:    maximum,
+						},
+					},
+				},
+			},
+		}
+	}
+
+	testData := []struct {
+		Name       string
+		OldVersion Catalogue
+		NewVersion Catalogue
+		Expected   []string
+	}{
+		{
+			Name:       "widened",
+			OldVersion: catalogue([]string{"Basic", "Standard"}, intPointer(7), intPointer(90)),
+			NewVersion: catalogue([]string{"Basic", "Standard", "Premium"}, intPointer(1), intPointer(365)),
+			Expected:   []string{},
+		},
+		{
+			Name:       "narrowed",
+			OldVersion: catalogue([]string{"Basic", "Standard", "Premium"}, intPointer(1), intPointer(365)),
+			NewVersion: catalogue([]string{"Standard"}, intPointer(7), intPointer(90)),
+			Expected: []string{
+				"Breaking|azurerm_example|retention_in_days|The minimum value has been increased from 1 to 7.",
+				"Breaking|azurerm_example|retention_in_days|The maximum value has been reduced from 365 to 90.",
+				"Breaking|azurerm_example|sku|The value(s) `Basic`, `Premium` are no longer accepted.",
+			},
+		},
+		{
+			Name:       "unknown",
+			OldVersion: catalogue([]string{"Basic", "Standard"}, intPointer(1), intPointer(365)),
+			NewVersion: catalogue(nil, nil, nil),
+			Expected:   []string{},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.Name)
+
+		actual := make([]string, 0)
+		for _, change := range compareCatalogues(v.OldVersion, v.NewVersion) {
+			actual = append(actual, strings.Join([]string{string(change.Severity), change.Name, change.Path, change.Message}, "|"))
+		}
+		if strings.Join(actual, "\n") != strings.Join(v.Expected, "\n") {
+			t.Fatalf("expected:\n%s\n\nbut got:\n%s", strings.Join(v.Expected, "\n"), strings.Join(actual, "\n"))
+		}
+	}
+}
+
+func TestCompareCataloguesWithoutValidation(t *testing.T) {
+	// catalogues exported by older versions of the `schema-export` tool don't contain the validation functions
+	oldVersion := Catalogue{
+		Resources: map[string]ResourceInfo{
+			"azurerm_example": {
+				Schema: map[string]Attribute{
+					"name": {
+						Type:     "string",
+						Required: true,
+					},
+				},
+			},
+		},
+	}
+	newVersion := Catalogue{
+		Resources: map[string]ResourceInfo{
+			"azurerm_example": {
+				Schema: map[string]Attribute{
+					"name": {
+						Type:       "string",
+						Required:   true,
+						Validation: "validation.StringIsNotEmpty",
+					},
+				},
+			},
+		},
+	}
+
+	if changes := compareCatalogues(oldVersion, newVersion); len(changes) != 0 {
+		t.Fatalf("expected no changes but got %+v", changes)
+	}
+}
+
+func TestWriteReport(t *testing.T) {
+	changes := Changes{
+		{
+			Severity: SeverityBreaking,
+			Kind:     "Resource",
+			Name:     "azurerm_example",
+			Path:     "sku",
+			Message:  "The field is now Required.",
+		},
+		{
+			Severity: SeverityWarning,
+			Kind:     "Resource",
+			Name:     "azurerm_example",
+			Message:  "The Resource has been deprecated: use `azurerm_example_v2` | instead",
+		},
+	}
+
+	buf := &bytes.Buffer{}
+	if err := writeReport(buf, changes); err != nil {
+		t.Fatalf("writing report: %+v", err)
+	}
+
+	report := buf.String()
+	for _, expected := range []string{
+		"Found 1 breaking change(s) and 1 warning(s).",
+		"| Breaking | Resource | `azurerm_example` | `sku` | The field is now Required. |",
+		"| Warning | Resource | `azurerm_example` |  | The Resource has been deprecated: use `azurerm_example_v2` \\| instead |",
+	} {
+		if !strings.Contains(report, expected) {
+			t.Fatalf("expected the report to contain %q but got:\n%s", expected, report)
+		}
+	}
+}
@@ -0,0 +1,512 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/provider"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+)
+
+// NOTE: since we're using `go run` for these tools all of the code needs to live within the main.go
+
+func main() {
+	f := flag.NewFlagSet("schema-export", flag.ExitOnError)
+
+	outputPath := f.String("output", "", "The path to the file where the JSON should be written, if omitted this is written to stdout")
+	includeProvider := f.Bool("include-provider", true, "Should the Schema for the Provider block be included?")
+
+	_ = f.Parse(os.Args[1:])
+
+	if err := run(*outputPath, *includeProvider); err != nil {
+		log.Print(err)
+		os.Exit(1)
+	}
+}
+
+func run(outputPath string, includeProvider bool) error {
+	catalogue, err := buildCatalogue(includeProvider)
+	if err != nil {
+		return fmt.Errorf("building catalogue: %+v", err)
+	}
+
+	content, err := json.MarshalIndent(catalogue, "", "  ")
+	if err != nil {
+		return fmt.Errorf("serializing catalogue: %+v", err)
+	}
+
+	if outputPath == "" {
+		_, err = fmt.Fprintln(os.Stdout, string(content))
+		return err
+	}
+
+	return ioutil.WriteFile(outputPath, append(content, '\n'), 0644)
+}
+
+// Catalogue is the complete surface of the Provider, which is consumed by tooling (such as policy linters)
+// and so changes to the structure of this should be backwards compatible
+type Catalogue struct {
+	FormatVersion string                  `json:"format_version"`
+	Provider      map[string]Attribute    `json:"provider,omitempty"`
+	DataSources   map[string]ResourceInfo `json:"data_sources"`
+	Resources     map[string]ResourceInfo `json:"resources"`
+}
+
+type ResourceInfo struct {
+	Service            string               `json:"service"`
+	WebsiteCategories  []string             `json:"website_categories"`
+	Typed              bool                 `json:"typed"`
+	DeprecationMessage string               `json:"deprecation_message,omitempty"`
+	Timeouts           map[string]string    `json:"timeouts,omitempty"`
+	IdFormat           *IdFormat            `json:"id_format,omitempty"`
+	Schema             map[string]Attribute `json:"schema"`
+}
+
+type IdFormat struct {
+	// Name is the name of the Resource ID Parser within the `parse` package, e.g. `ResourceGroup`
+	Name string `json:"name"`
+
+	// Example is an example of this Resource ID
+	Example string `json:"example"`
+}
+
+type Attribute struct {
+	Type          string      `json:"type"`
+	Description   string      `json:"description,omitempty"`
+	Required      bool        `json:"required,omitempty"`
+	Optional      bool        `json:"optional,omitempty"`
+	Computed      bool        `json:"computed,omitempty"`
+	ForceNew      bool        `json:"force_new,omitempty"`
+	Sensitive     bool        `json:"sensitive,omitempty"`
+	Deprecated    string      `json:"deprecated,omitempty"`
+	Default       interface{} `json:"default,omitempty"`
+	MinItems      int         `json:"min_items,omitempty"`
+	MaxItems      int         `json:"max_items,omitempty"`
+	ConflictsWith []string    `json:"conflicts_with,omitempty"`
+	ExactlyOneOf  []string    `json:"exactly_one_of,omitempty"`
+	AtLeastOneOf  []string    `json:"at_least_one_of,omitempty"`
+	RequiredWith  []string    `json:"required_with,omitempty"`
+
+	// Validation is the name of the Validation Function used for this field (or the elements of it)
+	// e.g. `validation.StringInSlice` - see probeValidation for the values being validated against
+	Validation string `json:"validation,omitempty"`
+
+	// AllowedValues are the values accepted by `validation.StringInSlice`
+	AllowedValues []string `json:"allowed_values,omitempty"`
+
+	// Minimum and Maximum are the bounds accepted by `validation.IntBetween`, `validation.IntAtLeast`
+	// and `validation.IntAtMost`
+	Minimum *int `json:"minimum,omitempty"`
+	Maximum *int `json:"maximum,omitempty"`
+
+	ElementType string               `json:"element_type,omitempty"`
+	Block       map[string]Attribute `json:"block,omitempty"`
+}
+
+const formatVersion = "1.0"
+
+func buildCatalogue(includeProvider bool) (*Catalogue, error) {
+	catalogue := Catalogue{
+		FormatVersion: formatVersion,
+		DataSources:   map[string]ResourceInfo{},
+		Resources:     map[string]ResourceInfo{},
+	}
+
+	// the Data Sources and Resources are retrieved from the Provider (rather than the Services) since the Provider
+	// adds fields to these (e.g. `tags_all`) - but the Services are used to determine which Service these belong to
+	azureProvider := provider.AzureProvider()
+	if includeProvider {
+		catalogue.Provider = flattenSchema(azureProvider.Schema)
+	}
+	dataSourceFor := func(name string, fallback *schema.Resource) *schema.Resource {
+		if v, ok := azureProvider.DataSourcesMap[name]; ok {
+			return v
+		}
+		return fallback
+	}
+	resourceFor := func(name string, fallback *schema.Resource) *schema.Resource {
+		if v, ok := azureProvider.ResourcesMap[name]; ok {
+			return v
+		}
+		return fallback
+	}
+
+	ids := newIdFormatLookup()
+
+	for _, service := range provider.SupportedTypedServices() {
+		for _, ds := range service.DataSources() {
+			wrapper := sdk.NewDataSourceWrapper(ds)
+			dataSource, err := wrapper.DataSource()
+			if err != nil {
+				return nil, fmt.Errorf("wrapping Data Source %q: %+v", ds.ResourceType(), err)
+			}
+			catalogue.DataSources[ds.ResourceType()] = flattenResource(service.Name(), service.WebsiteCategories(), true, dataSourceFor(ds.ResourceType(), dataSource), nil)
+		}
+
+		// List Data Sources are looked up by name (rather than using `sdk.TypedServiceRegistrationWithListDataSources`)
+		// since `schema-diff` runs this tool against older revisions of the Provider, which don't support these
+		for _, name := range listDataSourceTypes(service) {
+			if ds, ok := azureProvider.DataSourcesMap[name]; ok {
+				catalogue.DataSources[name] = flattenResource(service.Name(), service.WebsiteCategories(), true, ds, nil)
+			}
+		}
+
+		for _, rs := range service.Resources() {
+			wrapper := sdk.NewResourceWrapper(rs)
+			resource, err := wrapper.Resource()
+			if err != nil {
+				return nil, fmt.Errorf("wrapping Resource %q: %+v", rs.ResourceType(), err)
+			}
+			idFormat := ids.forValidationFunc(rs.IDValidationFunc())
+			catalogue.Resources[rs.ResourceType()] = flattenResource(service.Name(), service.WebsiteCategories(), true, resourceFor(rs.ResourceType(), resource), idFormat)
+		}
+	}
+
+	for _, service := range provider.SupportedUntypedServices() {
+		for name, ds := range service.SupportedDataSources() {
+			catalogue.DataSources[name] = flattenResource(service.Name(), service.WebsiteCategories(), false, dataSourceFor(name, ds), nil)
+		}
+
+		for name, rs := range service.SupportedResources() {
+			// the ID Format is determined from the Resource defined by the Service, since the Provider wraps the CRUD functions
+			idFormat := ids.forResource(rs)
+			catalogue.Resources[name] = flattenResource(service.Name(), service.WebsiteCategories(), false, resourceFor(name, rs), idFormat)
+		}
+	}
+
+	return &catalogue, nil
+}
+
+// listDataSourceTypes returns the names of the List Data Sources supported by the specified Service (if any)
+func listDataSourceTypes(service interface{}) []string {
+	method := reflect.ValueOf(service).MethodByName("ListDataSources")
+	if !method.IsValid() || method.Type().NumIn() != 0 || method.Type().NumOut() != 1 || method.Type().Out(0).Kind() != reflect.Slice {
+		return nil
+	}
+
+	names := make([]string, 0)
+	items := method.Call(nil)[0]
+	for i := 0; i < items.Len(); i++ {
+		resourceType := items.Index(i).MethodByName("ResourceType")
+		if !resourceType.IsValid() || resourceType.Type().NumIn() != 0 || resourceType.Type().NumOut() != 1 {
+			continue
+		}
+		names = append(names, resourceType.Call(nil)[0].String())
+	}
+	return names
+}
+
+func flattenResource(serviceName string, websiteCategories []string, typed bool, resource *schema.Resource, idFormat *IdFormat) ResourceInfo {
+	categories := append([]string{}, websiteCategories...)
+	sort.Strings(categories)
+
+	return ResourceInfo{
+		Service:            serviceName,
+		WebsiteCategories:  categories,
+		Typed:              typed,
+		DeprecationMessage: resource.DeprecationMessage,
+		Timeouts:           flattenTimeouts(resource.Timeouts),
+		IdFormat:           idFormat,
+		Schema:             flattenSchema(resource.Schema),
+	}
+}
+
+func flattenTimeouts(input *schema.ResourceTimeout) map[string]string {
+	if input == nil {
+		return nil
+	}
+
+	output := make(map[string]string)
+	timeouts := map[string]*time.Duration{
+		"create": input.Create,
+		"read":   input.Read,
+		"update": input.Update,
+		"delete": input.Delete,
+	}
+	for operation, timeout := range timeouts {
+		if timeout != nil {
+			output[operation] = timeout.String()
+		}
+	}
+	return output
+}
+
+func flattenSchema(input map[string]*schema.Schema) map[string]Attribute {
+	output := make(map[string]Attribute, len(input))
+	for name, field := range input {
+		output[name] = flattenAttribute(field)
+	}
+	return output
+}
+
+func flattenAttribute(input *schema.Schema) Attribute {
+	output := Attribute{
+		Type:          typeName(input.Type),
+		Description:   input.Description,
+		Required:      input.Required,
+		Optional:      input.Optional,
+		Computed:      input.Computed,
+		ForceNew:      input.ForceNew,
+		Sensitive:     input.Sensitive,
+		Deprecated:    input.Deprecated,
+		Default:       input.Default,
+		MinItems:      input.MinItems,
+		MaxItems:      input.MaxItems,
+		ConflictsWith: input.ConflictsWith,
+		ExactlyOneOf:  input.ExactlyOneOf,
+		AtLeastOneOf:  input.AtLeastOneOf,
+		RequiredWith:  input.RequiredWith,
+	}
+
+	if input.ValidateFunc != nil {
+		output.Validation = validationName(input.ValidateFunc)
+		probeValidation(input.ValidateFunc, &output)
+	} else if input.ValidateDiagFunc != nil {
+		output.Validation = validationName(input.ValidateDiagFunc)
+	}
+
+	switch elem := input.Elem.(type) {
+	case *schema.Schema:
+		output.ElementType = typeName(elem.Type)
+		if output.Validation == "" && elem.ValidateFunc != nil {
+			output.Validation = validationName(elem.ValidateFunc)
+			probeValidation(elem.ValidateFunc, &output)
+		}
+	case *schema.Resource:
+		output.Block = flattenSchema(elem.Schema)
+	}
+
+	return output
+}
+
+// validationName returns the name of the Validation Function without the package path, e.g. `validation.StringInSlice`
+func validationName(input interface{}) string {
+	function := functionForPointer(input)
+	if function == nil {
+		return "unknown"
+	}
+
+	name := function.Name()
+	name = name[strings.LastIndex(name, "/")+1:]
+
+	// closures returned by a function (e.g. `validation.StringInSlice.func1`) are named after that function
+	segments := strings.Split(name, ".")
+	for len(segments) > 2 && strings.HasPrefix(segments[len(segments)-1], "func") {
+		segments = segments[:len(segments)-1]
+	}
+	return strings.Join(segments, ".")
+}
+
+var (
+	stringInSliceRegex = regexp.MustCompile(`to be one of \[(.*)\], got `)
+	intBetweenRegex    = regexp.MustCompile(`to be in the range \((-?\d+) - (-?\d+)\)`)
+	intAtLeastRegex    = regexp.MustCompile(`to be at least \((-?\d+)\)`)
+	intAtMostRegex     = regexp.MustCompile(`to be at most \((-?\d+)\)`)
+)
+
+// probeValidation determines the values accepted by the common Validation Functions - since the arguments passed
+// to these can't be retrieved, these are instead called with a value which is invalid and the error is parsed
+func probeValidation(validate schema.SchemaValidateFunc, output *Attribute) {
+	switch output.Validation {
+	case "validation.StringInSlice":
+		output.AllowedValues = probeAllowedValues(validate)
+
+	case "validation.IntBetween":
+		if match := intBetweenRegex.FindStringSubmatch(probeError(validate, math.MinInt32)); match != nil {
+			output.Minimum = parseBound(match[1])
+			output.Maximum = parseBound(match[2])
+		}
+
+	case "validation.IntAtLeast":
+		if match := intAtLeastRegex.FindStringSubmatch(probeError(validate, math.MinInt32)); match != nil {
+			output.Minimum = parseBound(match[1])
+		}
+
+	case "validation.IntAtMost":
+		if match := intAtMostRegex.FindStringSubmatch(probeError(validate, math.MaxInt32)); match != nil {
+			output.Maximum = parseBound(match[1])
+		}
+	}
+}
+
+// probeAllowedValues returns the values accepted by `validation.StringInSlice` - which are listed in the error
+// separated by a space, so since the values can contain spaces each candidate is confirmed against the validator
+func probeAllowedValues(validate schema.SchemaValidateFunc) []string {
+	match := stringInSliceRegex.FindStringSubmatch(probeError(validate, "\x00"))
+	if match == nil {
+		return nil
+	}
+
+	values := make([]string, 0)
+	candidate := make([]string, 0)
+	for _, segment := range strings.Split(match[1], " ") {
+		candidate = append(candidate, segment)
+		value := strings.Join(candidate, " ")
+		if _, errs := validate(value, "probe"); len(errs) == 0 {
+			values = append(values, value)
+			candidate = make([]string, 0)
+		}
+	}
+	// the values couldn't be determined, so these aren't exported rather than being incorrect
+	if len(candidate) > 0 {
+		return nil
+	}
+
+	return values
+}
+
+func probeError(validate schema.SchemaValidateFunc, value interface{}) string {
+	_, errs := validate(value, "probe")
+	if len(errs) == 0 {
+		return ""
+	}
+	return errs[0].Error()
+}
+
+func parseBound(input string) *int {
+	v, err := strconv.Atoi(input)
+	if err != nil {
+		return nil
+	}
+	return &v
+}
+
+func typeName(input schema.ValueType) string {
+	switch input {
+	case schema.TypeBool:
+		return "bool"
+	case schema.TypeInt:
+		return "int"
+	case schema.TypeFloat:
+		return "float"
+	case schema.TypeString:
+		return "string"
+	case schema.TypeList:
+		return "list"
+	case schema.TypeMap:
+		return "map"
+	case schema.TypeSet:
+		return "set"
+	}
+
+	return "unknown"
+}
+
+// idFormatLookup determines the format of the Resource ID used by a Resource from the examples specified
+// when generating the Resource ID Parsers (in the `resourceids.go` file within each Service Package)
+type idFormatLookup struct {
+	// examples is a map of Service Package directory to the example for each Resource ID (keyed by name)
+	examples map[string]map[string]string
+}
+
+var (
+	resourceIdGeneratorRegex = regexp.MustCompile(`-name=(\w+)\s+-id=(\S+)`)
+	importerRegex            = regexp.MustCompile(`ImporterValidatingResourceId(?:Then)?\(func\(id string\) error \{\s*_, err := (?:\w+\.)?(\w+)ID\(id\)`)
+)
+
+func newIdFormatLookup() idFormatLookup {
+	return idFormatLookup{
+		examples: map[string]map[string]string{},
+	}
+}
+
+// forValidationFunc returns the ID Format for a Typed Resource from it's ID Validation Function (e.g. `validate.ProviderID`)
+func (l idFormatLookup) forValidationFunc(input interface{}) *IdFormat {
+	function := functionForPointer(input)
+	if function == nil {
+		return nil
+	}
+
+	file, _ := function.FileLine(function.Entry())
+	serviceDirectory := filepath.Dir(filepath.Dir(file))
+	name := function.Name()
+	name = strings.TrimSuffix(name[strings.LastIndex(name, ".")+1:], "ID")
+
+	return l.find(serviceDirectory, name)
+}
+
+// forResource returns the ID Format for an Untyped Resource from the Importer defined in the file containing it's Read function
+func (l idFormatLookup) forResource(resource *schema.Resource) *IdFormat {
+	function := functionForPointer(resource.ReadContext)
+	//lint:ignore SA1019 SDKv2 migration - staticcheck's own linter directives are currently being ignored under golanci-lint
+	if resource.Read != nil { //nolint:staticcheck
+		function = functionForPointer(resource.Read) //nolint:staticcheck
+	}
+	if function == nil {
+		return nil
+	}
+
+	file, _ := function.FileLine(function.Entry())
+	contents, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil
+	}
+
+	names := map[string]struct{}{}
+	for _, match := range importerRegex.FindAllStringSubmatch(string(contents), -1) {
+		names[match[1]] = struct{}{}
+	}
+	// where multiple Resources are defined in the same file the ID Format can't be determined
+	if len(names) != 1 {
+		return nil
+	}
+
+	for name := range names {
+		return l.find(filepath.Dir(file), name)
+	}
+	return nil
+}
+
+func (l idFormatLookup) find(serviceDirectory string, name string) *IdFormat {
+	if example, ok := l.examplesForService(serviceDirectory)[name]; ok {
+		return &IdFormat{
+			Name:    name,
+			Example: example,
+		}
+	}
+
+	return nil
+}
+
+func (l idFormatLookup) examplesForService(serviceDirectory string) map[string]string {
+	if v, ok := l.examples[serviceDirectory]; ok {
+		return v
+	}
+
+	examples := map[string]string{}
+	if contents, err := ioutil.ReadFile(filepath.Join(serviceDirectory, "resourceids.go")); err == nil {
+		for _, match := range resourceIdGeneratorRegex.FindAllStringSubmatch(string(contents), -1) {
+			examples[match[1]] = match[2]
+		}
+	}
+
+	l.examples[serviceDirectory] = examples
+	return examples
+}
+
+func functionForPointer(input interface{}) *runtime.Func {
+	if input == nil {
+		return nil
+	}
+
+	value := reflect.ValueOf(input)
+	if value.Kind() != reflect.Func || value.IsNil() {
+		return nil
+	}
+
+	return runtime.FuncForPC(value.Pointer())
+}
@@ -0,0 +1,242 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func TestFlattenAttribute(t *testing.T) {
+	input := &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		ForceNew: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"password": {
+					Type:         schema.TypeString,
+					Required:     true,
+					Sensitive:    true,
+					ValidateFunc: validation.StringLenBetween(8, 128),
+				},
+				"names": {
+					Type:       schema.TypeSet,
+					Computed:   true,
+					Deprecated: "this field is deprecated",
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+			},
+		},
+	}
+
+	expected := Attribute{
+		Type:     "list",
+		Optional: true,
+		ForceNew: true,
+		MaxItems: 1,
+		Block: map[string]Attribute{
+			"password": {
+				Type:       "string",
+				Required:   true,
+				Sensitive:  true,
+				Validation: "validation.StringLenBetween",
+			},
+			"names": {
+				Type:        "set",
+				Computed:    true,
+				Deprecated:  "this field is deprecated",
+				ElementType: "string",
+			},
+		},
+	}
+
+	if actual := flattenAttribute(input); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %+v but got %+v", expected, actual)
+	}
+}
+
+func TestProbeValidation(t *testing.T) {
+	intPointer := func(input int) *int {
+		return &input
+	}
+
+	testData := []struct {
+		Name     string
+		Validate schema.SchemaValidateFunc
+		Expected Attribute
+	}{
+		{
+			Name:     "StringInSlice",
+			Validate: validation.StringInSlice([]string{"Basic", "Standard", "Premium"}, false),
+			Expected: Attribute{
+				Validation:    "validation.StringInSlice",
+				AllowedValues: []string{"Basic", "Standard", "Premium"},
+			},
+		},
+		{
+			Name:     "StringInSlice with spaces",
+			Validate: validation.StringInSlice([]string{"First Value", "Second", "Third  Value"}, true),
+			Expected: Attribute{
+				Validation:    "validation.StringInSlice",
+				AllowedValues: []string{"First Value", "Second", "Third  Value"},
+			},
+		},
+		{
+			Name:     "IntBetween",
+			Validate: validation.IntBetween(-1, 365),
+			Expected: Attribute{
+				Validation: "validation.IntBetween",
+				Minimum:    intPointer(-1),
+				Maximum:    intPointer(365),
+			},
+		},
+		{
+			Name:     "IntAtLeast",
+			Validate: validation.IntAtLeast(1),
+			Expected: Attribute{
+				Validation: "validation.IntAtLeast",
+				Minimum:    intPointer(1),
+			},
+		},
+		{
+			Name:     "IntAtMost",
+			Validate: validation.IntAtMost(100),
+			Expected: Attribute{
+				Validation: "validation.IntAtMost",
+				Maximum:    intPointer(100),
+			},
+		},
+		{
+			Name:     "StringIsNotEmpty",
+			Validate: validation.StringIsNotEmpty,
+			Expected: Attribute{
+				Validation: "validation.StringIsNotEmpty",
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.Name)
+
+		actual := Attribute{
+			Validation: validationName(v.Validate),
+		}
+		probeValidation(v.Validate, &actual)
+		if !reflect.DeepEqual(actual, v.Expected) {
+			t.Fatalf("expected %+v but got %+v", v.Expected, actual)
+		}
+	}
+}
+
+func TestIdFormatLookup(t *testing.T) {
+	directory, err := ioutil.TempDir("", "schema-export")
+	if err != nil {
+		t.Fatalf("creating temp directory: %+v", err)
+	}
+	defer os.RemoveAll(directory)
+
+	resourceIds := `package example
+
+//go:generate go run ../../tools/generator-resource-id/main.go -path=./ -name=Server -id=/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Example/servers/server1
+//go:generate go run ../../tools/generator-resource-id/main.go -path=./ -name=ServerDatabase -id=/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Example/servers/server1/databases/database1 -rewrite=true
+`
+	if err := ioutil.WriteFile(filepath.Join(directory, "resourceids.go"), []byte(resourceIds), 0644); err != nil {
+		t.Fatalf("writing resourceids.go: %+v", err)
+	}
+
+	lookup := newIdFormatLookup()
+	testData := []struct {
+		Name     string
+		Expected *IdFormat
+	}{
+		{
+			Name: "Server",
+			Expected: &IdFormat{
+				Name:    "Server",
+				Example: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Example/servers/server1",
+			},
+		},
+		{
+			Name: "ServerDatabase",
+			Expected: &IdFormat{
+				Name:    "ServerDatabase",
+				Example: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Example/servers/server1/databases/database1",
+			},
+		},
+		{
+			Name:     "DoesNotExist",
+			Expected: nil,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.Name)
+
+		if actual := lookup.find(directory, v.Name); !reflect.DeepEqual(actual, v.Expected) {
+			t.Fatalf("expected %+v but got %+v", v.Expected, actual)
+		}
+	}
+}
+
+func TestImporterRegex(t *testing.T) {
+	testData := []struct {
+		Input    string
+		Expected string
+	}{
+		{
+			Input: `		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			_, err := parse.ServerDatabaseID(id)
+			return err
+		}),`,
+			Expected: "ServerDatabase",
+		},
+		{
+			Input: `		Importer: pluginsdk.ImporterValidatingResourceIdThen(func(id string) error {
+			_, err := parse.VirtualMachineID(id)
+			return err
+		}, importVirtualMachine(compute.Linux, "azurerm_linux_virtual_machine")),`,
+			Expected: "VirtualMachine",
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.Expected)
+
+		matches := importerRegex.FindStringSubmatch(v.Input)
+		if len(matches) != 2 || matches[1] != v.Expected {
+			t.Fatalf("expected the parser `%s` but got %+v", v.Expected, matches)
+		}
+	}
+}
+
+func TestBuildCatalogue(t *testing.T) {
+	catalogue, err := buildCatalogue(true)
+	if err != nil {
+		t.Fatalf("building catalogue: %+v", err)
+	}
+
+	resourceGroup, ok := catalogue.Resources["azurerm_resource_group"]
+	if !ok {
+		t.Fatalf("expected the Resource `azurerm_resource_group` to be exported")
+	}
+	if resourceGroup.IdFormat == nil || resourceGroup.IdFormat.Name != "ResourceGroup" {
+		t.Fatalf("expected the ID Format for `azurerm_resource_group` to be `ResourceGroup` but got %+v", resourceGroup.IdFormat)
+	}
+	if !resourceGroup.Schema["name"].ForceNew {
+		t.Fatalf("expected the `name` field for `azurerm_resource_group` to be ForceNew")
+	}
+	if _, ok := catalogue.DataSources["azurerm_resource_group"]; !ok {
+		t.Fatalf("expected the Data Source `azurerm_resource_group` to be exported")
+	}
+	if _, ok := catalogue.Provider["features"]; !ok {
+		t.Fatalf("expected the Provider block to be exported")
+	}
+}